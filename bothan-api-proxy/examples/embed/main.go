@@ -0,0 +1,59 @@
+// Command embed runs the proxy inside another program, observes its lifecycle through hooks
+// and customizes the JSON of its responses.
+//
+//	go run ./examples/embed -grpc localhost:50051 -listen 127.0.0.1:8081
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/bandprotocol/bothan/bothan-api-proxy/proxy"
+)
+
+// hooks logs the events the embedding program is interested in.
+type hooks struct {
+	proxy.NopHooks
+}
+
+func (hooks) OnStart(e proxy.StartEvent) {
+	log.Println("proxy listening on", e.Addr)
+}
+
+func (hooks) OnUpstreamLost(e proxy.UpstreamEvent) {
+	log.Println("lost connection to", e.Target)
+}
+
+func (hooks) OnRequestCompleted(e proxy.RequestEvent) {
+	log.Println(e.Method, e.Path, e.Status, e.Duration, e.Consumer.Name)
+}
+
+func main() {
+	grpcAddr := flag.String("grpc", "localhost:50051", "address of the Bothan gRPC server")
+	listenAddr := flag.String("listen", "127.0.0.1:8081", "address the proxy listens on")
+	flag.Parse()
+
+	server, err := proxy.New(proxy.Config{
+		Grpc:    proxy.GrpcConfig{Addr: *grpcAddr},
+		GoProxy: proxy.GoProxyConfig{Addr: *listenAddr},
+	}, hooks{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	server.AddServeMuxOptions(runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+		MarshalOptions: protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+	}))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := server.Run(ctx); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -1,21 +1,41 @@
-module go-proxy
+module github.com/bandprotocol/bothan/bothan-api-proxy
 
 go 1.22.0
 
 require (
-	github.com/bandprotocol/bothan/bothan-api/client/go-client v0.0.1
+	github.com/bandprotocol/bothan/bothan-api/client/go-client v0.1.0
+	github.com/bandprotocol/bothan/bothan-api/client/go-client/query v0.1.0
+	github.com/bandprotocol/bothan/bothan-api/client/go-client/v2 v2.0.0
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.19.1
 	github.com/pelletier/go-toml v1.9.5
+	github.com/prometheus/client_golang v1.19.1
+	go.etcd.io/bbolt v1.3.10
+	golang.org/x/net v0.24.0
+	golang.org/x/sys v0.19.0
+	golang.org/x/time v0.5.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240429193739-8cf5692501f6
 	google.golang.org/grpc v1.63.2
+	google.golang.org/protobuf v1.34.1
 )
 
 require (
-	golang.org/x/net v0.24.0 // indirect
-	golang.org/x/sys v0.19.0 // indirect
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/google/go-querystring v1.1.0 // indirect
+	github.com/json-iterator/go v1.1.12 // indirect
+	github.com/levigross/grequests v0.0.0-20231203190023-9c307ef1f48d // indirect
+	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
+	github.com/prometheus/client_model v0.5.0 // indirect
+	github.com/prometheus/common v0.48.0 // indirect
+	github.com/prometheus/procfs v0.12.0 // indirect
 	golang.org/x/text v0.14.0 // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20240506185236-b8a5c65736ae // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20240429193739-8cf5692501f6 // indirect
-	google.golang.org/protobuf v1.34.1 // indirect
 )
 
 replace github.com/bandprotocol/bothan/bothan-api/client/go-client => ../bothan-api/client/go-client
+
+replace github.com/bandprotocol/bothan/bothan-api/client/go-client/v2 => ../bothan-api/client/go-client/v2
+
+replace github.com/bandprotocol/bothan/bothan-api/client/go-client/query => ../bothan-api/client/go-client/query
@@ -2,78 +2,227 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
-	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/pelletier/go-toml"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/grpclog"
 
-	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+	"github.com/bandprotocol/bothan/bothan-api-proxy/proxy"
 )
 
-type GrpcConfig struct {
-	Addr string `toml:"addr"`
+const configPath = "./config.toml"
+
+// logHooks prints the lifecycle events of the proxy.
+type logHooks struct {
+	proxy.NopHooks
 }
 
-type GoProxyConfig struct {
-	Addr string `toml:"addr"`
+func (logHooks) OnStart(e proxy.StartEvent) {
+	fmt.Println("Server running on", e.Addr)
 }
 
-func run(grpcConfig GrpcConfig, goProxyConfig GoProxyConfig) error {
-	ctx := context.Background()
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+func (logHooks) OnUpstreamConnected(e proxy.UpstreamEvent) {
+	fmt.Println("Connected to upstream", e.Target)
+}
 
-	// Register gRPC server endpoint
-	// Note: Make sure the gRPC server is running properly and accessible
-	mux := runtime.NewServeMux()
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
-	err := query.RegisterQueryHandlerFromEndpoint(ctx, mux, grpcConfig.Addr, opts)
-	if err != nil {
-		return err
-	}
+func (logHooks) OnUpstreamLost(e proxy.UpstreamEvent) {
+	fmt.Println("Lost connection to upstream", e.Target, "state:", e.State)
+}
 
-	fmt.Println("Server running on", goProxyConfig.Addr)
+func (logHooks) OnUpstreamSwitched(e proxy.UpstreamSwitchEvent) {
+	fmt.Println("Switched upstream from", e.From, "to", e.To)
+}
 
-	// Start HTTP server (and proxy calls to gRPC server endpoint)
-	return http.ListenAndServe(goProxyConfig.Addr, mux)
+func (logHooks) OnConfigReloaded(proxy.ConfigReloadedEvent) {
+	fmt.Println("Configuration reloaded")
 }
 
-func main() {
-	config, err := toml.LoadFile("./config.toml")
+func loadConfig(path string) (proxy.Config, error) {
+	config, err := toml.LoadFile(path)
 	if err != nil {
-		fmt.Println("Error loading TOML file:", err)
-		return
+		return proxy.Config{}, fmt.Errorf("error loading TOML file: %w", err)
 	}
 
 	grpcTable, ok := config.Get("grpc").(*toml.Tree)
 	if !ok {
-		fmt.Println("gRPC configuration not found in TOML file")
-		return
+		return proxy.Config{}, errors.New("gRPC configuration not found in TOML file")
 	}
 
-	grpcConfig := GrpcConfig{}
+	grpcConfig := proxy.GrpcConfig{}
 	if err := grpcTable.Unmarshal(&grpcConfig); err != nil {
-		fmt.Println("Error parsing gRPC config:", err)
-		return
+		return proxy.Config{}, fmt.Errorf("error parsing gRPC config: %w", err)
 	}
 
 	goProxyTable, ok := config.Get("go-proxy").(*toml.Tree)
 	if !ok {
-		fmt.Println("goProxy configuration not found in TOML file")
-		return
+		return proxy.Config{}, errors.New("goProxy configuration not found in TOML file")
 	}
 
-	goProxyConfig := GoProxyConfig{}
+	goProxyConfig := proxy.GoProxyConfig{}
 	if err := goProxyTable.Unmarshal(&goProxyConfig); err != nil {
-		fmt.Println("Error parsing goProxy config:", err)
+		return proxy.Config{}, fmt.Errorf("error parsing goProxy config: %w", err)
+	}
+
+	// The remaining sections are optional and fall back to their defaults if omitted.
+	usageConfig := proxy.UsageConfig{}
+	if err := unmarshalOptional(config, "usage", &usageConfig); err != nil {
+		return proxy.Config{}, err
+	}
+
+	tunnelConfig := proxy.TunnelConfig{}
+	if err := unmarshalOptional(config, "tunnel", &tunnelConfig); err != nil {
+		return proxy.Config{}, err
+	}
+
+	chaosConfig := proxy.ChaosConfig{}
+	if err := unmarshalOptional(config, "chaos", &chaosConfig); err != nil {
+		return proxy.Config{}, err
+	}
+
+	timeoutConfig := proxy.TimeoutConfig{}
+	if err := unmarshalOptional(config, "timeouts", &timeoutConfig); err != nil {
+		return proxy.Config{}, err
+	}
+
+	tlsConfig := proxy.TLSConfig{}
+	if err := unmarshalOptional(config, "tls", &tlsConfig); err != nil {
+		return proxy.Config{}, err
+	}
+
+	requestLogConfig := proxy.RequestLogConfig{}
+	if err := unmarshalOptional(config, "request_log", &requestLogConfig); err != nil {
+		return proxy.Config{}, err
+	}
+
+	costConfig := proxy.CostConfig{}
+	if err := unmarshalOptional(config, "cost", &costConfig); err != nil {
+		return proxy.Config{}, err
+	}
+
+	signalWatchConfig := proxy.SignalWatchConfig{}
+	if err := unmarshalOptional(config, "signal_watch", &signalWatchConfig); err != nil {
+		return proxy.Config{}, err
+	}
+
+	redactionConfig := proxy.RedactionConfig{}
+	if err := unmarshalOptional(config, "redaction", &redactionConfig); err != nil {
+		return proxy.Config{}, err
+	}
+
+	readinessConfig := proxy.ReadinessConfig{}
+	if err := unmarshalOptional(config, "readiness", &readinessConfig); err != nil {
+		return proxy.Config{}, err
+	}
+
+	idTranslationConfig := proxy.IDTranslationConfig{}
+	if err := unmarshalOptional(config, "id_translation", &idTranslationConfig); err != nil {
+		return proxy.Config{}, err
+	}
+
+	recentConfig := proxy.RecentConfig{}
+	if err := unmarshalOptional(config, "recent", &recentConfig); err != nil {
+		return proxy.Config{}, err
+	}
+
+	routingConfig := proxy.RoutingConfig{}
+	if err := unmarshalOptional(config, "routing", &routingConfig); err != nil {
+		return proxy.Config{}, err
+	}
+
+	apiKeysConfig := proxy.APIKeysConfig{}
+	if err := unmarshalOptional(config, "api_keys", &apiKeysConfig); err != nil {
+		return proxy.Config{}, err
+	}
+
+	accessLogConfig := proxy.AccessLogConfig{}
+	if err := unmarshalOptional(config, "access_log", &accessLogConfig); err != nil {
+		return proxy.Config{}, err
+	}
+
+	stalenessConfig := proxy.StalenessConfig{}
+	if err := unmarshalOptional(config, "staleness", &stalenessConfig); err != nil {
+		return proxy.Config{}, err
+	}
+
+	return proxy.Config{
+		Grpc:          grpcConfig,
+		GoProxy:       goProxyConfig,
+		Usage:         usageConfig,
+		Tunnel:        tunnelConfig,
+		Chaos:         chaosConfig,
+		Timeouts:      timeoutConfig,
+		TLS:           tlsConfig,
+		RequestLog:    requestLogConfig,
+		Cost:          costConfig,
+		SignalWatch:   signalWatchConfig,
+		Redaction:     redactionConfig,
+		Readiness:     readinessConfig,
+		IDTranslation: idTranslationConfig,
+		Recent:        recentConfig,
+		Routing:       routingConfig,
+		APIKeys:       apiKeysConfig,
+		AccessLog:     accessLogConfig,
+		Staleness:     stalenessConfig,
+	}, nil
+}
+
+// unmarshalOptional unmarshals the named table into v if it is present in the config.
+func unmarshalOptional(config *toml.Tree, name string, v interface{}) error {
+	table, ok := config.Get(name).(*toml.Tree)
+	if !ok {
+		return nil
+	}
+
+	if err := table.Unmarshal(v); err != nil {
+		return fmt.Errorf("error parsing %s config: %w", name, err)
+	}
+
+	return nil
+}
+
+func main() {
+	config, err := loadConfig(configPath)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	server, err := proxy.New(config, logHooks{})
+	if err != nil {
+		grpclog.Fatal(err)
+	}
+
+	if isService, err := runService(server); isService || err != nil {
+		if err != nil {
+			grpclog.Fatal(err)
+		}
 		return
 	}
 
-	if err := run(grpcConfig, goProxyConfig); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Reload the configuration on SIGHUP.
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			config, err := loadConfig(configPath)
+			if err != nil {
+				fmt.Println("Error reloading config:", err)
+				continue
+			}
+			if err := server.Reload(config); err != nil {
+				fmt.Println("Error reloading config:", err)
+			}
+		}
+	}()
+
+	if err := server.Run(ctx); err != nil {
 		grpclog.Fatal(err)
 	}
 }
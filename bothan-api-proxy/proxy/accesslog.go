@@ -0,0 +1,82 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/grpclog"
+)
+
+// AccessLogConfig enables the access log of the proxy, which bothanctl replay reads to replay
+// the captured traffic against another deployment.
+type AccessLogConfig struct {
+	// Path is the file the requests are appended to as JSON lines. Empty disables the log.
+	Path string `toml:"path"`
+}
+
+// AccessLogEntry is a line of the access log. API keys and consumer names are left out.
+type AccessLogEntry struct {
+	// Time is when the request started.
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	Path   string    `json:"path"`
+	Query  string    `json:"query,omitempty"`
+	Status int       `json:"status"`
+	// DurationMs is how long the proxy took to serve the request, in milliseconds.
+	DurationMs float64 `json:"duration_ms"`
+}
+
+// accessLog writes a line per completed request to the access log. A nil accessLog writes
+// nothing.
+type accessLog struct {
+	NopHooks
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+func openAccessLog(config AccessLogConfig) (*accessLog, error) {
+	if config.Path == "" {
+		return nil, nil
+	}
+
+	file, err := os.OpenFile(config.Path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening the access log: %w", err)
+	}
+	return &accessLog{file: file}, nil
+}
+
+func (l *accessLog) OnRequestCompleted(e RequestEvent) {
+	line, err := json.Marshal(AccessLogEntry{
+		Time:       time.Now().Add(-e.Duration).UTC(),
+		Method:     e.Method,
+		Path:       e.Path,
+		Query:      e.Query,
+		Status:     e.Status,
+		DurationMs: float64(e.Duration.Microseconds()) / 1000,
+	})
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		grpclog.Errorf("error writing the access log: %v", err)
+	}
+}
+
+// Close closes the access log.
+func (l *accessLog) Close() error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
@@ -0,0 +1,52 @@
+package proxy
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAccessLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	l, err := openAccessLog(AccessLogConfig{Path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bus := NewEventBus(l)
+	bus.OnRequestCompleted(RequestEvent{Method: "GET", Path: "/prices/btc", Query: "status=available", Status: 200, Duration: 1500 * time.Microsecond})
+	bus.OnRequestCompleted(RequestEvent{Method: "GET", Path: "/signals", Status: 503, Duration: time.Millisecond})
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var entries []AccessLogEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry AccessLogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	first := entries[0]
+	if first.Method != "GET" || first.Path != "/prices/btc" || first.Query != "status=available" || first.Status != 200 || first.DurationMs != 1.5 || first.Time.IsZero() {
+		t.Errorf("unexpected entry %+v", first)
+	}
+	if entries[1].Path != "/signals" || entries[1].Status != 503 || entries[1].Query != "" {
+		t.Errorf("unexpected entry %+v", entries[1])
+	}
+}
@@ -0,0 +1,64 @@
+package proxy
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"time"
+)
+
+//go:embed ui
+var uiFiles embed.FS
+
+// AdminStatus is the response body of the admin status endpoint.
+type AdminStatus struct {
+	Upstreams []UpstreamStatus `json:"upstreams"`
+	StartedAt time.Time        `json:"started_at"`
+}
+
+// UpstreamStatus is the status of an upstream gRPC server.
+type UpstreamStatus struct {
+	Target string `json:"target"`
+	State  string `json:"state"`
+	// Active is set for the upstream currently serving requests.
+	Active bool `json:"active"`
+}
+
+// adminHandler serves the admin UI and its status endpoint. Both require the admin token.
+func (s *Server) adminHandler() http.Handler {
+	ui, err := fs.Sub(uiFiles, "ui")
+	if err != nil {
+		panic(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/admin/", http.StripPrefix("/admin/", http.FileServer(http.FS(ui))))
+	mux.HandleFunc("/admin/status", s.serveStatus)
+	mux.Handle(apiKeysPath, s.apiKeys)
+	mux.Handle(apiKeysPath+"/", s.apiKeys)
+
+	return s.usage.requireAdmin(mux)
+}
+
+func (s *Server) serveStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	status := AdminStatus{StartedAt: s.startedAt}
+	if s.failover != nil {
+		current := s.failover.currentTarget()
+		for _, u := range s.failover.upstreams {
+			status.Upstreams = append(status.Upstreams, UpstreamStatus{
+				Target: u.target,
+				State:  u.conn.GetState().String(),
+				Active: u.target == current,
+			})
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status)
+}
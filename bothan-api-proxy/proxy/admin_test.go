@@ -0,0 +1,55 @@
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAdminUIRequiresAdminToken(t *testing.T) {
+	server, err := New(Config{
+		Grpc:  GrpcConfig{Addr: "localhost:50051"},
+		Usage: UsageConfig{AdminToken: "secret"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := server.adminHandler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status %d, got %d", http.StatusUnauthorized, rec.Code)
+	}
+	if rec.Header().Get("WWW-Authenticate") == "" {
+		t.Error("expected a Basic authentication challenge")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/", nil)
+	req.SetBasicAuth("admin", "secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Bothan Proxy") {
+		t.Fatalf("expected the admin UI, got status %d", rec.Code)
+	}
+	for _, path := range []string{"/signals/active", "/registry"} {
+		if !strings.Contains(rec.Body.String(), path) {
+			t.Errorf("expected the admin UI to show %s", path)
+		}
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/status", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var status AdminStatus
+	if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Upstreams) != 0 {
+		t.Errorf("expected no upstreams before the server runs, got %+v", status.Upstreams)
+	}
+}
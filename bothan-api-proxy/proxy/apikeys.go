@@ -0,0 +1,403 @@
+package proxy
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	bolt "go.etcd.io/bbolt"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// apiKeyPrefix starts every key managed by the proxy, followed by the ID and the secret of
+	// the key separated by '_'.
+	apiKeyPrefix = "bk_"
+
+	apiKeysPath = "/admin/keys"
+
+	// apiKeysOpenTimeout bounds the wait for the lock of the key file, which is held by any
+	// other proxy using the same file.
+	apiKeysOpenTimeout = 5 * time.Second
+)
+
+var (
+	apiKeysBucket = []byte("api_keys")
+
+	errAPIKeyNotFound = errors.New("API key not found")
+)
+
+// APIKeysConfig defines the API keys managed by the proxy itself, for hosted gateways without
+// an external auth service.
+type APIKeysConfig struct {
+	// Enabled requires one of the managed keys for every request of the gateway, in the
+	// header of the usage configuration or as a bearer token. Keys are created, listed and
+	// revoked on /admin/keys, which requires the admin token.
+	Enabled bool `toml:"enabled"`
+	// Path is the BoltDB file the keys are stored in. Only hashes of the secrets are stored.
+	Path string `toml:"path"`
+	// DefaultRateLimit is the rate limit, in requests per second, of keys created without
+	// one. Zero leaves them unlimited.
+	DefaultRateLimit float64 `toml:"default_rate_limit"`
+}
+
+// APIKey is a key managed by the proxy, as listed on /admin/keys.
+type APIKey struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Scopes are the path prefixes the key may request, e.g. "/prices". A key without scopes
+	// may request every route of the gateway.
+	Scopes []string `json:"scopes"`
+	// RateLimit is the number of requests per second the key may make on average, with
+	// bursts of up to Burst requests. Zero is unlimited.
+	RateLimit float64    `json:"rate_limit"`
+	Burst     int        `json:"burst"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// CreateAPIKeyRequest is the request body of POST /admin/keys. The rate limit of the
+// configuration applies if RateLimit is nil, and the burst defaults to the rate limit rounded
+// up.
+type CreateAPIKeyRequest struct {
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	RateLimit *float64 `json:"rate_limit"`
+	Burst     int      `json:"burst"`
+}
+
+// CreatedAPIKey is the response body of POST /admin/keys. Key is only ever returned here.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// storedAPIKey is the record of a key in the key file.
+type storedAPIKey struct {
+	APIKey
+	// Hash is the hex encoded SHA-256 of the secret of the key.
+	Hash string `json:"hash"`
+}
+
+// apiKeyStore holds the managed keys, in memory for authentication and in a BoltDB file for
+// restarts. A nil apiKeyStore lets every request through.
+type apiKeyStore struct {
+	db               *bolt.DB
+	defaultRateLimit float64
+
+	mu       sync.RWMutex
+	keys     map[string]storedAPIKey
+	limiters map[string]*rate.Limiter
+}
+
+// openAPIKeys opens the key file of the configuration and loads its keys, or returns nil if
+// the managed keys are disabled.
+func openAPIKeys(config APIKeysConfig) (*apiKeyStore, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+	if config.Path == "" {
+		return nil, errors.New("no path given for the API keys")
+	}
+	if config.DefaultRateLimit < 0 {
+		return nil, fmt.Errorf("invalid default_rate_limit %v", config.DefaultRateLimit)
+	}
+
+	db, err := bolt.Open(config.Path, 0o600, &bolt.Options{Timeout: apiKeysOpenTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("error opening the API keys: %w", err)
+	}
+
+	s := &apiKeyStore{
+		db:               db,
+		defaultRateLimit: config.DefaultRateLimit,
+		keys:             make(map[string]storedAPIKey),
+		limiters:         make(map[string]*rate.Limiter),
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(apiKeysBucket)
+		if err != nil {
+			return err
+		}
+		return bucket.ForEach(func(_, v []byte) error {
+			var key storedAPIKey
+			if err := json.Unmarshal(v, &key); err != nil {
+				return err
+			}
+			s.add(key)
+			return nil
+		})
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error loading the API keys: %w", err)
+	}
+
+	return s, nil
+}
+
+// Close closes the key file.
+func (s *apiKeyStore) Close() error {
+	if s == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+// add adds a loaded or created key to the memory of the store. The caller must hold the lock
+// or be the only user of the store.
+func (s *apiKeyStore) add(key storedAPIKey) {
+	s.keys[key.ID] = key
+	if key.RevokedAt == nil && key.RateLimit > 0 {
+		s.limiters[key.ID] = rate.NewLimiter(rate.Limit(key.RateLimit), key.Burst)
+	} else {
+		delete(s.limiters, key.ID)
+	}
+}
+
+func (s *apiKeyStore) put(tx *bolt.Tx, key storedAPIKey) error {
+	v, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(apiKeysBucket).Put([]byte(key.ID), v)
+}
+
+// create creates a key and returns it along with its secret.
+func (s *apiKeyStore) create(req CreateAPIKeyRequest) (CreatedAPIKey, error) {
+	for _, scope := range req.Scopes {
+		if !strings.HasPrefix(scope, "/") {
+			return CreatedAPIKey{}, fmt.Errorf("invalid scope %q, scopes are paths starting with '/'", scope)
+		}
+	}
+	rateLimit := s.defaultRateLimit
+	if req.RateLimit != nil {
+		rateLimit = *req.RateLimit
+	}
+	if rateLimit < 0 || req.Burst < 0 {
+		return CreatedAPIKey{}, errors.New("invalid rate limit, rate_limit and burst must not be negative")
+	}
+	burst := req.Burst
+	if burst == 0 {
+		burst = max(1, int(math.Ceil(rateLimit)))
+	}
+
+	id, err := randomHex(8)
+	if err != nil {
+		return CreatedAPIKey{}, err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return CreatedAPIKey{}, err
+	}
+
+	key := storedAPIKey{
+		APIKey: APIKey{
+			ID:        id,
+			Name:      req.Name,
+			Scopes:    req.Scopes,
+			RateLimit: rateLimit,
+			Burst:     burst,
+			CreatedAt: time.Now().UTC(),
+		},
+		Hash: hashSecret(secret),
+	}
+	if key.Scopes == nil {
+		key.Scopes = []string{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.db.Update(func(tx *bolt.Tx) error { return s.put(tx, key) }); err != nil {
+		return CreatedAPIKey{}, err
+	}
+	s.add(key)
+
+	return CreatedAPIKey{APIKey: key.APIKey, Key: apiKeyPrefix + id + "_" + secret}, nil
+}
+
+// revoke revokes the key with the given ID. Revoked keys are kept, so that they stay listed.
+func (s *apiKeyStore) revoke(id string) (APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.keys[id]
+	if !ok {
+		return APIKey{}, errAPIKeyNotFound
+	}
+	if key.RevokedAt != nil {
+		return key.APIKey, nil
+	}
+
+	now := time.Now().UTC()
+	key.RevokedAt = &now
+	if err := s.db.Update(func(tx *bolt.Tx) error { return s.put(tx, key) }); err != nil {
+		return APIKey{}, err
+	}
+	s.add(key)
+
+	return key.APIKey, nil
+}
+
+// list returns the keys, oldest first.
+func (s *apiKeyStore) list() []APIKey {
+	s.mu.RLock()
+	keys := make([]APIKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, key.APIKey)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+		}
+		return keys[i].ID < keys[j].ID
+	})
+	return keys
+}
+
+// authenticate returns a gRPC status error unless the given key is a valid key whose scopes
+// include the path and whose rate limit is not exceeded.
+func (s *apiKeyStore) authenticate(given, path string) error {
+	if given == "" {
+		return status.Error(codes.Unauthenticated, "missing API key")
+	}
+	id, secret, ok := strings.Cut(strings.TrimPrefix(given, apiKeyPrefix), "_")
+	if !ok || !strings.HasPrefix(given, apiKeyPrefix) {
+		return status.Error(codes.Unauthenticated, "invalid API key")
+	}
+
+	s.mu.RLock()
+	key, ok := s.keys[id]
+	limiter := s.limiters[id]
+	s.mu.RUnlock()
+
+	if !ok || subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(key.Hash)) != 1 {
+		return status.Error(codes.Unauthenticated, "invalid API key")
+	}
+	if key.RevokedAt != nil {
+		return status.Error(codes.Unauthenticated, "revoked API key")
+	}
+	if !inScopes(key.Scopes, path) {
+		return status.Errorf(codes.PermissionDenied, "API key is not allowed to request %s", path)
+	}
+	if limiter != nil && !limiter.Allow() {
+		return status.Errorf(codes.ResourceExhausted, "rate limit of %v requests per second exceeded", key.RateLimit)
+	}
+
+	return nil
+}
+
+// inScopes reports whether the path is one of the scopes or below one of them. No scopes
+// include every path.
+func inScopes(scopes []string, path string) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+	for _, scope := range scopes {
+		scope = strings.TrimSuffix(scope, "/")
+		if path == scope || strings.HasPrefix(path, scope+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// middleware rejects the requests of the gateway that do not carry a valid managed key with a
+// gateway error. Requests authorized with the admin token, such as those of the admin UI, need
+// no key.
+func (s *apiKeyStore) middleware(mux *runtime.ServeMux, usage *UsageTracker, next http.Handler) http.Handler {
+	if s == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if usage.authorized(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err := s.authenticate(apiKey(r, usage.getConfig().APIKeyHeader), r.URL.Path); err != nil {
+			runtime.HTTPError(r.Context(), mux, &runtime.JSONPb{}, w, r, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ServeHTTP manages the keys: GET /admin/keys lists them, POST /admin/keys creates one and
+// DELETE /admin/keys/{id} revokes one. It must be wrapped with the admin authorization.
+func (s *apiKeyStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s == nil {
+		http.Error(w, "API keys are not enabled", http.StatusNotFound)
+		return
+	}
+
+	if id, ok := strings.CutPrefix(r.URL.Path, apiKeysPath+"/"); ok {
+		if r.Method != http.MethodDelete {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		key, err := s.revoke(id)
+		switch {
+		case errors.Is(err, errAPIKeyNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		default:
+			writeJSON(w, http.StatusOK, key)
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.list())
+	case http.MethodPost:
+		var req CreateAPIKeyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+		key, err := s.create(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, http.StatusCreated, key)
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
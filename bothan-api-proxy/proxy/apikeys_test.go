@@ -0,0 +1,217 @@
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func openTestAPIKeys(t *testing.T, path string) *apiKeyStore {
+	t.Helper()
+
+	s, err := openAPIKeys(APIKeysConfig{Enabled: true, Path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestAPIKeysAuthenticate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.db")
+	s := openTestAPIKeys(t, path)
+
+	limit := 1.0
+	prices, err := s.create(CreateAPIKeyRequest{Name: "oracle", Scopes: []string{"/prices"}, RateLimit: &limit})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlimited, err := s.create(CreateAPIKeyRequest{Name: "dashboard"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		key, path string
+		code      codes.Code
+	}{
+		{prices.Key, "/prices/crypto_price.btcusd", codes.OK},
+		// The burst of one request is used up.
+		{prices.Key, "/prices/crypto_price.btcusd", codes.ResourceExhausted},
+		{prices.Key, "/signals", codes.PermissionDenied},
+		{prices.Key, "/pricesx", codes.PermissionDenied},
+		{unlimited.Key, "/signals", codes.OK},
+		{unlimited.Key, "/signals", codes.OK},
+		{"", "/signals", codes.Unauthenticated},
+		{unlimited.Key + "x", "/signals", codes.Unauthenticated},
+		{strings.TrimPrefix(unlimited.Key, apiKeyPrefix), "/signals", codes.Unauthenticated},
+	}
+	for _, c := range cases {
+		if code := status.Code(s.authenticate(c.key, c.path)); code != c.code {
+			t.Errorf("expected %s for %s, got %s", c.code, c.path, code)
+		}
+	}
+
+	if _, err := s.revoke(unlimited.ID); err != nil {
+		t.Fatal(err)
+	}
+	if code := status.Code(s.authenticate(unlimited.Key, "/signals")); code != codes.Unauthenticated {
+		t.Errorf("expected a revoked key to be rejected, got %s", code)
+	}
+
+	// The keys survive a restart.
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	s = openTestAPIKeys(t, path)
+	defer s.Close()
+
+	keys := s.list()
+	if len(keys) != 2 || keys[0].Name != "oracle" || keys[1].Name != "dashboard" || keys[1].RevokedAt == nil {
+		t.Fatalf("unexpected keys after reopening %+v", keys)
+	}
+	if err := s.authenticate(prices.Key, "/prices/crypto_price.btcusd"); err != nil {
+		t.Errorf("expected the key to be valid after reopening, got %v", err)
+	}
+	if code := status.Code(s.authenticate(unlimited.Key, "/signals")); code != codes.Unauthenticated {
+		t.Errorf("expected the key to stay revoked after reopening, got %s", code)
+	}
+}
+
+func TestAPIKeysAdmin(t *testing.T) {
+	server, err := New(Config{
+		Grpc:  GrpcConfig{Addr: "localhost:50051"},
+		Usage: UsageConfig{AdminToken: "secret"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.apiKeys = openTestAPIKeys(t, filepath.Join(t.TempDir(), "keys.db"))
+	defer server.apiKeys.Close()
+	handler := server.adminHandler()
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/keys", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected the keys to require the admin token, got status %d", rec.Code)
+	}
+
+	rec = do(http.MethodPost, "/admin/keys", `{"name": "oracle", "scopes": ["/prices"], "rate_limit": 2.5}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body)
+	}
+	var created CreatedAPIKey
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(created.Key, apiKeyPrefix+created.ID+"_") || created.RateLimit != 2.5 || created.Burst != 3 {
+		t.Errorf("unexpected key %+v", created)
+	}
+
+	if rec := do(http.MethodPost, "/admin/keys", `{"scopes": ["prices"]}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected an invalid scope to be rejected, got status %d", rec.Code)
+	}
+
+	rec = do(http.MethodGet, "/admin/keys", "")
+	if strings.Contains(rec.Body.String(), created.Key) || strings.Contains(rec.Body.String(), "hash") {
+		t.Errorf("expected the listed keys to leave out the secrets, got %s", rec.Body)
+	}
+	var keys []APIKey
+	if err := json.Unmarshal(rec.Body.Bytes(), &keys); err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0].ID != created.ID {
+		t.Fatalf("unexpected keys %+v", keys)
+	}
+
+	if rec := do(http.MethodDelete, "/admin/keys/"+created.ID, ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "revoked_at") {
+		t.Errorf("expected the key to be revoked, got status %d: %s", rec.Code, rec.Body)
+	}
+	if rec := do(http.MethodDelete, "/admin/keys/unknown", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for an unknown key, got %d", http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestAPIKeysAdminWithoutToken(t *testing.T) {
+	if _, err := New(Config{
+		Grpc:    GrpcConfig{Addr: "localhost:50051"},
+		APIKeys: APIKeysConfig{Enabled: true, Path: filepath.Join(t.TempDir(), "keys.db")},
+	}); err == nil {
+		t.Fatal("expected API keys without an admin token to be rejected")
+	}
+
+	// A server embedded without the check still rejects every admin request.
+	server, err := New(Config{Grpc: GrpcConfig{Addr: "localhost:50051"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.apiKeys = openTestAPIKeys(t, filepath.Join(t.TempDir(), "keys.db"))
+	defer server.apiKeys.Close()
+	handler := server.adminHandler()
+
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		req := httptest.NewRequest(method, "/admin/keys", strings.NewReader(`{"name": "oracle"}`))
+		req.Header.Set("Authorization", "Bearer ")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s: expected status 401 without an admin token, got %d", method, rec.Code)
+		}
+	}
+	if keys := server.apiKeys.list(); len(keys) != 0 {
+		t.Errorf("expected no key to be created, got %+v", keys)
+	}
+}
+
+func TestAPIKeysMiddlewareAdmin(t *testing.T) {
+	server, err := New(Config{
+		Grpc:  GrpcConfig{Addr: "localhost:50051"},
+		Usage: UsageConfig{AdminToken: "secret"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.apiKeys = openTestAPIKeys(t, filepath.Join(t.TempDir(), "keys.db"))
+	defer server.apiKeys.Close()
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := server.apiKeys.middleware(server.newGatewayMux(), server.usage, next)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prices/crypto_price.btcusd", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected a request without a key to be rejected, got status %d", rec.Code)
+	}
+
+	// The admin UI authenticates with the admin token instead of a key.
+	req := httptest.NewRequest(http.MethodGet, "/prices/crypto_price.btcusd", nil)
+	req.SetBasicAuth("admin", "secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected an admin request to need no key, got status %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/prices/crypto_price.btcusd", nil)
+	req.SetBasicAuth("admin", "wrong")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected a wrong admin token to be rejected, got status %d", rec.Code)
+	}
+}
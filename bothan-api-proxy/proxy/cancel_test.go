@@ -0,0 +1,173 @@
+package proxy
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// blockingQueryServer blocks every call until it is cancelled and reports the cancellation.
+type blockingQueryServer struct {
+	query.UnimplementedQueryServer
+	started   chan struct{}
+	cancelled chan struct{}
+}
+
+func (s *blockingQueryServer) Prices(ctx context.Context, _ *query.QueryPricesRequest) (*query.QueryPricesResponse, error) {
+	s.started <- struct{}{}
+	<-ctx.Done()
+	s.cancelled <- struct{}{}
+	return nil, ctx.Err()
+}
+
+type startHooks struct {
+	NopHooks
+	addr chan string
+}
+
+func (h startHooks) OnStart(e StartEvent) {
+	h.addr <- e.Addr
+}
+
+// startBlockingProxy runs a proxy in front of a blockingQueryServer and returns the address
+// of the proxy.
+func startBlockingProxy(t *testing.T) (string, *blockingQueryServer) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	upstream := &blockingQueryServer{started: make(chan struct{}, 100), cancelled: make(chan struct{}, 100)}
+	grpcServer := grpc.NewServer()
+	query.RegisterQueryServer(grpcServer, upstream)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	hooks := startHooks{addr: make(chan string, 1)}
+	server, err := New(Config{
+		Grpc:    GrpcConfig{Addr: listener.Addr().String()},
+		GoProxy: GoProxyConfig{Addr: "127.0.0.1:0"},
+	}, hooks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := server.Run(ctx); err != nil {
+			t.Error(err)
+		}
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	return <-hooks.addr, upstream
+}
+
+func waitFor(t *testing.T, ch <-chan struct{}, what string) {
+	t.Helper()
+	select {
+	case <-ch:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for %s", what)
+	}
+}
+
+func TestCancellationPropagatesToUpstream(t *testing.T) {
+	addr, upstream := startBlockingProxy(t)
+
+	tests := []struct {
+		name    string
+		request func(ctx context.Context) error
+	}{
+		{"rest", func(ctx context.Context) error {
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addr+"/prices/btc", nil)
+			resp, err := http.DefaultClient.Do(req)
+			if err == nil {
+				resp.Body.Close()
+			}
+			return err
+		}},
+		{"server-sent events", func(ctx context.Context) error {
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addr+"/prices/btc", nil)
+			req.Header.Set("Accept", "text/event-stream")
+			resp, err := http.DefaultClient.Do(req)
+			if err == nil {
+				resp.Body.Close()
+			}
+			return err
+		}},
+		{"websocket", func(ctx context.Context) error {
+			ws, err := websocket.Dial("ws://"+addr+"/prices/btc", "", "http://"+addr)
+			if err != nil {
+				return err
+			}
+			<-ctx.Done()
+			return ws.Close()
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			errCh := make(chan error, 1)
+			go func() { errCh <- tt.request(ctx) }()
+
+			waitFor(t, upstream.started, "the upstream call")
+			cancel()
+			waitFor(t, upstream.cancelled, "the cancellation of the upstream call")
+			<-errCh
+		})
+	}
+}
+
+func TestCancellationDoesNotLeakGoroutines(t *testing.T) {
+	addr, upstream := startBlockingProxy(t)
+	client := &http.Client{Timeout: 20 * time.Millisecond}
+
+	churn := func(n int) {
+		for i := 0; i < n; i++ {
+			resp, err := client.Get("http://" + addr + "/prices/btc")
+			if err == nil {
+				resp.Body.Close()
+			}
+			waitFor(t, upstream.started, "the upstream call")
+			waitFor(t, upstream.cancelled, "the cancellation of the upstream call")
+		}
+		client.CloseIdleConnections()
+	}
+
+	// Warm up the connections and pools before taking the baseline.
+	churn(5)
+	time.Sleep(100 * time.Millisecond)
+	baseline := runtime.NumGoroutine()
+
+	churn(50)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		// Allow for a few goroutines of connections that are still being torn down.
+		n := runtime.NumGoroutine()
+		if n <= baseline+5 {
+			return
+		}
+		if time.Now().After(deadline) {
+			buf := make([]byte, 1<<16)
+			t.Fatalf("goroutines grew from %d to %d:\n%s", baseline, n, strings.TrimSpace(string(buf[:runtime.Stack(buf, true)])))
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
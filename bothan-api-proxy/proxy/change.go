@@ -0,0 +1,157 @@
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+const (
+	// changeSuffix is the suffix of the paths of the price changes of signals, e.g.
+	// /prices/crypto_price.btcusd,crypto_price.ethusd/change?window=1h.
+	changeSuffix = "/change"
+	// windowParam is the query parameter with the window of the price changes, as a Go
+	// duration.
+	windowParam = "window"
+)
+
+// changeResponse is the body of GET /prices/{signal_ids}/change.
+type changeResponse struct {
+	Window  string        `json:"window"`
+	Changes []priceChange `json:"changes"`
+}
+
+// priceChange is the change of the price of a signal over the window. The base price is the
+// last price observed at least the window ago, and the change is only set when the history
+// reaches back that far and the base price is not zero.
+type priceChange struct {
+	SignalID      string     `json:"signal_id"`
+	Price         string     `json:"price,omitempty"`
+	Time          *time.Time `json:"time,omitempty"`
+	BasePrice     string     `json:"base_price,omitempty"`
+	BaseTime      *time.Time `json:"base_time,omitempty"`
+	PercentChange *float64   `json:"percent_change,omitempty"`
+}
+
+// change returns the last available observation of the signal, and the last available one
+// observed at or before since. Either is nil if the history has none.
+func (p *recentPrices) change(signalID string, since time.Time) (current, base *observation) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	series, ok := p.series[signalID]
+	if !ok {
+		return nil, nil
+	}
+
+	// Walk the ring buffer from the newest observation to the oldest. The observations are
+	// copied, as the buffer is overwritten once the lock is released.
+	n := len(series.observations)
+	for i := 1; i <= n; i++ {
+		o := series.observations[(series.next-i+n)%n]
+		if o.price.PriceStatus != query.PriceStatus_PRICE_STATUS_AVAILABLE {
+			continue
+		}
+		if current == nil {
+			current = &o
+		}
+		if !o.time.After(since) {
+			base = &o
+			break
+		}
+	}
+
+	return current, base
+}
+
+// changeMiddleware serves GET /prices/{signal_ids}/change?window=1h from the history of the
+// proxy, where the signal IDs may be external IDs of the ID translation. The changes are
+// computed from the prices redacted with the profile of the request, like the observations
+// of /recent. As the history only holds the prices the proxy served, the change of a signal
+// is missing until the proxy served it at least the window ago. Other requests are passed to
+// next.
+func (p *recentPrices) changeMiddleware(mux *runtime.ServeMux, ids *idTranslation, next http.Handler) http.Handler {
+	if p == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		param, ok := strings.CutPrefix(r.URL.Path, pricesPath+"/")
+		param, isChange := strings.CutSuffix(param, changeSuffix)
+		if r.Method != http.MethodGet || !ok || !isChange {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		window, err := time.ParseDuration(r.URL.Query().Get(windowParam))
+		if err != nil || window <= 0 {
+			err := status.Errorf(codes.InvalidArgument, "invalid %s %q", windowParam, r.URL.Query().Get(windowParam))
+			runtime.HTTPError(r.Context(), mux, &runtime.JSONPb{}, w, r, err)
+			return
+		}
+		requested := strings.Split(param, ",")
+		if slices.Contains(requested, "") || strings.Contains(param, "/") {
+			runtime.HTTPError(r.Context(), mux, &runtime.JSONPb{}, w, r, status.Error(codes.InvalidArgument, "expected comma separated signal IDs"))
+			return
+		}
+
+		signalIDs, _ := ids.translate(requested)
+		since := time.Now().Add(-window)
+
+		// Redact the current and base prices as the prices of a response, whose paths the
+		// profiles use, two per signal.
+		observations := make([]*observation, 2*len(signalIDs))
+		redacted := &query.QueryPricesResponse{Prices: make([]*query.PriceData, len(observations))}
+		for i, id := range signalIDs {
+			observations[2*i], observations[2*i+1] = p.change(id, since)
+		}
+		for i, o := range observations {
+			redacted.Prices[i] = &query.PriceData{}
+			if o != nil {
+				redacted.Prices[i] = proto.Clone(o.price).(*query.PriceData)
+			}
+		}
+		_ = redactResponse(r.Context(), w, redacted)
+
+		resp := changeResponse{Window: window.String(), Changes: make([]priceChange, len(requested))}
+		for i, id := range requested {
+			resp.Changes[i] = newPriceChange(id, observations[2*i], redacted.Prices[2*i], observations[2*i+1], redacted.Prices[2*i+1])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
+
+// newPriceChange creates the change of a signal from its observations and their redacted
+// prices.
+func newPriceChange(signalID string, current *observation, currentPrice *query.PriceData, base *observation, basePrice *query.PriceData) priceChange {
+	change := priceChange{SignalID: signalID}
+	if current == nil {
+		return change
+	}
+	change.Price, change.Time = currentPrice.Price, &current.time
+	if base == nil {
+		return change
+	}
+	change.BasePrice, change.BaseTime = basePrice.Price, &base.time
+
+	now, err1 := strconv.ParseFloat(change.Price, 64)
+	then, err2 := strconv.ParseFloat(change.BasePrice, 64)
+	if err1 == nil && err2 == nil && then != 0 {
+		percent := (now - then) / then * 100
+		change.PercentChange = &percent
+	}
+
+	return change
+}
@@ -0,0 +1,106 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestPriceChange(t *testing.T) {
+	recent, err := newRecentPrices(RecentConfig{Observations: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Observations of btc two hours, one hour and a minute ago, of eth only a minute ago.
+	now := time.Now()
+	observe := func(id string, age time.Duration, price string, priceStatus query.PriceStatus) {
+		series, ok := recent.series[id]
+		if !ok {
+			series = &priceSeries{}
+			recent.series[id] = series
+		}
+		series.add(observation{time: now.Add(-age), price: &query.PriceData{SignalId: id, Price: price, PriceStatus: priceStatus}}, recent.size)
+	}
+	observe("btc", 2*time.Hour, "50000", query.PriceStatus_PRICE_STATUS_AVAILABLE)
+	observe("btc", time.Hour+time.Second, "40000", query.PriceStatus_PRICE_STATUS_AVAILABLE)
+	observe("btc", 30*time.Minute, "", query.PriceStatus_PRICE_STATUS_UNAVAILABLE)
+	observe("btc", time.Minute, "44000", query.PriceStatus_PRICE_STATUS_AVAILABLE)
+	observe("eth", time.Minute, "3000", query.PriceStatus_PRICE_STATUS_AVAILABLE)
+
+	handler := recent.changeMiddleware(runtime.NewServeMux(), nil, http.NotFoundHandler())
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	rec := get("/prices/btc,eth,unknown/change?window=1h")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body)
+	}
+	var body changeResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Window != "1h0m0s" || len(body.Changes) != 3 {
+		t.Fatalf("expected 3 changes over 1h, got %+v", body)
+	}
+
+	btc := body.Changes[0]
+	if btc.SignalID != "btc" || btc.Price != "44000" || btc.BasePrice != "40000" || btc.PercentChange == nil || math.Abs(*btc.PercentChange-10) > 1e-9 {
+		t.Errorf("expected btc to be up 10%% from the last price at least 1h old, got %+v", btc)
+	}
+	if eth := body.Changes[1]; eth.Price != "3000" || eth.BaseTime != nil || eth.PercentChange != nil {
+		t.Errorf("expected no change of eth without a price 1h old, got %+v", eth)
+	}
+	if unknown := body.Changes[2]; unknown != (priceChange{SignalID: "unknown"}) {
+		t.Errorf("expected no prices of an unknown signal, got %+v", unknown)
+	}
+
+	for _, path := range []string{"/prices/btc/change", "/prices/btc/change?window=x", "/prices/btc/change?window=-1h", "/prices/btc,/change?window=1h"} {
+		if rec := get(path); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d: %s", path, rec.Code, rec.Body)
+		}
+	}
+	if rec := get("/prices/btc"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected other requests to be passed on, got %d", rec.Code)
+	}
+}
+
+func TestPriceChangeGateway(t *testing.T) {
+	recent, err := newRecentPrices(RecentConfig{Observations: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gwmux := runtime.NewServeMux(runtime.WithForwardResponseOption(recent.observe))
+	if err := query.RegisterQueryHandlerClient(context.Background(), gwmux, stubQueryClient{}); err != nil {
+		t.Fatal(err)
+	}
+	handler := recent.changeMiddleware(gwmux, nil, gwmux)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prices/btc", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body)
+	}
+
+	// The price just served is the current price, but the history does not reach back a minute.
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prices/btc/change?window=1m", nil))
+	var body changeResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Changes) != 1 || body.Changes[0].Price != "1" || body.Changes[0].PercentChange != nil {
+		t.Errorf("expected the served price without a change, got %+v", body)
+	}
+}
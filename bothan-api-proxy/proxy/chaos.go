@@ -0,0 +1,121 @@
+package proxy
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/chaos"
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// chaosHeader carries a per-request fault specification, see chaos.Parse.
+const chaosHeader = "X-Bothan-Chaos"
+
+// ChaosConfig enables fault injection for testing consumers. It must never be enabled in
+// production.
+type ChaosConfig struct {
+	Enabled bool `toml:"enabled"`
+	// Faults is the fault specification applied to requests without a chaos header.
+	Faults string `toml:"faults"`
+}
+
+// chaosMiddleware injects the faults of the config, or of the chaos header if present, into
+// the requests passing through it.
+func chaosMiddleware(config ChaosConfig, next http.Handler) (http.Handler, error) {
+	defaults, err := chaos.Parse(config.Faults)
+	if err != nil {
+		return nil, err
+	}
+	injector := chaos.NewInjector(defaults)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		injector := injector
+		if spec := r.Header.Get(chaosHeader); spec != "" {
+			faults, err := chaos.Parse(spec)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			injector = injector.With(faults)
+		}
+
+		if err := injector.Delay(r.Context()); err != nil {
+			// The client went away while the latency was injected.
+			return
+		}
+		if err := injector.Err(); err != nil {
+			writeStatus(w, status.Convert(err))
+			return
+		}
+
+		if !strings.HasPrefix(r.URL.Path, "/prices/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		buffered := newBufferedWriter()
+		next.ServeHTTP(buffered, r)
+		if buffered.status == http.StatusOK {
+			var resp query.QueryPricesResponse
+			if err := protojson.Unmarshal(buffered.body.Bytes(), &resp); err == nil {
+				resp.Prices = injector.Apply(resp.Prices)
+				if body, err := (protojson.MarshalOptions{EmitUnpopulated: true}).Marshal(&resp); err == nil {
+					buffered.body.Reset()
+					buffered.body.Write(body)
+				}
+			}
+		}
+		buffered.writeTo(w)
+	}), nil
+}
+
+// writeStatus writes a gRPC status the same way the gateway renders upstream errors.
+func writeStatus(w http.ResponseWriter, s *status.Status) {
+	body, err := protojson.Marshal(s.Proto())
+	if err != nil {
+		http.Error(w, s.Message(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(runtime.HTTPStatusFromCode(s.Code()))
+	_, _ = w.Write(body)
+}
+
+// bufferedWriter holds a response in memory so that it can be modified before being sent.
+type bufferedWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newBufferedWriter() *bufferedWriter {
+	return &bufferedWriter{header: make(http.Header), status: http.StatusOK}
+}
+
+func (w *bufferedWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *bufferedWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *bufferedWriter) writeTo(dst http.ResponseWriter) {
+	for key, values := range w.header {
+		dst.Header()[key] = values
+	}
+	dst.Header().Set("Content-Length", strconv.Itoa(w.body.Len()))
+	dst.WriteHeader(w.status)
+	_, _ = dst.Write(w.body.Bytes())
+}
@@ -0,0 +1,64 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestChaosMiddleware(t *testing.T) {
+	upstream := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := &query.QueryPricesResponse{Prices: []*query.PriceData{
+			{SignalId: "crypto_price.btcusd", Price: "60000", PriceStatus: query.PriceStatus_PRICE_STATUS_AVAILABLE},
+		}}
+		body, _ := protojson.Marshal(resp)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	})
+
+	handler, err := chaosMiddleware(ChaosConfig{Enabled: true}, upstream)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		faults string
+		status int
+		prices int
+	}{
+		{"no faults", "", http.StatusOK, 1},
+		{"error", "error=1,code=UNAVAILABLE", http.StatusServiceUnavailable, -1},
+		{"partial", "partial=1", http.StatusOK, 0},
+		{"invalid", "error=2", http.StatusBadRequest, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/prices/crypto_price.btcusd", nil)
+			if tt.faults != "" {
+				req.Header.Set(chaosHeader, tt.faults)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, rec.Code)
+			}
+			if tt.prices < 0 {
+				return
+			}
+
+			var resp query.QueryPricesResponse
+			if err := protojson.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.Prices) != tt.prices {
+				t.Errorf("expected %d prices, got %d", tt.prices, len(resp.Prices))
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// contentDigestHeader is the standard response header of RFC 9530 with a hash of the response
+// body, with which clients detect bodies corrupted by caches or middleboxes between them and
+// the proxy.
+const contentDigestHeader = "Content-Digest"
+
+// checksum sets the Content-Digest header of every response to the SHA-256 hash of its body,
+// e.g. "sha-256=:47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=:". The body is buffered to hash
+// it before the header is sent. Streams flush every message, so a response is passed through
+// without the header from its first flush on, and responses without a body get none either.
+// Responses that the stream bridge reframes as events or WebSocket messages get no header, as
+// the bytes sent are not the body hashed here.
+func checksum(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isWebSocket(r) || acceptsEvents(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &checksumWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(cw, r)
+		cw.finish()
+	})
+}
+
+// checksumWriter buffers a response until it is finished or flushed.
+type checksumWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	streaming   bool
+	body        bytes.Buffer
+}
+
+func (w *checksumWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+	if w.streaming {
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (w *checksumWriter) Write(b []byte) (int, error) {
+	if w.streaming {
+		return w.ResponseWriter.Write(b)
+	}
+	w.wroteHeader = true
+	return w.body.Write(b)
+}
+
+// Flush sends the buffered response without a checksum and passes the rest through.
+func (w *checksumWriter) Flush() {
+	if !w.streaming {
+		w.streaming = true
+		w.ResponseWriter.WriteHeader(w.status)
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+		w.body.Reset()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack lets the stream bridge take over the connection for WebSockets.
+func (w *checksumWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("connection cannot be hijacked")
+	}
+	w.streaming = true
+
+	return h.Hijack()
+}
+
+// finish sends the buffered response with its checksum.
+func (w *checksumWriter) finish() {
+	if w.streaming {
+		return
+	}
+	if w.body.Len() > 0 {
+		sum := sha256.Sum256(w.body.Bytes())
+		w.Header().Set(contentDigestHeader, "sha-256=:"+base64.StdEncoding.EncodeToString(sum[:])+":")
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(w.body.Bytes())
+}
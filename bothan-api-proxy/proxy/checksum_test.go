@@ -0,0 +1,90 @@
+package proxy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestChecksum(t *testing.T) {
+	gwmux := runtime.NewServeMux()
+	if err := query.RegisterQueryHandlerClient(context.Background(), gwmux, stubQueryClient{}); err != nil {
+		t.Fatal(err)
+	}
+	handler := headRequests(checksum(gwmux))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prices/btc", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d", rec.Code)
+	}
+	sum := sha256.Sum256(rec.Body.Bytes())
+	digest := "sha-256=:" + base64.StdEncoding.EncodeToString(sum[:]) + ":"
+	if got := rec.Header().Get(contentDigestHeader); got != digest {
+		t.Fatalf("expected digest %q, got %q", digest, got)
+	}
+
+	// A HEAD request gets the digest of the body of the GET request.
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/prices/btc", nil))
+	if got := rec.Header().Get(contentDigestHeader); got != digest {
+		t.Errorf("expected digest %q for HEAD, got %q", digest, got)
+	}
+}
+
+func TestChecksumStream(t *testing.T) {
+	handler := checksum(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("first\n"))
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte("second\n"))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prices/stream", nil))
+	if rec.Code != http.StatusAccepted || rec.Body.String() != "first\nsecond\n" || !rec.Flushed {
+		t.Fatalf("unexpected response %d: %q", rec.Code, rec.Body)
+	}
+	if got := rec.Header().Get(contentDigestHeader); got != "" {
+		t.Errorf("expected no digest for a stream, got %q", got)
+	}
+}
+
+func TestChecksumNoBody(t *testing.T) {
+	handler := checksum(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusNoContent || rec.Header().Get(contentDigestHeader) != "" {
+		t.Errorf("unexpected response %d with digest %q", rec.Code, rec.Header().Get(contentDigestHeader))
+	}
+}
+
+func TestChecksumServerSentEvents(t *testing.T) {
+	gwmux := runtime.NewServeMux()
+	if err := query.RegisterQueryHandlerClient(context.Background(), gwmux, stubQueryClient{}); err != nil {
+		t.Fatal(err)
+	}
+	handler := streamBridge(checksum(gwmux), TunnelConfig{}.checkOrigin)
+
+	r := httptest.NewRequest(http.MethodGet, "/prices/btc", nil)
+	r.Header.Set("Accept", "text/event-stream")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Body.String(), "data: ") {
+		t.Fatalf("unexpected response %d: %q", rec.Code, rec.Body)
+	}
+	if got := rec.Header().Get(contentDigestHeader); got != "" {
+		t.Errorf("expected no digest for events, got %q", got)
+	}
+}
@@ -0,0 +1,45 @@
+package proxy
+
+// GrpcConfig defines the upstream gRPC servers of the proxy.
+type GrpcConfig struct {
+	// Addr is the address of the primary upstream.
+	Addr string `toml:"addr"`
+	// Fallbacks are the addresses of the upstreams used while the primary is unavailable, in
+	// order of priority.
+	Fallbacks []string `toml:"fallbacks"`
+}
+
+// targets returns the addresses of all upstreams in order of priority.
+func (c GrpcConfig) targets() []string {
+	return append([]string{c.Addr}, c.Fallbacks...)
+}
+
+// GoProxyConfig defines the HTTP server of the proxy.
+type GoProxyConfig struct {
+	Addr string `toml:"addr"`
+	// ReusePort sets SO_REUSEPORT on the listener, so that an upgraded binary can be started
+	// on the same address before the running one is stopped.
+	ReusePort bool `toml:"reuse_port"`
+}
+
+// Config is the configuration of the proxy.
+type Config struct {
+	Grpc          GrpcConfig          `toml:"grpc"`
+	GoProxy       GoProxyConfig       `toml:"go-proxy"`
+	Usage         UsageConfig         `toml:"usage"`
+	Tunnel        TunnelConfig        `toml:"tunnel"`
+	Chaos         ChaosConfig         `toml:"chaos"`
+	Timeouts      TimeoutConfig       `toml:"timeouts"`
+	TLS           TLSConfig           `toml:"tls"`
+	RequestLog    RequestLogConfig    `toml:"request_log"`
+	Cost          CostConfig          `toml:"cost"`
+	SignalWatch   SignalWatchConfig   `toml:"signal_watch"`
+	Redaction     RedactionConfig     `toml:"redaction"`
+	Readiness     ReadinessConfig     `toml:"readiness"`
+	IDTranslation IDTranslationConfig `toml:"id_translation"`
+	Recent        RecentConfig        `toml:"recent"`
+	Routing       RoutingConfig       `toml:"routing"`
+	APIKeys       APIKeysConfig       `toml:"api_keys"`
+	AccessLog     AccessLogConfig     `toml:"access_log"`
+	Staleness     StalenessConfig     `toml:"staleness"`
+}
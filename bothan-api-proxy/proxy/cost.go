@@ -0,0 +1,92 @@
+package proxy
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// costHeader is the response header that reports the cost of a request.
+	costHeader = "X-Request-Cost"
+	// groupPrefix is the prefix of the signal IDs that refer to a signal group of the upstream.
+	groupPrefix = "@"
+)
+
+// CostConfig defines the budget of a single request.
+type CostConfig struct {
+	// MaxCost is the highest cost a request may have, requests above it are rejected. The
+	// cost of a request is the number of signals it queries times the number of points in
+	// time it queries them at, which is one for current prices. Zero disables the limit.
+	MaxCost int `toml:"max_cost"`
+	// Groups is the number of signals of each signal group of the upstream, keyed by name
+	// without the "@" as in the configuration of the server, so that a reference to a group
+	// costs its signals. A group may hold any number of signals, so with MaxCost set, requests
+	// referring to a group that is not listed are rejected.
+	Groups map[string]int `toml:"groups"`
+}
+
+// checkSignalIDs reports the cost of querying the current prices of the given signal IDs and
+// returns an error if it exceeds the budget or cannot be known.
+func (c CostConfig) checkSignalIDs(w http.ResponseWriter, signalIDs []string) error {
+	signals := 0
+	for _, id := range signalIDs {
+		name, ok := strings.CutPrefix(id, groupPrefix)
+		if !ok {
+			signals++
+			continue
+		}
+
+		size, known := c.Groups[name]
+		switch {
+		case known:
+			signals += size
+		case c.MaxCost > 0:
+			return status.Errorf(codes.InvalidArgument, "the cost of signal group %s is unknown, as its size is not configured", id)
+		default:
+			signals++
+		}
+	}
+
+	return c.check(w, signals, 1)
+}
+
+// check reports the cost in the response headers and returns an error if it exceeds the
+// budget.
+func (c CostConfig) check(w http.ResponseWriter, signals, depth int) error {
+	cost := signals * depth
+	w.Header().Set(costHeader, strconv.Itoa(cost))
+
+	if c.MaxCost > 0 && cost > c.MaxCost {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"request cost %d (%d signals × %d points) exceeds the limit of %d",
+			cost, signals, depth, c.MaxCost,
+		)
+	}
+
+	return nil
+}
+
+// middleware checks the cost of the GET /prices/{signal_ids} requests of the gateway and
+// rejects the ones above the budget with a gateway error.
+func (c CostConfig) middleware(mux *runtime.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids, ok := strings.CutPrefix(r.URL.Path, pricesPath+"/")
+		if r.Method != http.MethodGet || !ok {
+			mux.ServeHTTP(w, r)
+			return
+		}
+
+		if err := c.checkSignalIDs(w, strings.Split(ids, ",")); err != nil {
+			runtime.HTTPError(r.Context(), mux, &runtime.JSONPb{}, w, r, err)
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
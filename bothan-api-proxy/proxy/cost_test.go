@@ -0,0 +1,63 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+func TestCostLimit(t *testing.T) {
+	handler := CostConfig{MaxCost: 2}.middleware(runtime.NewServeMux())
+
+	tests := []struct {
+		path   string
+		cost   string
+		status int
+	}{
+		{"/prices/a,b", "2", http.StatusNotFound},
+		{"/prices/a,b,c", "3", http.StatusBadRequest},
+		{"/signals", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if rec.Code != tt.status {
+			t.Errorf("%s: expected status %d, got %d", tt.path, tt.status, rec.Code)
+		}
+		if cost := rec.Header().Get(costHeader); cost != tt.cost {
+			t.Errorf("%s: expected cost %q, got %q", tt.path, tt.cost, cost)
+		}
+	}
+}
+
+func TestCostLimitGroups(t *testing.T) {
+	handler := CostConfig{MaxCost: 2, Groups: map[string]int{"pair": 2, "all": 50000}}.middleware(runtime.NewServeMux())
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/prices/@pair", http.StatusNotFound},
+		{"/prices/@pair,a", http.StatusBadRequest},
+		{"/prices/@all", http.StatusBadRequest},
+		// The size of the group is unknown, so it could be any cost.
+		{"/prices/@unknown", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Code != tt.status {
+			t.Errorf("%s: expected status %d, got %d", tt.path, tt.status, rec.Code)
+		}
+	}
+
+	// Without a limit, groups of unknown size are counted as one signal.
+	rec := httptest.NewRecorder()
+	CostConfig{}.middleware(runtime.NewServeMux()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prices/@unknown,a", nil))
+	if cost := rec.Header().Get(costHeader); cost != "2" {
+		t.Errorf("expected cost 2, got %q", cost)
+	}
+}
@@ -0,0 +1,136 @@
+package proxy
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/connectivity"
+)
+
+// StartEvent is emitted once the proxy starts listening.
+type StartEvent struct {
+	// Addr is the address the proxy listens on.
+	Addr string
+}
+
+// UpstreamEvent is emitted when the connectivity to the upstream gRPC server changes.
+type UpstreamEvent struct {
+	// Target is the address of the upstream gRPC server.
+	Target string
+	// State is the connectivity state the upstream connection transitioned to.
+	State connectivity.State
+}
+
+// UpstreamSwitchEvent is emitted when requests start being served by a different upstream,
+// either because the previous one failed or because a higher priority one recovered.
+type UpstreamSwitchEvent struct {
+	From string
+	To   string
+}
+
+// ConfigReloadedEvent is emitted after the configuration of the proxy has been reloaded.
+type ConfigReloadedEvent struct {
+	Config Config
+}
+
+// RequestEvent is emitted after the proxy completed an HTTP request.
+type RequestEvent struct {
+	Method string
+	Path   string
+	// Query is the raw query string of the request, without the '?'.
+	Query    string
+	Status   int
+	Duration time.Duration
+	Consumer Consumer
+}
+
+// Hooks receives the lifecycle events of the proxy. Hooks are called synchronously, so
+// implementations should return quickly and hand off any slow work.
+type Hooks interface {
+	OnStart(StartEvent)
+	OnUpstreamConnected(UpstreamEvent)
+	OnUpstreamLost(UpstreamEvent)
+	OnConfigReloaded(ConfigReloadedEvent)
+	OnRequestCompleted(RequestEvent)
+}
+
+// UpstreamSwitchHooks can be implemented by Hooks to also receive the upstream switches. It is
+// separate from Hooks so that existing implementations keep compiling.
+type UpstreamSwitchHooks interface {
+	OnUpstreamSwitched(UpstreamSwitchEvent)
+}
+
+// NopHooks implements Hooks by ignoring every event. It can be embedded by implementations
+// that are only interested in some of the events.
+type NopHooks struct{}
+
+var (
+	_ Hooks               = NopHooks{}
+	_ UpstreamSwitchHooks = NopHooks{}
+)
+
+func (NopHooks) OnStart(StartEvent)                     {}
+func (NopHooks) OnUpstreamConnected(UpstreamEvent)      {}
+func (NopHooks) OnUpstreamLost(UpstreamEvent)           {}
+func (NopHooks) OnUpstreamSwitched(UpstreamSwitchEvent) {}
+func (NopHooks) OnConfigReloaded(ConfigReloadedEvent)   {}
+func (NopHooks) OnRequestCompleted(RequestEvent)        {}
+
+// EventBus dispatches every event to all subscribed hooks in subscription order.
+type EventBus struct {
+	mu    sync.RWMutex
+	hooks []Hooks
+}
+
+var (
+	_ Hooks               = &EventBus{}
+	_ UpstreamSwitchHooks = &EventBus{}
+)
+
+// NewEventBus creates a new EventBus with the given hooks subscribed.
+func NewEventBus(hooks ...Hooks) *EventBus {
+	return &EventBus{hooks: hooks}
+}
+
+// Subscribe adds the given hooks to the bus.
+func (b *EventBus) Subscribe(hooks Hooks) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.hooks = append(b.hooks, hooks)
+}
+
+func (b *EventBus) each(f func(Hooks)) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, hooks := range b.hooks {
+		f(hooks)
+	}
+}
+
+func (b *EventBus) OnStart(e StartEvent) {
+	b.each(func(h Hooks) { h.OnStart(e) })
+}
+
+func (b *EventBus) OnUpstreamConnected(e UpstreamEvent) {
+	b.each(func(h Hooks) { h.OnUpstreamConnected(e) })
+}
+
+func (b *EventBus) OnUpstreamLost(e UpstreamEvent) {
+	b.each(func(h Hooks) { h.OnUpstreamLost(e) })
+}
+
+func (b *EventBus) OnUpstreamSwitched(e UpstreamSwitchEvent) {
+	b.each(func(h Hooks) {
+		if h, ok := h.(UpstreamSwitchHooks); ok {
+			h.OnUpstreamSwitched(e)
+		}
+	})
+}
+
+func (b *EventBus) OnConfigReloaded(e ConfigReloadedEvent) {
+	b.each(func(h Hooks) { h.OnConfigReloaded(e) })
+}
+
+func (b *EventBus) OnRequestCompleted(e RequestEvent) {
+	b.each(func(h Hooks) { h.OnRequestCompleted(e) })
+}
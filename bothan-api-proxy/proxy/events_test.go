@@ -0,0 +1,73 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type recordingHooks struct {
+	NopHooks
+	requests []RequestEvent
+}
+
+func (h *recordingHooks) OnRequestCompleted(e RequestEvent) {
+	h.requests = append(h.requests, e)
+}
+
+func TestRequestCompletedEvent(t *testing.T) {
+	first, second := &recordingHooks{}, &recordingHooks{}
+	server, err := New(Config{}, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Events().Subscribe(second)
+
+	handler := server.instrument(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/prices/a", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	for _, hooks := range []*recordingHooks{first, second} {
+		if len(hooks.requests) != 1 {
+			t.Fatalf("expected 1 event, got %d", len(hooks.requests))
+		}
+		e := hooks.requests[0]
+		if e.Method != http.MethodGet || e.Path != "/prices/a" || e.Status != http.StatusTeapot {
+			t.Errorf("unexpected event %+v", e)
+		}
+		if e.Consumer != (Consumer{Kind: "ip", ID: "10.0.0.1"}) {
+			t.Errorf("unexpected consumer %+v", e.Consumer)
+		}
+	}
+}
+
+// legacyHooks implements Hooks without embedding NopHooks, as implementations written before the
+// upstream switches were added do.
+type legacyHooks struct {
+	starts int
+}
+
+func (h *legacyHooks) OnStart(StartEvent)                   { h.starts++ }
+func (h *legacyHooks) OnUpstreamConnected(UpstreamEvent)    {}
+func (h *legacyHooks) OnUpstreamLost(UpstreamEvent)         {}
+func (h *legacyHooks) OnConfigReloaded(ConfigReloadedEvent) {}
+func (h *legacyHooks) OnRequestCompleted(RequestEvent)      {}
+
+func TestUpstreamSwitchedEventIsOptional(t *testing.T) {
+	plain, switches := &legacyHooks{}, &switchHooks{}
+	bus := NewEventBus(plain, switches)
+
+	bus.OnStart(StartEvent{})
+	bus.OnUpstreamSwitched(UpstreamSwitchEvent{From: "a", To: "b"})
+
+	if plain.starts != 1 {
+		t.Errorf("expected 1 start event, got %d", plain.starts)
+	}
+	if len(switches.switches) != 1 || switches.switches[0] != (UpstreamSwitchEvent{From: "a", To: "b"}) {
+		t.Errorf("unexpected switch events %+v", switches.switches)
+	}
+}
@@ -0,0 +1,167 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+)
+
+// upstream is a connection to one of the upstream gRPC servers.
+type upstream struct {
+	target string
+	conn   *grpc.ClientConn
+}
+
+// failoverConn sends every call to the first healthy upstream in priority order and retries
+// it on the next upstream if the chosen one turns out to be unavailable. As the primary is
+// preferred again as soon as it is healthy, traffic returns to it once it recovers.
+type failoverConn struct {
+	upstreams   []upstream
+	events      *EventBus
+	active      *prometheus.GaugeVec
+	failovers   *prometheus.CounterVec
+	lastSuccess *prometheus.GaugeVec
+
+	mu      sync.Mutex
+	current string
+}
+
+var _ grpc.ClientConnInterface = &failoverConn{}
+
+func newFailoverConn(upstreams []upstream, events *EventBus, registerer prometheus.Registerer) (*failoverConn, error) {
+	if len(upstreams) == 0 {
+		return nil, errors.New("no upstream configured")
+	}
+
+	active := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "bothan_proxy_active_upstream",
+		Help: "Whether the upstream is the one currently serving requests.",
+	}, []string{"target"})
+	failovers := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "bothan_proxy_upstream_switches_total",
+		Help: "Number of times requests switched to the upstream.",
+	}, []string{"target"})
+	lastSuccess := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "bothan_proxy_upstream_last_success_timestamp_seconds",
+		Help: "Unix time of the last successful call to the upstream.",
+	}, []string{"target"})
+	for _, collector := range []prometheus.Collector{active, failovers, lastSuccess} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, u := range upstreams {
+		active.WithLabelValues(u.target).Set(0)
+	}
+	active.WithLabelValues(upstreams[0].target).Set(1)
+
+	return &failoverConn{
+		upstreams:   upstreams,
+		events:      events,
+		active:      active,
+		failovers:   failovers,
+		lastSuccess: lastSuccess,
+		current:     upstreams[0].target,
+	}, nil
+}
+
+// candidates returns the upstreams to try, healthy ones first, each group in priority order.
+func (c *failoverConn) candidates() []upstream {
+	healthy := make([]upstream, 0, len(c.upstreams))
+	var unhealthy []upstream
+	for _, u := range c.upstreams {
+		if usable(u.conn.GetState()) {
+			healthy = append(healthy, u)
+		} else {
+			unhealthy = append(unhealthy, u)
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+// healthy reports whether any upstream can serve requests.
+func (c *failoverConn) healthy() bool {
+	for _, u := range c.upstreams {
+		if usable(u.conn.GetState()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// usable reports whether a connection in the given state is expected to serve requests.
+func usable(state connectivity.State) bool {
+	return state != connectivity.TransientFailure && state != connectivity.Shutdown
+}
+
+func (c *failoverConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	var err error
+	for _, u := range c.candidates() {
+		err = u.conn.Invoke(ctx, method, args, reply, opts...)
+		if status.Code(err) == codes.Unavailable && ctx.Err() == nil {
+			continue
+		}
+
+		c.use(u.target)
+		if err == nil {
+			c.lastSuccess.WithLabelValues(u.target).SetToCurrentTime()
+		}
+		return err
+	}
+
+	return err
+}
+
+// NewStream opens the stream on the first upstream that accepts it. Only the opening is
+// retried: a stream that fails with Unavailable after it was opened is not moved to another
+// upstream, as messages may already have been exchanged.
+func (c *failoverConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	var err error
+	for _, u := range c.candidates() {
+		var stream grpc.ClientStream
+		stream, err = u.conn.NewStream(ctx, desc, method, opts...)
+		if status.Code(err) == codes.Unavailable && ctx.Err() == nil {
+			continue
+		}
+
+		c.use(u.target)
+		return stream, err
+	}
+
+	return nil, err
+}
+
+// use records that requests are served by the given upstream. The metrics are updated with
+// the current upstream so that concurrent switches leave them consistent, while the event is
+// emitted after, as hooks may be slow.
+func (c *failoverConn) use(target string) {
+	c.mu.Lock()
+	previous := c.current
+	if previous == target {
+		c.mu.Unlock()
+		return
+	}
+	c.current = target
+	c.active.WithLabelValues(previous).Set(0)
+	c.active.WithLabelValues(target).Set(1)
+	c.failovers.WithLabelValues(target).Inc()
+	c.mu.Unlock()
+
+	c.events.OnUpstreamSwitched(UpstreamSwitchEvent{From: previous, To: target})
+}
+
+// currentTarget returns the target of the upstream that served the last request.
+func (c *failoverConn) currentTarget() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.current
+}
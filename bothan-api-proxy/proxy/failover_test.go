@@ -0,0 +1,157 @@
+package proxy
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+type staticQueryServer struct {
+	query.UnimplementedQueryServer
+	price string
+}
+
+func (s *staticQueryServer) Prices(_ context.Context, req *query.QueryPricesRequest) (*query.QueryPricesResponse, error) {
+	prices := make([]*query.PriceData, 0, len(req.SignalIds))
+	for _, id := range req.SignalIds {
+		prices = append(prices, &query.PriceData{SignalId: id, Price: s.price, PriceStatus: query.PriceStatus_PRICE_STATUS_AVAILABLE})
+	}
+	return &query.QueryPricesResponse{Prices: prices}, nil
+}
+
+type switchHooks struct {
+	NopHooks
+	switches []UpstreamSwitchEvent
+}
+
+func (h *switchHooks) OnUpstreamSwitched(e UpstreamSwitchEvent) {
+	h.switches = append(h.switches, e)
+}
+
+func dialUpstream(t *testing.T, target string) upstream {
+	t.Helper()
+	conn, err := grpc.Dial(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return upstream{target: target, conn: conn}
+}
+
+func TestFailoverToFallbackUpstream(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	query.RegisterQueryServer(server, &staticQueryServer{price: "42"})
+	go server.Serve(listener)
+	defer server.Stop()
+
+	// Nothing listens on the primary, so every call to it fails with Unavailable.
+	primary := dialUpstream(t, "127.0.0.1:1")
+	fallback := dialUpstream(t, listener.Addr().String())
+
+	hooks := &switchHooks{}
+	conn, err := newFailoverConn([]upstream{primary, fallback}, NewEventBus(hooks), prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := query.NewQueryClient(conn).Prices(context.Background(), &query.QueryPricesRequest{SignalIds: []string{"crypto_price.btcusd"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Prices) != 1 || resp.Prices[0].Price != "42" {
+		t.Errorf("unexpected response %v", resp)
+	}
+
+	if conn.currentTarget() != fallback.target {
+		t.Errorf("expected the fallback to be active, got %s", conn.currentTarget())
+	}
+	if len(hooks.switches) != 1 || hooks.switches[0] != (UpstreamSwitchEvent{From: primary.target, To: fallback.target}) {
+		t.Errorf("unexpected switch events %+v", hooks.switches)
+	}
+}
+
+func TestFailoverStreamToFallbackUpstream(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	query.RegisterQueryServer(server, &staticQueryServer{price: "42"})
+	go server.Serve(listener)
+	defer server.Stop()
+
+	primary := dialUpstream(t, "127.0.0.1:1")
+	fallback := dialUpstream(t, listener.Addr().String())
+
+	conn, err := newFailoverConn([]upstream{primary, fallback}, NewEventBus(NopHooks{}), prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A unary method can be called over a stream, which opens the stream the way the
+	// server-streaming methods do.
+	stream, err := conn.NewStream(context.Background(), &grpc.StreamDesc{ServerStreams: true}, query.Query_Prices_FullMethodName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.SendMsg(&query.QueryPricesRequest{SignalIds: []string{"crypto_price.btcusd"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	var resp query.QueryPricesResponse
+	if err := stream.RecvMsg(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Prices) != 1 || resp.Prices[0].Price != "42" {
+		t.Errorf("unexpected response %v", &resp)
+	}
+
+	if conn.currentTarget() != fallback.target {
+		t.Errorf("expected the fallback to be active, got %s", conn.currentTarget())
+	}
+}
+
+func TestFailoverConcurrentSwitches(t *testing.T) {
+	a, b := upstream{target: "a"}, upstream{target: "b"}
+	conn, err := newFailoverConn([]upstream{a, b}, NewEventBus(NopHooks{}), prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(target string) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				conn.use(target)
+			}
+		}([]string{"a", "b"}[i%2])
+	}
+	wg.Wait()
+
+	current := conn.currentTarget()
+	for _, target := range []string{"a", "b"} {
+		expected := 0.0
+		if target == current {
+			expected = 1
+		}
+		if got := testutil.ToFloat64(conn.active.WithLabelValues(target)); got != expected {
+			t.Errorf("expected the active gauge of %s to be %v with %s current, got %v", target, expected, current, got)
+		}
+	}
+}
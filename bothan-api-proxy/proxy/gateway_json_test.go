@@ -0,0 +1,144 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"math"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files")
+
+// gatewayMarshaler returns the marshaler of the gateway mux of the proxy, so that changes of
+// its marshaler options are caught by the golden files.
+func gatewayMarshaler() runtime.Marshaler {
+	_, marshaler := runtime.MarshalerForRequest((&Server{}).newGatewayMux(), httptest.NewRequest("GET", "/", nil))
+	return marshaler
+}
+
+// TestGatewayJSONGolden renders every message of the query proto with the marshaler the
+// gateway uses for responses and compares the output with the golden files in
+// testdata/gateway, so that changes of field names or enum strings are noticed before they
+// break consumers. Run the test with -update to accept changes.
+func TestGatewayJSONGolden(t *testing.T) {
+	marshaler := gatewayMarshaler()
+
+	messages := query.File_query_query_proto.Messages()
+	for i := 0; i < messages.Len(); i++ {
+		desc := messages.Get(i)
+		t.Run(string(desc.Name()), func(t *testing.T) {
+			msg := dynamicpb.NewMessage(desc)
+			populate(msg)
+
+			b, err := marshaler.Marshal(msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// protojson randomizes its whitespace, so compare indented output.
+			var got bytes.Buffer
+			if err := json.Indent(&got, b, "", "  "); err != nil {
+				t.Fatal(err)
+			}
+			got.WriteByte('\n')
+
+			path := filepath.Join("testdata", "gateway", string(desc.Name())+".json")
+			if *updateGolden {
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, got.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("missing golden file, run the test with -update: %v", err)
+			}
+			if !bytes.Equal(got.Bytes(), want) {
+				t.Errorf("gateway JSON changed, got:\n%s\nwant:\n%s", got.Bytes(), want)
+			}
+		})
+	}
+}
+
+// populate sets every field of the message to a deterministic non-default value: strings to
+// their field name, integers to their field number, doubles to their field number plus a
+// half, enums to their last value and repeated fields to a single element.
+func populate(msg protoreflect.Message) {
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		switch {
+		case field.IsList():
+			list := msg.Mutable(field).List()
+			if field.Kind() == protoreflect.MessageKind {
+				elem := list.NewElement()
+				populate(elem.Message())
+				list.Append(elem)
+			} else {
+				list.Append(scalarValue(field))
+			}
+		case field.Kind() == protoreflect.MessageKind:
+			populate(msg.Mutable(field).Message())
+		default:
+			msg.Set(field, scalarValue(field))
+		}
+	}
+}
+
+func scalarValue(field protoreflect.FieldDescriptor) protoreflect.Value {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(string(field.Name()))
+	case protoreflect.Int32Kind:
+		return protoreflect.ValueOfInt32(int32(field.Number()))
+	case protoreflect.Uint32Kind:
+		return protoreflect.ValueOfUint32(uint32(field.Number()))
+	case protoreflect.Uint64Kind:
+		return protoreflect.ValueOfUint64(uint64(field.Number()))
+	case protoreflect.BoolKind:
+		// False is the zero value, which is left out of the JSON.
+		return protoreflect.ValueOfBool(true)
+	case protoreflect.DoubleKind:
+		// A fraction shows that doubles are written as JSON numbers, not strings.
+		return protoreflect.ValueOfFloat64(float64(field.Number()) + 0.5)
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+		return protoreflect.ValueOfEnum(values.Get(values.Len() - 1).Number())
+	default:
+		panic("unsupported field kind " + field.Kind().String())
+	}
+}
+
+// TestGatewayJSONUint64AsString checks that 64-bit integers are written as JSON strings, so
+// that JavaScript consumers, whose numbers are float64, do not lose precision above 2^53.
+func TestGatewayJSONUint64AsString(t *testing.T) {
+	marshaler := gatewayMarshaler()
+
+	for _, n := range []uint64{1<<53 + 1, math.MaxUint64} {
+		b, err := marshaler.Marshal(&query.ServerTiming{QueueTimeUs: n})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var fields map[string]any
+		if err := json.Unmarshal(b, &fields); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := fields["queueTimeUs"], strconv.FormatUint(n, 10); got != want {
+			t.Errorf("expected %q, got %#v", want, got)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// IDTranslationConfig defines the translation of external IDs, e.g. CoinGecko IDs or chain
+// denoms, to signal IDs, so that consumers built around other IDs can query the proxy
+// unchanged.
+type IDTranslationConfig struct {
+	// File is a JSON object mapping external IDs to signal IDs, e.g.
+	// {"bitcoin": "crypto_price.btcusd"}. Empty disables the translation.
+	File string `toml:"file"`
+}
+
+// idTranslation translates the external IDs of price requests to signal IDs, and the signal
+// IDs of their responses back to the external IDs the request used. IDs without a mapping are
+// passed through unchanged. A nil idTranslation translates nothing.
+type idTranslation struct {
+	signalIDs map[string]string
+}
+
+// requestIDs maps the signal IDs of a request to the external IDs they were translated from.
+type requestIDs map[string]string
+
+type idTranslationKey struct{}
+
+func newIDTranslation(config IDTranslationConfig) (*idTranslation, error) {
+	if config.File == "" {
+		return nil, nil
+	}
+
+	b, err := os.ReadFile(config.File)
+	if err != nil {
+		return nil, fmt.Errorf("error reading ID translation: %w", err)
+	}
+
+	var signalIDs map[string]string
+	if err := json.Unmarshal(b, &signalIDs); err != nil {
+		return nil, fmt.Errorf("error parsing ID translation %s: %w", config.File, err)
+	}
+	for external, signalID := range signalIDs {
+		if external == "" || signalID == "" {
+			return nil, fmt.Errorf("ID translation %s maps %q to %q, IDs must not be empty", config.File, external, signalID)
+		}
+	}
+
+	return &idTranslation{signalIDs: signalIDs}, nil
+}
+
+// translate returns the signal IDs of the given IDs and, for the translated ones, the external
+// ID of every signal ID. If several external IDs of a request map to the same signal ID, the
+// response uses the first of them.
+func (t *idTranslation) translate(ids []string) ([]string, requestIDs) {
+	if t == nil {
+		return ids, nil
+	}
+
+	translated := make([]string, len(ids))
+	var external requestIDs
+	for i, id := range ids {
+		signalID, ok := t.signalIDs[id]
+		if !ok {
+			translated[i] = id
+			continue
+		}
+
+		translated[i] = signalID
+		if external == nil {
+			external = make(requestIDs)
+		}
+		if _, ok := external[signalID]; !ok {
+			external[signalID] = id
+		}
+	}
+
+	return translated, external
+}
+
+// middleware translates the IDs of the GET /prices/{signal_ids} requests of the gateway and
+// stores the external IDs in the request context, where translateResponse picks them up.
+func (t *idTranslation) middleware(next http.Handler) http.Handler {
+	if t == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids, ok := strings.CutPrefix(r.URL.Path, pricesPath+"/")
+		if r.Method != http.MethodGet || !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		signalIDs, external := t.translate(strings.Split(ids, ","))
+		if external != nil {
+			u := *r.URL
+			u.Path = pricesPath + "/" + strings.Join(signalIDs, ",")
+			u.RawPath = ""
+			r = r.WithContext(withRequestIDs(r.Context(), external))
+			r.URL = &u
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withRequestIDs returns a copy of ctx that carries the external IDs of a request.
+func withRequestIDs(ctx context.Context, external requestIDs) context.Context {
+	if external == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, idTranslationKey{}, external)
+}
+
+// translateResponse replaces the signal IDs of a price response by the external IDs the
+// request used. It is a forward response option of the gateway, which calls it before
+// marshaling every response.
+func translateResponse(ctx context.Context, _ http.ResponseWriter, m proto.Message) error {
+	external, _ := ctx.Value(idTranslationKey{}).(requestIDs)
+	resp, ok := m.(*query.QueryPricesResponse)
+	if external == nil || !ok {
+		return nil
+	}
+
+	for _, price := range resp.Prices {
+		if id, ok := external[price.SignalId]; ok {
+			price.SignalId = id
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func writeIDTranslation(t *testing.T, content string) IDTranslationConfig {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ids.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return IDTranslationConfig{File: path}
+}
+
+func TestNewIDTranslation(t *testing.T) {
+	if ids, err := newIDTranslation(IDTranslationConfig{}); ids != nil || err != nil {
+		t.Errorf("expected no translation without a file, got %v, %v", ids, err)
+	}
+	if _, err := newIDTranslation(IDTranslationConfig{File: filepath.Join(t.TempDir(), "missing.json")}); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	if _, err := newIDTranslation(writeIDTranslation(t, `["bitcoin"]`)); err == nil {
+		t.Error("expected an error for a file that is not an object")
+	}
+	if _, err := newIDTranslation(writeIDTranslation(t, `{"bitcoin": ""}`)); err == nil {
+		t.Error("expected an error for an empty signal ID")
+	}
+}
+
+func TestIDTranslationGateway(t *testing.T) {
+	ids, err := newIDTranslation(writeIDTranslation(t, `{"bitcoin": "crypto_price.btcusd", "btc": "crypto_price.btcusd"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gwmux := runtime.NewServeMux(runtime.WithForwardResponseOption(translateResponse))
+	if err := query.RegisterQueryHandlerClient(context.Background(), gwmux, stubQueryClient{}); err != nil {
+		t.Fatal(err)
+	}
+	handler := ids.middleware(normalizeSignalIDs(gwmux, gwmux))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prices/bitcoin,crypto_price.ethusd,btc", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body)
+	}
+
+	var body struct {
+		Prices []struct {
+			SignalID string `json:"signalId"`
+		} `json:"prices"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	// Both external IDs of BTC are queried once and answered with the first of them.
+	var got []string
+	for _, price := range body.Prices {
+		got = append(got, price.SignalID)
+	}
+	if len(got) != 2 || got[0] != "bitcoin" || got[1] != "crypto_price.ethusd" {
+		t.Errorf("expected the external IDs in the response, got %v", got)
+	}
+}
+
+func TestIDTranslationProtobuf(t *testing.T) {
+	ids, err := newIDTranslation(writeIDTranslation(t, `{"bitcoin": "crypto_price.btcusd"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := protobufHandler((&Server{}).newGatewayMux(), stubQueryClient{}, CostConfig{}, ids, http.NotFoundHandler())
+
+	resp := postProtobuf(t, handler, &query.QueryPricesRequest{SignalIds: []string{"bitcoin"}})
+	body, _ := io.ReadAll(resp.Body)
+	var prices query.QueryPricesResponse
+	if err := proto.Unmarshal(body, &prices); err != nil {
+		t.Fatal(err)
+	}
+	if len(prices.Prices) != 1 || prices.Prices[0].SignalId != "bitcoin" {
+		t.Errorf("expected the external ID in the response, got %v", &prices)
+	}
+}
@@ -0,0 +1,18 @@
+package proxy
+
+import (
+	"context"
+	"net"
+)
+
+// listen opens the listener of the HTTP server. With reusePort, several processes can listen
+// on the same address, which allows a new binary to start accepting connections before the
+// old one stops.
+func listen(ctx context.Context, addr string, reusePort bool) (net.Listener, error) {
+	var lc net.ListenConfig
+	if reusePort {
+		lc.Control = reusePortControl
+	}
+
+	return lc.Listen(ctx, "tcp", addr)
+}
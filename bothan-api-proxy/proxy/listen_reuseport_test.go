@@ -0,0 +1,29 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package proxy
+
+import (
+	"context"
+	"testing"
+)
+
+func TestListenReusePort(t *testing.T) {
+	ctx := context.Background()
+	first, err := listen(ctx, "127.0.0.1:0", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+
+	// A second process, e.g. an upgraded binary, can listen on the same address.
+	second, err := listen(ctx, first.Addr().String(), true)
+	if err != nil {
+		t.Fatalf("expected to listen on %s again: %v", first.Addr(), err)
+	}
+	second.Close()
+
+	if l, err := listen(ctx, first.Addr().String(), false); err == nil {
+		l.Close()
+		t.Error("expected listening without reuse_port to fail")
+	}
+}
@@ -0,0 +1,92 @@
+package proxy
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// methodOverrideHeader carries the method of a request that is sent as POST, for clients
+	// in environments that only permit GET and POST.
+	methodOverrideHeader = "X-HTTP-Method-Override"
+	formContentType      = "application/x-www-form-urlencoded"
+)
+
+// methodOverride replaces the method of POST requests with the method of their
+// X-HTTP-Method-Override header, before any other middleware sees them. Like the gateway
+// does, the parameters of form bodies are taken as query parameters, so that e.g. a POST of
+// status=available with the override GET filters the prices as the GET request would.
+// Invalid methods are rejected with a gateway error.
+func methodOverride(mux *runtime.ServeMux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		override := r.Header.Get(methodOverrideHeader)
+		if r.Method != http.MethodPost || override == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		method := strings.ToUpper(strings.TrimSpace(override))
+		if !isMethod(method) {
+			err := status.Errorf(codes.InvalidArgument, "invalid %s %q", methodOverrideHeader, override)
+			runtime.HTTPError(r.Context(), mux, &runtime.JSONPb{}, w, r, err)
+			return
+		}
+
+		r2 := r.Clone(r.Context())
+		r2.Method = method
+		r2.Header.Del(methodOverrideHeader)
+		if r.Header.Get("Content-Type") == formContentType {
+			if err := r.ParseForm(); err != nil {
+				runtime.HTTPError(r.Context(), mux, &runtime.JSONPb{}, w, r, status.Error(codes.InvalidArgument, err.Error()))
+				return
+			}
+			r2.URL.RawQuery = r.Form.Encode()
+			r2.Body = http.NoBody
+			r2.ContentLength = 0
+			r2.Header.Del("Content-Type")
+		}
+
+		next.ServeHTTP(w, r2)
+	})
+}
+
+// isMethod reports whether s is a plausible HTTP method, i.e. a non-empty upper case word.
+func isMethod(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// headRequests answers HEAD requests like the GET requests of the same URL, without their
+// body, as the gateway only routes GET.
+func headRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		r2 := *r
+		r2.Method = http.MethodGet
+		next.ServeHTTP(headWriter{w}, &r2)
+	})
+}
+
+// headWriter drops the body of a response to a HEAD request.
+type headWriter struct {
+	http.ResponseWriter
+}
+
+func (w headWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
@@ -0,0 +1,83 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func newOverrideGateway(t *testing.T, client query.QueryClient) http.Handler {
+	t.Helper()
+
+	gwmux := runtime.NewServeMux()
+	if err := query.RegisterQueryHandlerClient(context.Background(), gwmux, client); err != nil {
+		t.Fatal(err)
+	}
+	return methodOverride(gwmux, headRequests(filterStatuses(gwmux, gwmux)))
+}
+
+func TestHeadRequests(t *testing.T) {
+	handler := newOverrideGateway(t, stubQueryClient{})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/prices/btc", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d", rec.Code)
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("expected no body, got %s", rec.Body)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected the headers of the GET request, got content type %q", ct)
+	}
+}
+
+func TestMethodOverride(t *testing.T) {
+	client := recordingQueryClient{requests: make(chan *query.QueryPricesRequest, 1)}
+	handler := newOverrideGateway(t, client)
+
+	post := func(override, contentType, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/prices/btc", strings.NewReader(body))
+		req.Header.Set(methodOverrideHeader, override)
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := post("get", "", ""); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"signalId":"btc"`) {
+		t.Fatalf("expected the GET response, got %d: %s", rec.Code, rec.Body)
+	}
+	<-client.requests
+
+	// Form parameters are query parameters, which the middlewares of GET requests see too.
+	if rec := post("GET", formContentType, "status=available"); rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body)
+	}
+	expected := []query.PriceStatus{query.PriceStatus_PRICE_STATUS_AVAILABLE}
+	if req := <-client.requests; !slices.Equal(req.Statuses, expected) {
+		t.Errorf("expected statuses %v upstream, got %v", expected, req.Statuses)
+	}
+
+	if rec := post("HEAD", "", ""); rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+		t.Errorf("expected an empty GET response, got %d: %s", rec.Code, rec.Body)
+	}
+	<-client.requests
+
+	if rec := post("G E T", "", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an invalid method, got %d", rec.Code)
+	}
+	// The gateway reports the unrouted method as Unimplemented.
+	if rec := post("", "", ""); rec.Code != http.StatusNotImplemented {
+		t.Errorf("expected POST without override to be routed as is, got %d", rec.Code)
+	}
+}
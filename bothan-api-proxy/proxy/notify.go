@@ -0,0 +1,72 @@
+package proxy
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/grpclog"
+)
+
+// sdNotify sends the given state to the service manager if the proxy was started by systemd
+// with Type=notify, and does nothing otherwise.
+func sdNotify(state string) error {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return nil
+	}
+	// Sockets starting with @ live in the abstract namespace.
+	if strings.HasPrefix(socket, "@") {
+		socket = "\x00" + socket[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(state))
+	return err
+}
+
+// watchdogInterval returns the interval at which the proxy pings the systemd watchdog, half of
+// the configured WatchdogSec, or zero if the watchdog is not enabled for this process.
+func watchdogInterval() time.Duration {
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0
+	}
+
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0
+	}
+
+	return time.Duration(usec) * time.Microsecond / 2
+}
+
+// watchdog pings the systemd watchdog every interval while at least one upstream is healthy,
+// until the context is cancelled. If all upstreams stay unavailable for longer than the
+// watchdog timeout, systemd considers the proxy dead and applies its restart policy.
+func (s *Server) watchdog(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if !s.failover.healthy() {
+			continue
+		}
+		if err := sdNotify("WATCHDOG=1"); err != nil {
+			grpclog.Errorf("error pinging systemd watchdog: %v", err)
+		}
+	}
+}
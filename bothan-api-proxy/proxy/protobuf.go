@@ -0,0 +1,118 @@
+package proxy
+
+import (
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+	clientv2 "github.com/bandprotocol/bothan/bothan-api/client/go-client/v2"
+)
+
+const (
+	protobufContentType = "application/x-protobuf"
+	pricesPath          = "/prices"
+
+	// maxProtobufBodySize bounds the size of a binary request body.
+	maxProtobufBodySize = 1 << 20
+)
+
+// protobufHandler serves POST /prices requests with a binary QueryPricesRequest body by
+// calling the upstream directly and writing the binary QueryPricesResponse, which spares
+// consumers the JSON encoding of the gateway. Errors are written as a binary google.rpc.Status.
+// The IDs are translated and the signal IDs normalized like on the gateway routes and requests
+// above the cost budget are rejected. The response goes through the forward response options
+// of the gateway mux, so it is checked, recorded, redacted and translated like the responses
+// of the gateway routes, including by options added with AddServeMuxOptions. All other
+// requests are passed to next.
+func protobufHandler(mux *runtime.ServeMux, client query.QueryClient, costs CostConfig, ids *idTranslation, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != pricesPath || !isProtobuf(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxProtobufBodySize))
+		if err != nil {
+			writeProtobufError(w, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		var req query.QueryPricesRequest
+		if err := proto.Unmarshal(body, &req); err != nil {
+			writeProtobufError(w, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		var external requestIDs
+		req.SignalIds, external = ids.translate(req.SignalIds)
+		if req.SignalIds, err = clientv2.NormalizeSignalIDs(req.SignalIds); err != nil {
+			writeProtobufError(w, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		if err := costs.checkSignalIDs(w, req.SignalIds); err != nil {
+			writeProtobufError(w, err)
+			return
+		}
+
+		ctx := metadata.NewOutgoingContext(r.Context(), incomingMetadata(r))
+		resp, err := client.Prices(ctx, &req)
+		if err != nil {
+			writeProtobufError(w, err)
+			return
+		}
+
+		ctx = withRequestIDs(r.Context(), external)
+		for _, opt := range mux.GetForwardResponseOptions() {
+			if err := opt(ctx, w, resp); err != nil {
+				writeProtobufError(w, err)
+				return
+			}
+		}
+
+		writeProtobuf(w, http.StatusOK, resp)
+	})
+}
+
+// isProtobuf reports whether the request body is a binary protobuf message.
+func isProtobuf(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == protobufContentType
+}
+
+// incomingMetadata converts the request headers into gRPC metadata the same way the gateway
+// does, so that trace context reaches the upstream on this route too.
+func incomingMetadata(r *http.Request) metadata.MD {
+	md := metadata.MD{}
+	for key, values := range r.Header {
+		if name, ok := traceHeaderMatcher(key); ok {
+			md.Append(name, values...)
+		}
+	}
+
+	return md
+}
+
+func writeProtobufError(w http.ResponseWriter, err error) {
+	s := status.Convert(err)
+	writeProtobuf(w, runtime.HTTPStatusFromCode(s.Code()), s.Proto())
+}
+
+func writeProtobuf(w http.ResponseWriter, code int, m proto.Message) {
+	b, err := proto.Marshal(m)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", protobufContentType)
+	w.WriteHeader(code)
+	_, _ = w.Write(b)
+}
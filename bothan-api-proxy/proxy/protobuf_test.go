@@ -0,0 +1,102 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+type stubQueryClient struct {
+	query.QueryClient
+}
+
+func (stubQueryClient) Prices(_ context.Context, in *query.QueryPricesRequest, _ ...grpc.CallOption) (*query.QueryPricesResponse, error) {
+	if len(in.SignalIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no signal ids")
+	}
+
+	var prices []*query.PriceData
+	for _, id := range in.SignalIds {
+		prices = append(prices, &query.PriceData{SignalId: id, Price: "1", PriceStatus: query.PriceStatus_PRICE_STATUS_AVAILABLE})
+	}
+	return &query.QueryPricesResponse{Prices: prices}, nil
+}
+
+func postProtobuf(t *testing.T, handler http.Handler, m proto.Message) *http.Response {
+	body, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/prices", bytes.NewReader(body))
+	req.Header.Set("Content-Type", protobufContentType)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec.Result()
+}
+
+func TestProtobufPrices(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected call of the next handler")
+	})
+	handler := protobufHandler((&Server{}).newGatewayMux(), stubQueryClient{}, CostConfig{}, nil, next)
+
+	resp := postProtobuf(t, handler, &query.QueryPricesRequest{SignalIds: []string{"crypto_price.btcusd"}})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != protobufContentType {
+		t.Errorf("expected content type %q, got %q", protobufContentType, ct)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	var prices query.QueryPricesResponse
+	if err := proto.Unmarshal(body, &prices); err != nil {
+		t.Fatal(err)
+	}
+	if len(prices.Prices) != 1 || prices.Prices[0].SignalId != "crypto_price.btcusd" {
+		t.Errorf("unexpected response %v", &prices)
+	}
+}
+
+func TestProtobufPricesError(t *testing.T) {
+	handler := protobufHandler((&Server{}).newGatewayMux(), stubQueryClient{}, CostConfig{}, nil, http.NotFoundHandler())
+
+	resp := postProtobuf(t, handler, &query.QueryPricesRequest{})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", resp.StatusCode)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	var s spb.Status
+	if err := proto.Unmarshal(body, &s); err != nil {
+		t.Fatal(err)
+	}
+	if codes.Code(s.Code) != codes.InvalidArgument {
+		t.Errorf("expected code %v, got %v", codes.InvalidArgument, codes.Code(s.Code))
+	}
+}
+
+func TestProtobufPassesOtherRequests(t *testing.T) {
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+	handler := protobufHandler((&Server{}).newGatewayMux(), stubQueryClient{}, CostConfig{}, nil, next)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/prices/crypto_price.btcusd", nil))
+	if !called {
+		t.Error("expected the next handler to serve a JSON request")
+	}
+}
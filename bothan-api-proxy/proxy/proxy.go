@@ -0,0 +1,393 @@
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/grpclog"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+// Server is an HTTP proxy that translates REST calls into gRPC calls to a Bothan server.
+type Server struct {
+	config    Config
+	events    *EventBus
+	registry  *prometheus.Registry
+	usage     *UsageTracker
+	timeouts  *timeouts
+	signals   *signalWatch
+	upstream  *upstreamMetrics
+	redaction *redaction
+	ids       *idTranslation
+	recent    *recentPrices
+	idCase    *signalCase
+	apiKeys   *apiKeyStore
+
+	muxOptions []runtime.ServeMuxOption
+
+	startedAt time.Time
+	failover  *failoverConn
+}
+
+// New creates a new Server from the given configuration. The given hooks receive the
+// lifecycle events of the server.
+func New(config Config, hooks ...Hooks) (*Server, error) {
+	if config.APIKeys.Enabled && config.Usage.AdminToken == "" {
+		return nil, errors.New("api_keys requires an admin_token to manage the keys")
+	}
+	if config.Tunnel.Enabled && !config.APIKeys.Enabled && config.Tunnel.Token == "" {
+		return nil, errors.New("tunnel requires a token unless api_keys is enabled")
+	}
+	if err := config.Staleness.validate(); err != nil {
+		return nil, err
+	}
+
+	registry := prometheus.NewRegistry()
+	usage, err := NewUsageTracker(config.Usage, registry)
+	if err != nil {
+		return nil, err
+	}
+
+	timeouts, err := newTimeouts(config.Timeouts, registry)
+	if err != nil {
+		return nil, err
+	}
+
+	signals, err := newSignalWatch(config.SignalWatch, registry)
+	if err != nil {
+		return nil, err
+	}
+
+	upstream, err := newUpstreamMetrics(registry)
+	if err != nil {
+		return nil, err
+	}
+
+	redaction, err := newRedaction(config.Redaction)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := newIDTranslation(config.IDTranslation)
+	if err != nil {
+		return nil, err
+	}
+
+	recent, err := newRecentPrices(config.Recent)
+	if err != nil {
+		return nil, err
+	}
+
+	idCase, err := newSignalCase(config.Routing)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		config:    config,
+		events:    NewEventBus(hooks...),
+		registry:  registry,
+		usage:     usage,
+		timeouts:  timeouts,
+		signals:   signals,
+		upstream:  upstream,
+		redaction: redaction,
+		ids:       ids,
+		recent:    recent,
+		idCase:    idCase,
+	}, nil
+}
+
+// Events returns the event bus of the server, which can be used to subscribe additional hooks.
+func (s *Server) Events() *EventBus {
+	return s.events
+}
+
+// AddServeMuxOptions adds options to the gateway mux of the server, e.g. marshalers,
+// metadata annotators or error handlers. The options are applied after the options of the
+// proxy, so options that replace a setting, like runtime.WithIncomingHeaderMatcher, take
+// precedence. It must be called before Run.
+func (s *Server) AddServeMuxOptions(opts ...runtime.ServeMuxOption) {
+	s.muxOptions = append(s.muxOptions, opts...)
+}
+
+// Reload applies the reloadable parts of the given configuration, which currently is the
+// usage configuration, including the file the admin token is read from. Changes to the listen
+// and upstream addresses require a restart.
+func (s *Server) Reload(config Config) error {
+	if err := s.usage.SetConfig(config.Usage); err != nil {
+		return err
+	}
+	s.events.OnConfigReloaded(ConfigReloadedEvent{Config: config})
+
+	return nil
+}
+
+// Run connects to the upstream gRPC servers and serves HTTP requests until the context is
+// cancelled, after which the server is shut down gracefully.
+func (s *Server) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Note: Make sure the gRPC server is running properly and accessible
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	opts = append(opts, s.signals.dialOptions()...)
+	opts = append(opts, s.config.RequestLog.dialOptions()...)
+	var upstreams []upstream
+	for _, target := range s.config.Grpc.targets() {
+		conn, err := grpc.DialContext(ctx, target, opts...)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		go s.watchUpstream(ctx, target, conn)
+		upstreams = append(upstreams, upstream{target: target, conn: conn})
+	}
+
+	go s.signals.run(ctx)
+
+	failover, err := newFailoverConn(upstreams, s.events, s.registry)
+	if err != nil {
+		return err
+	}
+	s.failover = failover
+
+	client := query.NewQueryClient(failover)
+	go s.idCase.run(ctx, client)
+	gwmux := s.newGatewayMux()
+	if err := query.RegisterQueryHandlerClient(ctx, gwmux, client); err != nil {
+		return err
+	}
+
+	readiness, err := newReadiness(s.config.Readiness, client, failover.healthy)
+	if err != nil {
+		return err
+	}
+
+	apiKeys, err := openAPIKeys(s.config.APIKeys)
+	if err != nil {
+		return err
+	}
+	defer apiKeys.Close()
+	s.apiKeys = apiKeys
+
+	accessLog, err := openAccessLog(s.config.AccessLog)
+	if err != nil {
+		return err
+	}
+	if accessLog != nil {
+		defer accessLog.Close()
+		s.events.Subscribe(accessLog)
+	}
+
+	// Outermost last: signal IDs are final before the status filter and cost see them, and
+	// responses are checked for staleness and recorded before fields are redacted.
+	handler := s.config.Cost.middleware(gwmux)
+	handler = filterStatuses(gwmux, handler)
+	handler = normalizeSignalIDs(gwmux, handler)
+	handler = s.idCase.middleware(handler)
+	handler = s.ids.middleware(handler)
+	handler = protobufHandler(gwmux, client, s.config.Cost, s.ids, handler)
+	handler = s.timeouts.middleware(handler)
+	handler = s.recent.middleware(gwmux, s.ids, handler)
+	handler = s.recent.changeMiddleware(gwmux, s.ids, handler)
+	handler = s.config.Staleness.middleware(gwmux, handler)
+	handler = s.redaction.middleware(s.usage, handler)
+	if s.config.Chaos.Enabled {
+		if handler, err = chaosMiddleware(s.config.Chaos, handler); err != nil {
+			return err
+		}
+	}
+
+	handler = checksum(handler)
+	handler = headRequests(handler)
+	handler = methodOverride(gwmux, handler)
+	handler = s.apiKeys.middleware(gwmux, s.usage, handler)
+
+	mux, err := s.newMux(gwmux, handler, readiness)
+	if err != nil {
+		return err
+	}
+
+	listener, err := listen(ctx, s.config.GoProxy.Addr, s.config.GoProxy.ReusePort)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{Handler: mux}
+	if s.config.TLS.enabled() {
+		certs, err := newCertReloader(s.config.TLS)
+		if err != nil {
+			listener.Close()
+			return err
+		}
+		server.TLSConfig = certs.tlsConfig()
+		listener = tls.NewListener(listener, server.TLSConfig)
+
+		certWatcher := newFileWatcher(s.config.TLS.CertFile, s.config.TLS.KeyFile)
+		go certWatcher.watch(ctx, secretPollInterval, func() {
+			if err := certs.reload(); err != nil {
+				grpclog.Errorf("error reloading TLS certificate: %v", err)
+			}
+		})
+	}
+	go s.usage.watchAdminToken(ctx, secretPollInterval)
+
+	s.startedAt = time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+
+	s.events.OnStart(StartEvent{Addr: listener.Addr().String()})
+	if err := sdNotify("READY=1"); err != nil {
+		grpclog.Errorf("error notifying systemd: %v", err)
+	}
+	if interval := watchdogInterval(); interval > 0 {
+		go s.watchdog(ctx, interval)
+	}
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		_ = sdNotify("STOPPING=1")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// newMux routes the requests of the proxy: the endpoints of the proxy itself, and every other
+// path to the gateway handler. Trailing slashes are only removed from the paths of the gateway,
+// as the admin UI is served under /admin/ and a redirect would send /admin back to /admin/.
+func (s *Server) newMux(gwmux *runtime.ServeMux, gateway http.Handler, readiness http.Handler) (*http.ServeMux, error) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(s.registry, promhttp.HandlerOpts{}))
+	mux.Handle("/readyz", readiness)
+	mux.Handle("/admin/usage", s.usage)
+	mux.Handle("/admin/", s.adminHandler())
+	if s.config.Tunnel.Enabled {
+		tunnel, err := tunnelHandler(s.config.Tunnel, s.config.Grpc.targets())
+		if err != nil {
+			return nil, err
+		}
+		mux.Handle(s.config.Tunnel.path(), s.instrument(s.apiKeys.middleware(gwmux, s.usage, tunnel)))
+	}
+
+	gateway = s.instrument(streamBridge(gateway, s.config.Tunnel.checkOrigin))
+	if s.config.Routing.TrailingSlash {
+		gateway = trailingSlash(gateway)
+	}
+	mux.Handle("/", gateway)
+
+	return mux, nil
+}
+
+// newGatewayMux creates the gateway mux with the options of the proxy, followed by the options
+// added with AddServeMuxOptions.
+func (s *Server) newGatewayMux() *runtime.ServeMux {
+	opts := []runtime.ServeMuxOption{
+		runtime.WithIncomingHeaderMatcher(traceHeaderMatcher),
+		runtime.WithForwardResponseOption(setServerTiming),
+		runtime.WithForwardResponseOption(checkStaleness),
+		runtime.WithForwardResponseOption(s.recent.observe),
+		runtime.WithForwardResponseOption(redactResponse),
+		runtime.WithForwardResponseOption(translateResponse),
+	}
+	return runtime.NewServeMux(append(opts, s.muxOptions...)...)
+}
+
+// watchUpstream emits an event whenever the upstream connection becomes ready or stops being
+// ready, until the context is cancelled.
+func (s *Server) watchUpstream(ctx context.Context, target string, conn *grpc.ClientConn) {
+	connected := false
+	previous := conn.GetState()
+	for state := previous; ; state = conn.GetState() {
+		s.upstream.observe(target, previous, state)
+		previous = state
+
+		event := UpstreamEvent{Target: target, State: state}
+		switch {
+		case state == connectivity.Ready && !connected:
+			connected = true
+			s.events.OnUpstreamConnected(event)
+		case state != connectivity.Ready && connected:
+			connected = false
+			s.events.OnUpstreamLost(event)
+		}
+
+		// Reconnect eagerly so that a lost upstream is noticed before the next request.
+		if state == connectivity.Idle {
+			conn.Connect()
+		}
+
+		if !conn.WaitForStateChange(ctx, state) {
+			return
+		}
+	}
+}
+
+// instrument records the usage of every request and emits its completion event.
+func (s *Server) instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		consumer := s.usage.Identify(r)
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(sw, r)
+
+		s.usage.Record(consumer, sw.status)
+		s.events.OnRequestCompleted(RequestEvent{
+			Method:   r.Method,
+			Path:     r.URL.Path,
+			Query:    r.URL.RawQuery,
+			Status:   sw.status,
+			Duration: time.Since(start),
+			Consumer: consumer,
+		})
+	})
+}
+
+// statusWriter captures the status code written by the wrapped handler.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack lets the stream bridge take over the connection for WebSockets.
+func (w *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("connection cannot be hijacked")
+	}
+	w.status = http.StatusSwitchingProtocols
+
+	return h.Hijack()
+}
@@ -0,0 +1,46 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestAddServeMuxOptions(t *testing.T) {
+	server, err := New(Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.AddServeMuxOptions(
+		runtime.WithForwardResponseOption(func(_ context.Context, w http.ResponseWriter, _ proto.Message) error {
+			w.Header().Set("X-Embedder", "yes")
+			return nil
+		}),
+		runtime.WithRoutingErrorHandler(func(_ context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, _ *http.Request, _ int) {
+			w.WriteHeader(http.StatusTeapot)
+		}),
+	)
+
+	gwmux := server.newGatewayMux()
+	if err := query.RegisterQueryHandlerClient(context.Background(), gwmux, stubQueryClient{}); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	gwmux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prices/btc", nil))
+	if rec.Code != http.StatusOK || rec.Header().Get("X-Embedder") != "yes" {
+		t.Errorf("expected the added forward option to run, got %d with headers %v", rec.Code, rec.Header())
+	}
+
+	rec = httptest.NewRecorder()
+	gwmux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+	if rec.Code != http.StatusTeapot {
+		t.Errorf("expected the added routing error handler to be used, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,107 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// ReadinessConfig defines when the proxy reports itself ready on /readyz.
+type ReadinessConfig struct {
+	// MinAvailableSignals keeps /readyz failing after a start until the upstream reports at
+	// least this many signals with an available price, so that no traffic is routed to a
+	// Bothan server that is still populating its feeds. While warming up, each probe queries
+	// the prices of every signal of the upstream registry, so the upstream starts computing
+	// them without waiting for consumer traffic. Zero only requires a usable upstream.
+	MinAvailableSignals int `toml:"min_available_signals"`
+}
+
+// warmupBatchSize is the number of signals whose prices a warmup queries at once.
+const warmupBatchSize = 100
+
+// readiness serves /readyz, which fails while no upstream is usable and, until the warmup is
+// complete, while fewer than the configured number of signals are available. Probes that fail
+// the warmup query the prices of the registry, which is what warms the upstream up. Once warmed up,
+// the signals are no longer counted, so that a feed going down later does not take the proxy
+// out of rotation along with it.
+type readiness struct {
+	minAvailable int
+	client       query.QueryClient
+	healthy      func() bool
+
+	warm atomic.Bool
+}
+
+func newReadiness(config ReadinessConfig, client query.QueryClient, healthy func() bool) (*readiness, error) {
+	if config.MinAvailableSignals < 0 {
+		return nil, fmt.Errorf("invalid min_available_signals %d", config.MinAvailableSignals)
+	}
+
+	r := &readiness{minAvailable: config.MinAvailableSignals, client: client, healthy: healthy}
+	r.warm.Store(config.MinAvailableSignals == 0)
+	return r, nil
+}
+
+func (r *readiness) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !r.healthy() {
+		http.Error(w, "no usable upstream", http.StatusServiceUnavailable)
+		return
+	}
+
+	if !r.warm.Load() {
+		available, total, err := r.warmUp(req.Context())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error warming up: %v", err), http.StatusServiceUnavailable)
+			return
+		}
+		if available < r.minAvailable {
+			msg := fmt.Sprintf("warming up: %d of %d signals available, %d required", available, total, r.minAvailable)
+			http.Error(w, msg, http.StatusServiceUnavailable)
+			return
+		}
+		r.warm.Store(true)
+	}
+
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+// warmUp lists the signals of the upstream registry page by page and queries their prices one
+// page at a time, which makes the upstream compute the prices of signals no consumer has
+// queried yet. It returns how many of the signals have an available price, and how many there
+// are.
+func (r *readiness) warmUp(ctx context.Context) (int, int, error) {
+	available, total := 0, 0
+	pageToken := ""
+	for {
+		resp, err := r.client.Signals(ctx, &query.QuerySignalsRequest{PageToken: pageToken, PageSize: warmupBatchSize})
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if len(resp.Signals) > 0 {
+			signalIDs := make([]string, len(resp.Signals))
+			for i, signal := range resp.Signals {
+				signalIDs[i] = signal.SignalId
+			}
+
+			prices, err := r.client.Prices(ctx, &query.QueryPricesRequest{SignalIds: signalIDs})
+			if err != nil {
+				return 0, 0, err
+			}
+			for _, price := range prices.Prices {
+				if price.PriceStatus == query.PriceStatus_PRICE_STATUS_AVAILABLE {
+					available++
+				}
+			}
+		}
+		total += len(resp.Signals)
+
+		if resp.NextPageToken == "" {
+			return available, total, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
@@ -0,0 +1,114 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+type signalsQueryClient struct {
+	query.QueryClient
+	pages   [][]*query.SignalInfo
+	calls   int
+	queried []string
+}
+
+func (c *signalsQueryClient) Signals(_ context.Context, in *query.QuerySignalsRequest, _ ...grpc.CallOption) (*query.QuerySignalsResponse, error) {
+	c.calls++
+	page := 0
+	if in.PageToken != "" {
+		page = 1
+	}
+
+	resp := &query.QuerySignalsResponse{Signals: c.pages[page]}
+	if page+1 < len(c.pages) {
+		resp.NextPageToken = "next"
+	}
+	return resp, nil
+}
+
+// Prices returns the statuses the pages list for the signals, standing in for prices the
+// upstream computes on request.
+func (c *signalsQueryClient) Prices(_ context.Context, in *query.QueryPricesRequest, _ ...grpc.CallOption) (*query.QueryPricesResponse, error) {
+	c.queried = append(c.queried, in.SignalIds...)
+	resp := &query.QueryPricesResponse{}
+	for _, id := range in.SignalIds {
+		for _, page := range c.pages {
+			for _, signal := range page {
+				if signal.SignalId == id {
+					resp.Prices = append(resp.Prices, &query.PriceData{SignalId: id, PriceStatus: signal.PriceStatus})
+				}
+			}
+		}
+	}
+	return resp, nil
+}
+
+func signalInfo(id string, status query.PriceStatus) *query.SignalInfo {
+	return &query.SignalInfo{SignalId: id, PriceStatus: status}
+}
+
+func TestReadinessWarmup(t *testing.T) {
+	client := &signalsQueryClient{pages: [][]*query.SignalInfo{
+		{signalInfo("btc", query.PriceStatus_PRICE_STATUS_AVAILABLE), signalInfo("eth", query.PriceStatus_PRICE_STATUS_UNAVAILABLE)},
+		{signalInfo("sol", query.PriceStatus_PRICE_STATUS_UNAVAILABLE)},
+	}}
+	healthy := true
+	r, err := newReadiness(ReadinessConfig{MinAvailableSignals: 2}, client, func() bool { return healthy })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	probe := func() int {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		return rec.Code
+	}
+
+	if code := probe(); code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 while warming up, got %d", code)
+	}
+	if got := strings.Join(client.queried, ","); got != "btc,eth,sol" {
+		t.Fatalf("expected the warmup to query the prices of every signal, got %q", got)
+	}
+
+	client.pages[1][0].PriceStatus = query.PriceStatus_PRICE_STATUS_AVAILABLE
+	if code := probe(); code != http.StatusOK {
+		t.Fatalf("expected 200 once enough signals are available, got %d", code)
+	}
+
+	// The signals are no longer counted after the warmup, but the upstream still is.
+	calls := client.calls
+	client.pages[0][0].PriceStatus = query.PriceStatus_PRICE_STATUS_UNAVAILABLE
+	if code := probe(); code != http.StatusOK || client.calls != calls {
+		t.Errorf("expected 200 without listing signals, got %d after %d calls", code, client.calls-calls)
+	}
+	healthy = false
+	if code := probe(); code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 without a usable upstream, got %d", code)
+	}
+}
+
+func TestReadinessWithoutWarmup(t *testing.T) {
+	client := &signalsQueryClient{}
+	r, err := newReadiness(ReadinessConfig{}, client, func() bool { return true })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusOK || client.calls != 0 || len(client.queried) != 0 {
+		t.Errorf("expected 200 without listing signals, got %d after %d calls", rec.Code, client.calls)
+	}
+
+	if _, err := newReadiness(ReadinessConfig{MinAvailableSignals: -1}, client, nil); err == nil {
+		t.Error("expected an error for a negative minimum")
+	}
+}
@@ -0,0 +1,193 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+const (
+	// recentSuffix is the suffix of the paths of the recent observations of a signal, e.g.
+	// /prices/crypto_price.btcusd/recent.
+	recentSuffix = "/recent"
+	// recentParam is the query parameter with the number of observations to return, by
+	// default all that are kept.
+	recentParam = "n"
+)
+
+// RecentConfig defines the in-memory history of the prices the proxy serves, which is
+// available on GET /prices/{signal_id}/recent?n=100 for quick local charting, and on
+// GET /prices/{signal_ids}/change?window=1h for alerts, without requiring history support of
+// the upstream.
+type RecentConfig struct {
+	// Observations is the number of observations kept per signal. Zero disables the history.
+	Observations int `toml:"observations"`
+}
+
+// recentPrices keeps the last observations of every signal whose price the gateway served,
+// in a ring buffer per signal. A nil recentPrices keeps nothing.
+type recentPrices struct {
+	size int
+
+	mu     sync.Mutex
+	series map[string]*priceSeries
+}
+
+// priceSeries is a ring buffer of the observations of a signal, next is the index the next
+// observation is written to.
+type priceSeries struct {
+	observations []observation
+	next         int
+}
+
+type observation struct {
+	time  time.Time
+	price *query.PriceData
+}
+
+func newRecentPrices(config RecentConfig) (*recentPrices, error) {
+	if config.Observations < 0 {
+		return nil, fmt.Errorf("invalid recent observations %d", config.Observations)
+	}
+	if config.Observations == 0 {
+		return nil, nil
+	}
+
+	return &recentPrices{size: config.Observations, series: make(map[string]*priceSeries)}, nil
+}
+
+// observe records the prices of a price response. It is a forward response option of the
+// gateway, which calls it before marshaling every response, and runs before the response is
+// redacted or translated. Unsupported signals are not recorded, so that requests of made up
+// signal IDs cannot grow the history.
+func (p *recentPrices) observe(_ context.Context, _ http.ResponseWriter, m proto.Message) error {
+	resp, ok := m.(*query.QueryPricesResponse)
+	if p == nil || !ok {
+		return nil
+	}
+
+	now := time.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, price := range resp.Prices {
+		if price.PriceStatus == query.PriceStatus_PRICE_STATUS_UNSUPPORTED {
+			continue
+		}
+
+		series, ok := p.series[price.SignalId]
+		if !ok {
+			series = &priceSeries{observations: make([]observation, 0, p.size)}
+			p.series[price.SignalId] = series
+		}
+		series.add(observation{time: now, price: proto.Clone(price).(*query.PriceData)}, p.size)
+	}
+
+	return nil
+}
+
+func (s *priceSeries) add(o observation, size int) {
+	if len(s.observations) < size {
+		s.observations = append(s.observations, o)
+	} else {
+		s.observations[s.next] = o
+	}
+	s.next = (s.next + 1) % size
+}
+
+// recent returns the last n observations of the signal, oldest first.
+func (p *recentPrices) recent(signalID string, n int) []observation {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	series, ok := p.series[signalID]
+	if !ok {
+		return nil
+	}
+
+	n = min(n, len(series.observations))
+	recent := make([]observation, 0, n)
+	for i := len(series.observations) - n; i < len(series.observations); i++ {
+		// Before the buffer is full, next is its length and the oldest observation is first.
+		recent = append(recent, series.observations[(series.next+i)%len(series.observations)])
+	}
+
+	return recent
+}
+
+// recentResponse is the body of GET /prices/{signal_id}/recent.
+type recentResponse struct {
+	SignalID     string              `json:"signal_id"`
+	Observations []recentObservation `json:"observations"`
+}
+
+type recentObservation struct {
+	Time        time.Time `json:"time"`
+	Price       string    `json:"price"`
+	PriceStatus string    `json:"price_status"`
+}
+
+// middleware serves GET /prices/{signal_id}/recent, where the signal ID may be an external ID
+// of the ID translation. The observations are redacted with the profile of the request like
+// the prices of the gateway. Other requests are passed to next.
+func (p *recentPrices) middleware(mux *runtime.ServeMux, ids *idTranslation, next http.Handler) http.Handler {
+	if p == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, ok := strings.CutPrefix(r.URL.Path, pricesPath+"/")
+		id, isRecent := strings.CutSuffix(id, recentSuffix)
+		if r.Method != http.MethodGet || !ok || !isRecent {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		n := p.size
+		if param := r.URL.Query().Get(recentParam); param != "" {
+			var err error
+			if n, err = strconv.Atoi(param); err != nil || n < 1 {
+				err := status.Errorf(codes.InvalidArgument, "invalid number of observations %q", param)
+				runtime.HTTPError(r.Context(), mux, &runtime.JSONPb{}, w, r, err)
+				return
+			}
+		}
+		if id == "" || strings.Contains(id, "/") {
+			runtime.HTTPError(r.Context(), mux, &runtime.JSONPb{}, w, r, status.Error(codes.InvalidArgument, "expected a single signal ID"))
+			return
+		}
+
+		signalIDs, _ := ids.translate([]string{id})
+		observations := p.recent(signalIDs[0], n)
+
+		// Redact the observations as the prices of a response, whose paths the profiles use.
+		redacted := &query.QueryPricesResponse{Prices: make([]*query.PriceData, len(observations))}
+		for i, o := range observations {
+			redacted.Prices[i] = proto.Clone(o.price).(*query.PriceData)
+		}
+		_ = redactResponse(r.Context(), w, redacted)
+
+		resp := recentResponse{SignalID: id, Observations: make([]recentObservation, len(observations))}
+		for i, o := range observations {
+			resp.Observations[i] = recentObservation{
+				Time:        o.time,
+				Price:       redacted.Prices[i].Price,
+				PriceStatus: redacted.Prices[i].PriceStatus.String(),
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
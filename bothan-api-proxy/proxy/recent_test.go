@@ -0,0 +1,138 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestNewRecentPrices(t *testing.T) {
+	if recent, err := newRecentPrices(RecentConfig{}); recent != nil || err != nil {
+		t.Errorf("expected no history by default, got %v, %v", recent, err)
+	}
+	if _, err := newRecentPrices(RecentConfig{Observations: -1}); err == nil {
+		t.Error("expected an error for a negative number of observations")
+	}
+}
+
+func TestRecentPricesRing(t *testing.T) {
+	recent, err := newRecentPrices(RecentConfig{Observations: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, price := range []string{"1", "2", "3", "4", "5"} {
+		resp := &query.QueryPricesResponse{Prices: []*query.PriceData{
+			{SignalId: "btc", Price: price, PriceStatus: query.PriceStatus_PRICE_STATUS_AVAILABLE},
+			{SignalId: "made_up", PriceStatus: query.PriceStatus_PRICE_STATUS_UNSUPPORTED},
+		}}
+		if err := recent.observe(context.Background(), nil, resp); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	prices := func(observations []observation) []string {
+		var prices []string
+		for _, o := range observations {
+			prices = append(prices, o.price.Price)
+		}
+		return prices
+	}
+	if got := prices(recent.recent("btc", 10)); len(got) != 3 || got[0] != "3" || got[2] != "5" {
+		t.Errorf("expected the last 3 prices oldest first, got %v", got)
+	}
+	if got := prices(recent.recent("btc", 2)); len(got) != 2 || got[0] != "4" || got[1] != "5" {
+		t.Errorf("expected the last 2 prices, got %v", got)
+	}
+	if got := recent.recent("made_up", 10); len(got) != 0 {
+		t.Errorf("expected unsupported signals not to be recorded, got %v", got)
+	}
+}
+
+func TestRecentPricesGateway(t *testing.T) {
+	recent, err := newRecentPrices(RecentConfig{Observations: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	redaction, err := newRedaction(RedactionConfig{
+		Default:  "public",
+		Profiles: map[string]RedactionProfile{"public": {Hide: []string{"prices.price_status"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	usage, err := NewUsageTracker(UsageConfig{}, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gwmux := runtime.NewServeMux(
+		runtime.WithForwardResponseOption(recent.observe),
+		runtime.WithForwardResponseOption(redactResponse),
+	)
+	if err := query.RegisterQueryHandlerClient(context.Background(), gwmux, stubQueryClient{}); err != nil {
+		t.Fatal(err)
+	}
+	handler := redaction.middleware(usage, recent.middleware(gwmux, nil, gwmux))
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+	for i := 0; i < 2; i++ {
+		if rec := get("/prices/btc,eth"); rec.Code != http.StatusOK {
+			t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body)
+		}
+	}
+
+	rec := get("/prices/btc/recent?n=1")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body)
+	}
+	var body recentResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.SignalID != "btc" || len(body.Observations) != 1 {
+		t.Fatalf("expected 1 observation of btc, got %+v", body)
+	}
+	if o := body.Observations[0]; o.Price != "1" || o.PriceStatus != "PRICE_STATUS_UNSPECIFIED" || o.Time.IsZero() {
+		t.Errorf("expected a redacted observation, got %+v", o)
+	}
+	// The history itself is recorded before the redaction.
+	if o := recent.recent("btc", 1)[0]; o.price.PriceStatus != query.PriceStatus_PRICE_STATUS_AVAILABLE {
+		t.Errorf("expected the unredacted price to be recorded, got %v", o.price)
+	}
+
+	if rec := get("/prices/unknown/recent"); rec.Code != http.StatusOK || rec.Body.String() != "{\"signal_id\":\"unknown\",\"observations\":[]}\n" {
+		t.Errorf("expected no observations of an unknown signal, got %d: %s", rec.Code, rec.Body)
+	}
+	for _, path := range []string{"/prices/btc/recent?n=0", "/prices/btc/recent?n=x", "/prices/btc,eth/recent/recent"} {
+		if rec := get(path); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d: %s", path, rec.Code, rec.Body)
+		}
+	}
+}
+
+func TestRecentPricesProtobuf(t *testing.T) {
+	recent, err := newRecentPrices(RecentConfig{Observations: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := protobufHandler((&Server{recent: recent}).newGatewayMux(), stubQueryClient{}, CostConfig{}, nil, http.NotFoundHandler())
+
+	if resp := postProtobuf(t, handler, &query.QueryPricesRequest{SignalIds: []string{"btc"}}); resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
+	}
+	if got := recent.recent("btc", 10); len(got) != 1 || got[0].price.Price != "1" {
+		t.Errorf("expected the binary response to be recorded, got %v", got)
+	}
+}
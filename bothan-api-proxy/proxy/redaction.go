@@ -0,0 +1,207 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// RedactionConfig defines response profiles, which hide response fields from some consumers,
+// and which requests they apply to. A request uses the profile of its API key, else the
+// profile of the longest matching route, else the default profile. Requests without a
+// profile receive whole responses.
+type RedactionConfig struct {
+	// Default is the profile of requests that match no API key or route.
+	Default string `toml:"default"`
+	// Profiles maps profile names to their definition. A profile that hides nothing, e.g.
+	// "internal", can be used to exempt API keys or routes from the default profile.
+	Profiles map[string]RedactionProfile `toml:"profiles"`
+	// Routes selects profiles by path prefix.
+	Routes []RouteProfile `toml:"routes"`
+	// APIKeys maps API key fingerprints, as shown in the usage report, to profiles.
+	APIKeys map[string]string `toml:"api_keys"`
+}
+
+// RedactionProfile lists the response fields a profile hides.
+type RedactionProfile struct {
+	// Hide holds the dotted proto field names of the fields to hide, relative to a response
+	// message, e.g. "expansions" or "prices.price_status". Hidden fields are cleared, so the
+	// JSON of the gateway still carries them with their zero value.
+	Hide []string `toml:"hide"`
+}
+
+// RouteProfile selects the profile of the requests whose path starts with Path.
+type RouteProfile struct {
+	Path    string `toml:"path"`
+	Profile string `toml:"profile"`
+}
+
+// redaction selects the response profile of requests and applies it to their responses.
+type redaction struct {
+	fallback []fieldPath
+	profiles map[string][]fieldPath
+	routes   []RouteProfile
+	apiKeys  map[string]string
+}
+
+// fieldPath is a dotted field name split into its parts.
+type fieldPath []protoreflect.Name
+
+type redactionKey struct{}
+
+func newRedaction(config RedactionConfig) (*redaction, error) {
+	profiles := make(map[string][]fieldPath, len(config.Profiles))
+	for name, profile := range config.Profiles {
+		paths := make([]fieldPath, 0, len(profile.Hide))
+		for _, hide := range profile.Hide {
+			path := parseFieldPath(hide)
+			if !resolvesInResponse(path) {
+				return nil, fmt.Errorf("invalid redaction profile %q: no response has the field %q", name, hide)
+			}
+			paths = append(paths, path)
+		}
+		profiles[name] = paths
+	}
+
+	lookup := func(name, where string) error {
+		if _, ok := profiles[name]; name != "" && !ok {
+			return fmt.Errorf("unknown redaction profile %q for %s", name, where)
+		}
+		return nil
+	}
+	if err := lookup(config.Default, "the default"); err != nil {
+		return nil, err
+	}
+	for _, route := range config.Routes {
+		if !strings.HasPrefix(route.Path, "/") {
+			return nil, fmt.Errorf("invalid redaction route %q: path must start with /", route.Path)
+		}
+		if err := lookup(route.Profile, "route "+route.Path); err != nil {
+			return nil, err
+		}
+	}
+	for fingerprint, profile := range config.APIKeys {
+		if err := lookup(profile, "API key "+fingerprint); err != nil {
+			return nil, err
+		}
+	}
+
+	routes := append([]RouteProfile(nil), config.Routes...)
+	sort.SliceStable(routes, func(i, j int) bool { return len(routes[i].Path) > len(routes[j].Path) })
+
+	return &redaction{
+		fallback: profiles[config.Default],
+		profiles: profiles,
+		routes:   routes,
+		apiKeys:  config.APIKeys,
+	}, nil
+}
+
+// middleware stores the profile of every request in its context, where the gateway and the
+// protobuf route pick it up when writing the response. API keys are read from the header the
+// usage tracker is configured with.
+func (d *redaction) middleware(usage *UsageTracker, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if paths := d.profile(r, usage.getConfig().APIKeyHeader); len(paths) > 0 {
+			r = r.WithContext(context.WithValue(r.Context(), redactionKey{}, paths))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (d *redaction) profile(r *http.Request, apiKeyHeader string) []fieldPath {
+	if key := apiKey(r, apiKeyHeader); key != "" {
+		if name, ok := d.apiKeys[fingerprint(key)]; ok {
+			return d.profiles[name]
+		}
+	}
+	for _, route := range d.routes {
+		if strings.HasPrefix(r.URL.Path, route.Path) {
+			return d.profiles[route.Profile]
+		}
+	}
+
+	return d.fallback
+}
+
+// redactResponse hides the fields of the profile of the request from the response. It is a
+// forward response option of the gateway, which calls it before marshaling every response.
+func redactResponse(ctx context.Context, _ http.ResponseWriter, m proto.Message) error {
+	paths, _ := ctx.Value(redactionKey{}).([]fieldPath)
+	for _, path := range paths {
+		clearField(m.ProtoReflect(), path)
+	}
+
+	return nil
+}
+
+// clearField clears the field at the given path, in every element of the repeated messages
+// along the path. Paths that do not exist in the message are ignored.
+func clearField(m protoreflect.Message, path fieldPath) {
+	fd := m.Descriptor().Fields().ByName(path[0])
+	if fd == nil {
+		return
+	}
+	if len(path) == 1 {
+		m.Clear(fd)
+		return
+	}
+	if fd.Message() == nil || fd.IsMap() || !m.Has(fd) {
+		return
+	}
+
+	if fd.IsList() {
+		list := m.Get(fd).List()
+		for i := 0; i < list.Len(); i++ {
+			clearField(list.Get(i).Message(), path[1:])
+		}
+		return
+	}
+	clearField(m.Mutable(fd).Message(), path[1:])
+}
+
+func parseFieldPath(s string) fieldPath {
+	parts := strings.Split(s, ".")
+	path := make(fieldPath, len(parts))
+	for i, part := range parts {
+		path[i] = protoreflect.Name(part)
+	}
+	return path
+}
+
+// resolvesInResponse reports whether the path names a field of the response of any method of
+// the query service.
+func resolvesInResponse(path fieldPath) bool {
+	services := query.File_query_query_proto.Services()
+	for i := 0; i < services.Len(); i++ {
+		methods := services.Get(i).Methods()
+		for j := 0; j < methods.Len(); j++ {
+			if resolves(methods.Get(j).Output(), path) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func resolves(md protoreflect.MessageDescriptor, path fieldPath) bool {
+	fd := md.Fields().ByName(path[0])
+	switch {
+	case fd == nil:
+		return false
+	case len(path) == 1:
+		return true
+	case fd.Message() == nil || fd.IsMap():
+		return false
+	default:
+		return resolves(fd.Message(), path[1:])
+	}
+}
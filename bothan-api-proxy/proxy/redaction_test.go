@@ -0,0 +1,112 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestRedactionConfigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config RedactionConfig
+		err    string
+	}{
+		{"unknown field", RedactionConfig{Profiles: map[string]RedactionProfile{"public": {Hide: []string{"prices.uuid"}}}}, `no response has the field "prices.uuid"`},
+		{"unknown default", RedactionConfig{Default: "public"}, `unknown redaction profile "public"`},
+		{"unknown route profile", RedactionConfig{Routes: []RouteProfile{{Path: "/prices", Profile: "public"}}}, `unknown redaction profile "public"`},
+		{"relative route", RedactionConfig{Routes: []RouteProfile{{Path: "prices"}}}, "path must start with /"},
+	}
+	for _, tt := range tests {
+		if _, err := newRedaction(tt.config); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.err, err)
+		}
+	}
+}
+
+func TestRedactionProfiles(t *testing.T) {
+	redaction, err := newRedaction(RedactionConfig{
+		Default: "public",
+		Profiles: map[string]RedactionProfile{
+			"public":   {Hide: []string{"prices.price_status", "expansions"}},
+			"signals":  {Hide: []string{"signals.price_status"}},
+			"internal": {},
+		},
+		Routes:  []RouteProfile{{Path: "/signals", Profile: "signals"}, {Path: "/prices/internal", Profile: "internal"}},
+		APIKeys: map[string]string{fingerprint("secret"): "internal"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	usage, err := NewUsageTracker(UsageConfig{}, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gwmux := runtime.NewServeMux(runtime.WithForwardResponseOption(redactResponse))
+	if err := query.RegisterQueryHandlerClient(context.Background(), gwmux, stubQueryClient{}); err != nil {
+		t.Fatal(err)
+	}
+	handler := redaction.middleware(usage, gwmux)
+
+	get := func(path, key string) map[string]any {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: unexpected status %d: %s", path, rec.Code, rec.Body)
+		}
+
+		var body struct {
+			Prices []map[string]any `json:"prices"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatal(err)
+		}
+		return body.Prices[0]
+	}
+
+	if price := get("/prices/btc", ""); price["priceStatus"] != "PRICE_STATUS_UNSPECIFIED" || price["price"] != "1" {
+		t.Errorf("expected the default profile to hide the status, got %v", price)
+	}
+	if price := get("/prices/btc", "secret"); price["priceStatus"] != "PRICE_STATUS_AVAILABLE" {
+		t.Errorf("expected the API key profile to show the status, got %v", price)
+	}
+	if price := get("/prices/internal", ""); price["priceStatus"] != "PRICE_STATUS_AVAILABLE" {
+		t.Errorf("expected the route profile to show the status, got %v", price)
+	}
+}
+
+func TestClearField(t *testing.T) {
+	resp := &query.QueryPricesResponse{
+		Prices: []*query.PriceData{
+			{SignalId: "a", Price: "1", PriceStatus: query.PriceStatus_PRICE_STATUS_AVAILABLE},
+			{SignalId: "b", Price: "2", PriceStatus: query.PriceStatus_PRICE_STATUS_AVAILABLE},
+		},
+		Expansions: []*query.GroupExpansion{{Group: "@g", SignalIds: []string{"a", "b"}}},
+	}
+
+	clearField(resp.ProtoReflect(), parseFieldPath("prices.price"))
+	clearField(resp.ProtoReflect(), parseFieldPath("expansions"))
+	clearField(resp.ProtoReflect(), parseFieldPath("signals.price_status"))
+
+	for _, price := range resp.Prices {
+		if price.Price != "" || price.SignalId == "" || price.PriceStatus != query.PriceStatus_PRICE_STATUS_AVAILABLE {
+			t.Errorf("expected only the price to be cleared, got %v", price)
+		}
+	}
+	if len(resp.Expansions) != 0 {
+		t.Errorf("expected the expansions to be cleared, got %v", resp.Expansions)
+	}
+}
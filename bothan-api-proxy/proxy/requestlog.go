@@ -0,0 +1,33 @@
+package proxy
+
+import (
+	"google.golang.org/grpc"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/logging"
+)
+
+// RequestLogConfig enables logging of whole upstream requests and responses for a sample of
+// the calls and for every failed call.
+type RequestLogConfig struct {
+	Enabled bool `toml:"enabled"`
+	// SampleRate is the probability of a successful call being logged. Failed calls are
+	// always logged, so 0 logs failed calls only.
+	SampleRate float64 `toml:"sample_rate"`
+	// MaxSize is the maximum number of bytes logged per request or response.
+	MaxSize int `toml:"max_size"`
+	// RedactedHeaders are headers whose values are not logged, in addition to Authorization,
+	// X-API-Key and Cookie.
+	RedactedHeaders []string `toml:"redacted_headers"`
+}
+
+func (c RequestLogConfig) dialOptions() []grpc.DialOption {
+	if !c.Enabled {
+		return nil
+	}
+
+	return []grpc.DialOption{grpc.WithChainUnaryInterceptor(logging.UnaryClientInterceptor(logging.Config{
+		SampleRate:   c.SampleRate,
+		MaxSize:      c.MaxSize,
+		RedactedKeys: c.RedactedHeaders,
+	}))}
+}
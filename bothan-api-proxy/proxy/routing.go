@@ -0,0 +1,175 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/grpclog"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// defaultSignalRefreshInterval is how often the signal IDs of the upstream are fetched for
+// case insensitive matching if the configuration does not say.
+const defaultSignalRefreshInterval = 5 * time.Minute
+
+// RoutingConfig defines how leniently the paths of hand-typed URLs are matched.
+type RoutingConfig struct {
+	// TrailingSlash routes paths with trailing slashes like the paths without them, e.g.
+	// /prices/crypto_price.btcusd/ like /prices/crypto_price.btcusd. It only applies to the
+	// gateway routes, not to the endpoints of the proxy such as /admin/.
+	TrailingSlash bool `toml:"trailing_slash"`
+	// CaseInsensitiveIDs matches the signal IDs of price requests regardless of case, by
+	// replacing them with the signal IDs of the registry of the upstream, e.g.
+	// CRYPTO_PRICE.BTCUSD with crypto_price.btcusd.
+	CaseInsensitiveIDs bool `toml:"case_insensitive_ids"`
+	// RefreshInterval is how often the signal IDs of the upstream are fetched for
+	// CaseInsensitiveIDs, e.g. "1m". Defaults to 5m.
+	RefreshInterval string `toml:"refresh_interval"`
+}
+
+// trailingSlash removes the trailing slashes of request paths, other than of the root path.
+func trailingSlash(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.URL.Path) > 1 && strings.HasSuffix(r.URL.Path, "/") {
+			u := *r.URL
+			u.Path = strings.TrimRight(u.Path, "/")
+			if u.Path == "" {
+				u.Path = "/"
+			}
+			u.RawPath = ""
+			r2 := *r
+			r2.URL = &u
+			r = &r2
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// signalCase maps the lower case signal IDs of the registry of the upstream to the IDs
+// themselves. A nil signalCase leaves signal IDs unchanged.
+type signalCase struct {
+	interval time.Duration
+
+	mu        sync.RWMutex
+	canonical map[string]string
+}
+
+func newSignalCase(config RoutingConfig) (*signalCase, error) {
+	if !config.CaseInsensitiveIDs {
+		return nil, nil
+	}
+
+	interval, err := parseTimeout(config.RefreshInterval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid routing refresh interval: %w", err)
+	}
+	if interval == 0 {
+		interval = defaultSignalRefreshInterval
+	}
+
+	return &signalCase{interval: interval, canonical: make(map[string]string)}, nil
+}
+
+// run fetches the signal IDs of the upstream every interval until the context is cancelled.
+func (c *signalCase) run(ctx context.Context, client query.QueryClient) {
+	if c == nil {
+		return
+	}
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		if err := c.refresh(ctx, client); err != nil && ctx.Err() == nil {
+			grpclog.Errorf("error fetching the signal IDs for case insensitive routing: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh replaces the signal IDs with the ones of the upstream, page by page. IDs that only
+// differ in case are left out, as there is no telling which of them is meant.
+func (c *signalCase) refresh(ctx context.Context, client query.QueryClient) error {
+	canonical := make(map[string]string)
+	ambiguous := make(map[string]struct{})
+	pageToken := ""
+	for {
+		resp, err := client.Signals(ctx, &query.QuerySignalsRequest{PageToken: pageToken})
+		if err != nil {
+			return err
+		}
+		for _, signal := range resp.Signals {
+			key := strings.ToLower(signal.SignalId)
+			if _, ok := canonical[key]; ok {
+				ambiguous[key] = struct{}{}
+			}
+			canonical[key] = signal.SignalId
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+	for key := range ambiguous {
+		delete(canonical, key)
+	}
+
+	c.mu.Lock()
+	c.canonical = canonical
+	c.mu.Unlock()
+	return nil
+}
+
+// canonicalize returns the signal ID of the upstream that equals the given ID regardless of
+// case, or the ID itself if there is none.
+func (c *signalCase) canonicalize(signalID string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if canonical, ok := c.canonical[strings.ToLower(signalID)]; ok {
+		return canonical
+	}
+	return signalID
+}
+
+// middleware replaces the signal IDs of GET /prices/{signal_ids} requests with their
+// canonical IDs, before they are normalized and queried.
+func (c *signalCase) middleware(next http.Handler) http.Handler {
+	if c == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids, ok := strings.CutPrefix(r.URL.Path, pricesPath+"/")
+		if r.Method != http.MethodGet || !ok || strings.Contains(ids, "/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		signalIDs := strings.Split(ids, ",")
+		for i, signalID := range signalIDs {
+			signalIDs[i] = c.canonicalize(signalID)
+		}
+
+		if canonical := strings.Join(signalIDs, ","); canonical != ids {
+			u := *r.URL
+			u.Path = pricesPath + "/" + canonical
+			u.RawPath = ""
+			r2 := *r
+			r2.URL = &u
+			r = &r2
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
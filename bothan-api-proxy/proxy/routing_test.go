@@ -0,0 +1,90 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestTrailingSlash(t *testing.T) {
+	var path string
+	handler := trailingSlash(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+	}))
+
+	for in, want := range map[string]string{"/prices/btc/": "/prices/btc", "/prices/btc//": "/prices/btc", "/": "/", "/readyz": "/readyz"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, in, nil))
+		if path != want {
+			t.Errorf("expected %s to be routed as %s, got %s", in, want, path)
+		}
+	}
+}
+
+func TestTrailingSlashAdminUI(t *testing.T) {
+	server, err := New(Config{
+		Grpc:    GrpcConfig{Addr: "localhost:50051"},
+		Usage:   UsageConfig{AdminToken: "secret"},
+		Routing: RoutingConfig{TrailingSlash: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var path string
+	gateway := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+	})
+	mux, err := server.newMux(server.newGatewayMux(), gateway, http.NotFoundHandler())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/", nil)
+	req.SetBasicAuth("admin", "secret")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected the admin UI, got status %d to %q", rec.Code, rec.Header().Get("Location"))
+	}
+
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/prices/btc/", nil))
+	if path != "/prices/btc" {
+		t.Errorf("expected the gateway to get /prices/btc, got %s", path)
+	}
+}
+
+func TestSignalCase(t *testing.T) {
+	c, err := newSignalCase(RoutingConfig{CaseInsensitiveIDs: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &signalsQueryClient{pages: [][]*query.SignalInfo{
+		{signalInfo("crypto_price.btcusd", query.PriceStatus_PRICE_STATUS_AVAILABLE), signalInfo("a", query.PriceStatus_PRICE_STATUS_AVAILABLE)},
+		{signalInfo("CS:ETH-USD", query.PriceStatus_PRICE_STATUS_AVAILABLE), signalInfo("A", query.PriceStatus_PRICE_STATUS_AVAILABLE)},
+	}}
+	if err := c.refresh(context.Background(), client); err != nil {
+		t.Fatal(err)
+	}
+
+	var path string
+	handler := c.middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/prices/CRYPTO_PRICE.BTCUSD,cs:eth-usd,a,unknown,@Majors", nil))
+	if want := "/prices/crypto_price.btcusd,CS:ETH-USD,a,unknown,@Majors"; path != want {
+		t.Errorf("expected %s, got %s", want, path)
+	}
+}
+
+func TestSignalCaseDisabled(t *testing.T) {
+	c, err := newSignalCase(RoutingConfig{})
+	if err != nil || c != nil {
+		t.Fatalf("expected no signal case, got %v, %v", c, err)
+	}
+	if _, err := newSignalCase(RoutingConfig{CaseInsensitiveIDs: true, RefreshInterval: "soon"}); err == nil {
+		t.Error("expected an invalid refresh interval to be rejected")
+	}
+}
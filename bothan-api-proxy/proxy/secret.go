@@ -0,0 +1,121 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	secretEnvPrefix  = "env:"
+	secretFilePrefix = "file:"
+
+	// secretPollInterval is how often files referenced by the config are checked for changes.
+	secretPollInterval = 5 * time.Second
+)
+
+// Secret is a configuration value that is either given literally, read from an environment
+// variable ("env:NAME") or read from a file ("file:/path/to/secret"). Secrets read from files
+// are reloaded when the file changes.
+type Secret string
+
+// Resolve returns the value of the secret. Surrounding whitespace is removed from values read
+// from files.
+func (s Secret) Resolve() (string, error) {
+	value := string(s)
+	switch {
+	case strings.HasPrefix(value, secretEnvPrefix):
+		name := strings.TrimPrefix(value, secretEnvPrefix)
+		env, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return env, nil
+	case strings.HasPrefix(value, secretFilePrefix):
+		b, err := os.ReadFile(s.path())
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(b)), nil
+	default:
+		return value, nil
+	}
+}
+
+// path returns the file the secret is read from, or an empty string.
+func (s Secret) path() string {
+	value := string(s)
+	if !strings.HasPrefix(value, secretFilePrefix) {
+		return ""
+	}
+
+	return strings.TrimPrefix(value, secretFilePrefix)
+}
+
+// fileWatcher detects changes of files by polling their modification time and size, which
+// also catches the symlink swaps used to update mounted Kubernetes secrets.
+type fileWatcher struct {
+	files map[string]fileState
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+func newFileWatcher(paths ...string) *fileWatcher {
+	w := &fileWatcher{files: make(map[string]fileState)}
+	for _, path := range paths {
+		if path != "" {
+			w.files[path] = statFile(path)
+		}
+	}
+
+	return w
+}
+
+// changed reports whether any of the watched files changed since the last call.
+func (w *fileWatcher) changed() bool {
+	changed := false
+	for path, previous := range w.files {
+		if current := statFile(path); current != previous {
+			w.files[path] = current
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// watch calls onChange whenever one of the watched files changes, until the context is
+// cancelled.
+func (w *fileWatcher) watch(ctx context.Context, interval time.Duration, onChange func()) {
+	if len(w.files) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if w.changed() {
+				onChange()
+			}
+		}
+	}
+}
+
+func statFile(path string) fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}
+	}
+
+	return fileState{modTime: info.ModTime(), size: info.Size()}
+}
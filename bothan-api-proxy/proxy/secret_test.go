@@ -0,0 +1,128 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestSecretResolve(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("BOTHAN_TEST_TOKEN", "from-env")
+
+	tests := []struct {
+		secret Secret
+		value  string
+	}{
+		{"literal", "literal"},
+		{"env:BOTHAN_TEST_TOKEN", "from-env"},
+		{Secret("file:" + path), "from-file"},
+	}
+	for _, tt := range tests {
+		value, err := tt.secret.Resolve()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != tt.value {
+			t.Errorf("%s: expected %q, got %q", tt.secret, tt.value, value)
+		}
+	}
+
+	if _, err := Secret("env:BOTHAN_TEST_UNSET").Resolve(); err == nil {
+		t.Error("expected error for unset environment variable")
+	}
+}
+
+func TestAdminTokenReloadsFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("old"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tracker, err := NewUsageTracker(UsageConfig{AdminToken: Secret("file:" + path)}, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	watcher := newFileWatcher(path)
+
+	// Make sure the modification time changes even on coarse filesystem clocks.
+	if err := os.WriteFile(path, []byte("rotated"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	if !watcher.changed() {
+		t.Fatal("expected the watcher to detect the change")
+	}
+	if err := tracker.reloadSecrets(); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/usage", nil)
+	req.Header.Set("Authorization", "Bearer rotated")
+	rec := httptest.NewRecorder()
+	tracker.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if watcher.changed() {
+		t.Error("expected no further change")
+	}
+}
+
+func TestAdminTokenWatcherFollowsReload(t *testing.T) {
+	dir := t.TempDir()
+	oldPath, newPath := filepath.Join(dir, "old"), filepath.Join(dir, "new")
+	for path, token := range map[string]string{oldPath: "old", newPath: "new"} {
+		if err := os.WriteFile(path, []byte(token), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tracker, err := NewUsageTracker(UsageConfig{AdminToken: Secret("file:" + oldPath)}, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go tracker.watchAdminToken(ctx, time.Millisecond)
+
+	if err := tracker.SetConfig(UsageConfig{AdminToken: Secret("file:" + newPath)}); err != nil {
+		t.Fatal(err)
+	}
+	// Give the watcher time to switch to the new file before it is rotated.
+	time.Sleep(50 * time.Millisecond)
+
+	if err := os.WriteFile(newPath, []byte("rotated"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(newPath, future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		req := httptest.NewRequest(http.MethodGet, "/admin/usage", nil)
+		req.Header.Set("Authorization", "Bearer rotated")
+		if tracker.authorized(req) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the token rotated in the new file to be reloaded")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
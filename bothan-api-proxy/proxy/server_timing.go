@@ -0,0 +1,34 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// serverTimingHeader is the standard response header that browser developer tools and many
+// HTTP clients display as a breakdown of the server time.
+const serverTimingHeader = "Server-Timing"
+
+// setServerTiming reports the time the Bothan server spent on a response in the
+// Server-Timing header, e.g. "queue;dur=1.500, processing;dur=0.250" in milliseconds. It is a
+// forward response option of the gateway and is applied before redaction, so the header is
+// set even if a profile hides the server_timing field.
+func setServerTiming(_ context.Context, w http.ResponseWriter, m proto.Message) error {
+	timed, ok := m.(interface{ GetServerTiming() *query.ServerTiming })
+	if !ok || timed.GetServerTiming() == nil {
+		return nil
+	}
+
+	timing := timed.GetServerTiming()
+	w.Header().Set(serverTimingHeader, fmt.Sprintf(
+		"queue;dur=%.3f, processing;dur=%.3f",
+		float64(timing.QueueTimeUs)/1000, float64(timing.ProcessingTimeUs)/1000,
+	))
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package proxy
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestSetServerTiming(t *testing.T) {
+	rec := httptest.NewRecorder()
+	resp := &query.QueryPricesResponse{ServerTiming: &query.ServerTiming{QueueTimeUs: 1500, ProcessingTimeUs: 250}}
+	if err := setServerTiming(context.Background(), rec, resp); err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.Header().Get(serverTimingHeader); got != "queue;dur=1.500, processing;dur=0.250" {
+		t.Errorf("unexpected header %q", got)
+	}
+
+	rec = httptest.NewRecorder()
+	_ = setServerTiming(context.Background(), rec, &query.QuerySignalsResponse{})
+	_ = setServerTiming(context.Background(), rec, &query.QueryPricesResponse{})
+	if got := rec.Header().Get(serverTimingHeader); got != "" {
+		t.Errorf("expected no header without a timing, got %q", got)
+	}
+}
@@ -0,0 +1,44 @@
+package proxy
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	clientv2 "github.com/bandprotocol/bothan/bothan-api/client/go-client/v2"
+)
+
+// normalizeSignalIDs normalizes the comma separated signal IDs of the GET
+// /prices/{signal_ids} requests of the gateway the same way the clients do: duplicates are
+// removed and requests with an empty ID, e.g. from a trailing comma, are rejected with a
+// gateway error. The request reaches next with the normalized list in its path, so that the
+// cost and the upstream query only count every signal once.
+func normalizeSignalIDs(mux *runtime.ServeMux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids, ok := strings.CutPrefix(r.URL.Path, pricesPath+"/")
+		if r.Method != http.MethodGet || !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		signalIDs, err := clientv2.NormalizeSignalIDs(strings.Split(ids, ","))
+		if err != nil {
+			runtime.HTTPError(r.Context(), mux, &runtime.JSONPb{}, w, r, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		if normalized := strings.Join(signalIDs, ","); normalized != ids {
+			u := *r.URL
+			u.Path = pricesPath + "/" + normalized
+			u.RawPath = ""
+			r2 := *r
+			r2.URL = &u
+			r = &r2
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
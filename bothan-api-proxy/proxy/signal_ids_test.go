@@ -0,0 +1,136 @@
+package proxy
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// signalIDList is a list of signal IDs drawn from a small alphabet, so that duplicates and
+// empty IDs are frequent.
+type signalIDList []string
+
+func (signalIDList) Generate(rand *rand.Rand, size int) reflect.Value {
+	alphabet := []string{"", "btc", "eth", "CS:BTC-USD", "@majors", "a b"}
+	list := make(signalIDList, rand.Intn(size)+1)
+	for i := range list {
+		list[i] = alphabet[rand.Intn(len(alphabet))]
+	}
+	return reflect.ValueOf(list)
+}
+
+// normalized returns the expected normalization of the list and whether it is valid.
+func (l signalIDList) normalized() ([]string, bool) {
+	var ids []string
+	for _, id := range l {
+		if id == "" {
+			return nil, false
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, true
+}
+
+func (l signalIDList) path() string {
+	escaped := make([]string, len(l))
+	for i, id := range l {
+		escaped[i] = url.PathEscape(id)
+	}
+	return pricesPath + "/" + strings.Join(escaped, ",")
+}
+
+func TestNormalizeSignalIDsRoute(t *testing.T) {
+	property := func(ids signalIDList) bool {
+		var forwarded string
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			forwarded = r.URL.Path
+		})
+		rec := httptest.NewRecorder()
+		normalizeSignalIDs(runtime.NewServeMux(), next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ids.path(), nil))
+
+		want, ok := ids.normalized()
+		if !ok {
+			return rec.Code == http.StatusBadRequest && forwarded == ""
+		}
+		return rec.Code == http.StatusOK && forwarded == pricesPath+"/"+strings.Join(want, ",")
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNormalizeSignalIDsCorners(t *testing.T) {
+	tests := []struct {
+		path      string
+		status    int
+		forwarded string
+	}{
+		{"/prices/btc,", http.StatusBadRequest, ""},
+		{"/prices/,btc", http.StatusBadRequest, ""},
+		{"/prices/btc,,eth", http.StatusBadRequest, ""},
+		{"/prices/", http.StatusBadRequest, ""},
+		{"/prices/btc,eth,btc", http.StatusOK, "/prices/btc,eth"},
+		{"/prices/btc", http.StatusOK, "/prices/btc"},
+		{"/signals", http.StatusOK, "/signals"},
+	}
+	for _, tt := range tests {
+		var forwarded string
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			forwarded = r.URL.Path
+		})
+		rec := httptest.NewRecorder()
+		normalizeSignalIDs(runtime.NewServeMux(), next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if rec.Code != tt.status || forwarded != tt.forwarded {
+			t.Errorf("%s: expected %d forwarding %q, got %d forwarding %q", tt.path, tt.status, tt.forwarded, rec.Code, forwarded)
+		}
+	}
+}
+
+func TestNormalizeSignalIDsProtobuf(t *testing.T) {
+	handler := protobufHandler((&Server{}).newGatewayMux(), stubQueryClient{}, CostConfig{}, nil, http.NotFoundHandler())
+
+	property := func(ids signalIDList) bool {
+		resp := postProtobuf(t, handler, &query.QueryPricesRequest{SignalIds: ids})
+
+		want, ok := ids.normalized()
+		if !ok {
+			return resp.StatusCode == http.StatusBadRequest
+		}
+
+		var prices query.QueryPricesResponse
+		if resp.StatusCode != http.StatusOK || readProtobuf(resp, &prices) != nil {
+			return false
+		}
+		var got []string
+		for _, price := range prices.Prices {
+			got = append(got, price.SignalId)
+		}
+		return slices.Equal(got, want)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func readProtobuf(resp *http.Response, m proto.Message) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(body, m)
+}
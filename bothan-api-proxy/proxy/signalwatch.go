@@ -0,0 +1,165 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// SignalWatchConfig defines when a signal observed through the proxy is considered stuck.
+type SignalWatchConfig struct {
+	// Heartbeat is the longest time the price of a signal may stay unchanged, e.g. "10m".
+	// Every heartbeat without a change is counted as missed. Empty disables the check, the
+	// update metrics are exported regardless.
+	Heartbeat string `toml:"heartbeat"`
+}
+
+// observedSignal is the last price of a signal seen in an upstream response.
+type observedSignal struct {
+	price    string
+	updated  time.Time
+	lastMiss time.Time
+}
+
+// signalWatch tracks the prices in the responses of the upstream to export when each signal
+// last changed, so that alerts can fire when a feed silently stops updating.
+type signalWatch struct {
+	heartbeat  time.Duration
+	lastUpdate *prometheus.GaugeVec
+	change     *prometheus.GaugeVec
+	missed     *prometheus.CounterVec
+
+	mu      sync.Mutex
+	signals map[string]*observedSignal
+}
+
+func newSignalWatch(config SignalWatchConfig, registerer prometheus.Registerer) (*signalWatch, error) {
+	heartbeat, err := parseTimeout(config.Heartbeat)
+	if err != nil {
+		return nil, fmt.Errorf("invalid heartbeat: %w", err)
+	}
+
+	lastUpdate := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "bothan_signal_last_update_seconds",
+		Help: "Unix time at which the price of the signal last changed, as observed by the proxy.",
+	}, []string{"signal_id"})
+	change := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "bothan_signal_price_change_ratio",
+		Help: "Relative change of the price of the signal at its last update.",
+	}, []string{"signal_id"})
+	missed := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "bothan_signal_heartbeat_missed_total",
+		Help: "Number of heartbeats in which the price of the signal did not change.",
+	}, []string{"signal_id"})
+	for _, collector := range []prometheus.Collector{lastUpdate, change, missed} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return &signalWatch{
+		heartbeat:  heartbeat,
+		lastUpdate: lastUpdate,
+		change:     change,
+		missed:     missed,
+		signals:    make(map[string]*observedSignal),
+	}, nil
+}
+
+// dialOptions returns the interceptor that observes the prices returned by the upstream.
+func (w *signalWatch) dialOptions() []grpc.DialOption {
+	return []grpc.DialOption{grpc.WithChainUnaryInterceptor(
+		func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if resp, ok := reply.(*query.QueryPricesResponse); ok && err == nil {
+				w.observe(resp.Prices, time.Now())
+			}
+			return err
+		},
+	)}
+}
+
+// observe records the available prices of a response.
+func (w *signalWatch) observe(prices []*query.PriceData, now time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, price := range prices {
+		if price.PriceStatus != query.PriceStatus_PRICE_STATUS_AVAILABLE {
+			continue
+		}
+
+		signal, ok := w.signals[price.SignalId]
+		switch {
+		case !ok:
+			w.signals[price.SignalId] = &observedSignal{price: price.Price, updated: now}
+		case signal.price != price.Price:
+			w.change.WithLabelValues(price.SignalId).Set(changeRatio(signal.price, price.Price))
+			signal.price = price.Price
+			signal.updated = now
+		default:
+			continue
+		}
+		w.lastUpdate.WithLabelValues(price.SignalId).Set(float64(now.Unix()))
+	}
+}
+
+// check counts a missed heartbeat for every signal whose price has not changed for a
+// heartbeat since its last update or its last missed heartbeat.
+func (w *signalWatch) check(now time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for id, signal := range w.signals {
+		since := signal.updated
+		if signal.lastMiss.After(since) {
+			since = signal.lastMiss
+		}
+		if now.Sub(since) >= w.heartbeat {
+			w.missed.WithLabelValues(id).Inc()
+			signal.lastMiss = now
+		}
+	}
+}
+
+// run checks the heartbeats until the context is cancelled.
+func (w *signalWatch) run(ctx context.Context) {
+	if w.heartbeat == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(w.heartbeat / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			w.check(now)
+		}
+	}
+}
+
+// changeRatio returns the absolute change from old to new relative to old, or zero if either
+// price is not a number or old is zero.
+func changeRatio(old, new string) float64 {
+	o, err := strconv.ParseFloat(old, 64)
+	if err != nil || o == 0 {
+		return 0
+	}
+	n, err := strconv.ParseFloat(new, 64)
+	if err != nil {
+		return 0
+	}
+
+	return math.Abs(n-o) / math.Abs(o)
+}
@@ -0,0 +1,52 @@
+package proxy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestSignalWatch(t *testing.T) {
+	w, err := newSignalWatch(SignalWatchConfig{Heartbeat: "1m"}, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Unix(1700000000, 0)
+	price := func(p string) []*query.PriceData {
+		return []*query.PriceData{
+			{SignalId: "btc", Price: p, PriceStatus: query.PriceStatus_PRICE_STATUS_AVAILABLE},
+			{SignalId: "eth", PriceStatus: query.PriceStatus_PRICE_STATUS_UNAVAILABLE},
+		}
+	}
+
+	w.observe(price("100"), start)
+	w.observe(price("100"), start.Add(30*time.Second))
+	if got := testutil.ToFloat64(w.lastUpdate.WithLabelValues("btc")); got != float64(start.Unix()) {
+		t.Errorf("expected the first observation as last update, got %v", got)
+	}
+
+	w.observe(price("110"), start.Add(40*time.Second))
+	if got := testutil.ToFloat64(w.lastUpdate.WithLabelValues("btc")); got != float64(start.Add(40*time.Second).Unix()) {
+		t.Errorf("expected the price change as last update, got %v", got)
+	}
+	if got := testutil.ToFloat64(w.change.WithLabelValues("btc")); got < 0.0999 || got > 0.1001 {
+		t.Errorf("expected a change ratio of 0.1, got %v", got)
+	}
+
+	// A heartbeat is missed once per heartbeat without a change.
+	w.check(start.Add(90 * time.Second))
+	w.check(start.Add(110 * time.Second))
+	w.check(start.Add(150 * time.Second))
+	w.check(start.Add(170 * time.Second))
+	if got := testutil.ToFloat64(w.missed.WithLabelValues("btc")); got != 2 {
+		t.Errorf("expected 2 missed heartbeats, got %v", got)
+	}
+	if testutil.CollectAndCount(w.missed) != 1 {
+		t.Error("expected no heartbeats of unavailable signals")
+	}
+}
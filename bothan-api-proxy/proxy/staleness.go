@@ -0,0 +1,141 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+const (
+	// maxAgeParam is the query parameter of the price requests that overrides the maximum
+	// age of the staleness configuration for the request, in seconds. Zero disables the check.
+	maxAgeParam = "max_age"
+	// staleReason is the reason of the error info of the responses rejected as stale.
+	staleReason = "PRICES_STALE"
+	// errorDomain is the domain of the error infos of the proxy.
+	errorDomain = "bothan-api-proxy"
+	// maxAgeLimit is the largest maximum age in seconds that fits in a time.Duration.
+	maxAgeLimit = uint64(math.MaxInt64 / int64(time.Second))
+)
+
+// StalenessConfig makes the proxy reject price responses without a fresh price with 503
+// Service Unavailable, so that load balancers eject a region whose Bothan server stopped
+// receiving data.
+type StalenessConfig struct {
+	// MaxAge is the age in seconds above which a price is stale. A response is rejected if
+	// none of its prices is available and at most this old. Zero disables the check for the
+	// requests that do not set max_age. It must be at most about 292 years.
+	MaxAge uint64 `toml:"max_age"`
+}
+
+// validate rejects maximum ages that overflow a time.Duration, which would make every price
+// stale.
+func (c StalenessConfig) validate() error {
+	if c.MaxAge > maxAgeLimit {
+		return fmt.Errorf("staleness max_age %d exceeds %d seconds", c.MaxAge, maxAgeLimit)
+	}
+	return nil
+}
+
+type maxAgeKey struct{}
+
+// middleware stores the maximum age of every price request in its context, where the gateway
+// and the protobuf route pick it up when writing the response. Requests with an invalid
+// max_age, or one above maxAgeLimit, are rejected with a gateway error.
+func (c StalenessConfig) middleware(mux *runtime.ServeMux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		values := r.URL.Query()
+		maxAge := c.MaxAge
+		if values.Has(maxAgeParam) {
+			var err error
+			if maxAge, err = strconv.ParseUint(values.Get(maxAgeParam), 10, 64); err != nil || maxAge > maxAgeLimit {
+				err := status.Errorf(codes.InvalidArgument, "invalid %s %q", maxAgeParam, values.Get(maxAgeParam))
+				runtime.HTTPError(r.Context(), mux, &runtime.JSONPb{}, w, r, err)
+				return
+			}
+
+			// The parameter is not a field of the request, so it is not passed to the gateway.
+			values.Del(maxAgeParam)
+			u := *r.URL
+			u.RawQuery = values.Encode()
+			r2 := *r
+			r2.URL = &u
+			r = &r2
+		}
+
+		if maxAge > 0 {
+			r = r.WithContext(context.WithValue(r.Context(), maxAgeKey{}, time.Duration(maxAge)*time.Second))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// checkStaleness rejects a price response with codes.Unavailable, which the gateway maps to
+// 503, if the request has a maximum age and none of the prices is available and at most that
+// old. Unsupported prices are left out, as they never become fresh, and so are prices without
+// a timestamp, which servers that predate it send. The error carries an ErrorInfo with the
+// reason PRICES_STALE and the age of the newest price. It is a forward response option of the
+// gateway, which calls it before marshaling every response.
+func checkStaleness(ctx context.Context, _ http.ResponseWriter, m proto.Message) error {
+	maxAge, ok := ctx.Value(maxAgeKey{}).(time.Duration)
+	resp, isPrices := m.(*query.QueryPricesResponse)
+	if !ok || !isPrices {
+		return nil
+	}
+
+	now := time.Now()
+	checked := 0
+	var newest time.Time
+	for _, price := range resp.Prices {
+		switch {
+		case price.PriceStatus == query.PriceStatus_PRICE_STATUS_UNSUPPORTED:
+			continue
+		case price.PriceStatus == query.PriceStatus_PRICE_STATUS_AVAILABLE && price.Timestamp == 0:
+			return nil
+		case price.PriceStatus == query.PriceStatus_PRICE_STATUS_AVAILABLE:
+			updated := time.Unix(int64(price.Timestamp), 0)
+			if now.Sub(updated) <= maxAge {
+				return nil
+			}
+			if updated.After(newest) {
+				newest = updated
+			}
+		}
+		checked++
+	}
+	if checked == 0 {
+		return nil
+	}
+
+	metadata := map[string]string{
+		"max_age_seconds": strconv.FormatInt(int64(maxAge/time.Second), 10),
+		"stale_prices":    strconv.Itoa(checked),
+	}
+	msg := fmt.Sprintf("no price is available and at most %s old", maxAge)
+	if !newest.IsZero() {
+		age := now.Sub(newest).Truncate(time.Second)
+		metadata["newest_age_seconds"] = strconv.FormatInt(int64(age/time.Second), 10)
+		msg = fmt.Sprintf("%s, the newest is %s old", msg, age)
+	}
+
+	s, err := status.New(codes.Unavailable, msg).WithDetails(&errdetails.ErrorInfo{
+		Reason:   staleReason,
+		Domain:   errorDomain,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return status.Error(codes.Unavailable, msg)
+	}
+	return s.Err()
+}
@@ -0,0 +1,140 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// agedQueryClient returns a price that is a second old for the signal "fresh", ten minutes old
+// for "stale", the statuses of "unavailable" and "unsupported", and a price without a
+// timestamp for any other signal.
+type agedQueryClient struct {
+	query.QueryClient
+}
+
+func (agedQueryClient) Prices(_ context.Context, in *query.QueryPricesRequest, _ ...grpc.CallOption) (*query.QueryPricesResponse, error) {
+	now := uint64(time.Now().Unix())
+	var prices []*query.PriceData
+	for _, id := range in.SignalIds {
+		price := &query.PriceData{SignalId: id, Price: "1", PriceStatus: query.PriceStatus_PRICE_STATUS_AVAILABLE}
+		switch id {
+		case "fresh":
+			price.Timestamp = now - 1
+		case "stale":
+			price.Timestamp = now - 600
+		case "unavailable":
+			price = &query.PriceData{SignalId: id, PriceStatus: query.PriceStatus_PRICE_STATUS_UNAVAILABLE}
+		case "unsupported":
+			price = &query.PriceData{SignalId: id, PriceStatus: query.PriceStatus_PRICE_STATUS_UNSUPPORTED}
+		}
+		prices = append(prices, price)
+	}
+	return &query.QueryPricesResponse{Prices: prices}, nil
+}
+
+func TestStaleness(t *testing.T) {
+	gwmux := runtime.NewServeMux(runtime.WithForwardResponseOption(checkStaleness))
+	if err := query.RegisterQueryHandlerClient(context.Background(), gwmux, agedQueryClient{}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		maxAge uint64
+		target string
+		code   int
+	}{
+		{"fresh", 60, "/prices/fresh", http.StatusOK},
+		{"stale", 60, "/prices/stale", http.StatusServiceUnavailable},
+		{"one fresh price", 60, "/prices/stale,fresh", http.StatusOK},
+		{"unavailable", 60, "/prices/stale,unavailable", http.StatusServiceUnavailable},
+		{"unsupported only", 60, "/prices/unsupported", http.StatusOK},
+		// Servers that predate the timestamp send none.
+		{"no timestamp", 60, "/prices/stale,untimed", http.StatusOK},
+		{"disabled", 0, "/prices/stale", http.StatusOK},
+		{"enabled by the request", 0, "/prices/stale?max_age=60", http.StatusServiceUnavailable},
+		{"raised by the request", 60, "/prices/stale?max_age=3600", http.StatusOK},
+		{"disabled by the request", 60, "/prices/stale?max_age=0", http.StatusOK},
+		{"invalid max age", 60, "/prices/fresh?max_age=1m", http.StatusBadRequest},
+		{"largest max age", 0, "/prices/stale?max_age=" + strconv.FormatUint(maxAgeLimit, 10), http.StatusOK},
+		{"max age overflowing a duration", 0, "/prices/fresh?max_age=99999999999", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			StalenessConfig{MaxAge: tt.maxAge}.middleware(gwmux, gwmux).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if rec.Code != tt.code {
+				t.Fatalf("expected status %d, got %d: %s", tt.code, rec.Code, rec.Body)
+			}
+		})
+	}
+}
+
+func TestStalenessConfigOverflow(t *testing.T) {
+	if _, err := New(Config{Staleness: StalenessConfig{MaxAge: maxAgeLimit + 1}}); err == nil {
+		t.Error("expected a max_age overflowing a duration to be rejected")
+	}
+	if _, err := New(Config{Staleness: StalenessConfig{MaxAge: maxAgeLimit}}); err != nil {
+		t.Errorf("expected the largest max_age to be accepted, got %v", err)
+	}
+}
+
+func TestStalenessError(t *testing.T) {
+	gwmux := runtime.NewServeMux(runtime.WithForwardResponseOption(checkStaleness))
+	if err := query.RegisterQueryHandlerClient(context.Background(), gwmux, agedQueryClient{}); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	StalenessConfig{MaxAge: 60}.middleware(gwmux, gwmux).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prices/stale,unavailable", nil))
+
+	var body struct {
+		Code    codes.Code `json:"code"`
+		Details []struct {
+			Type     string            `json:"@type"`
+			Reason   string            `json:"reason"`
+			Metadata map[string]string `json:"metadata"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Code != codes.Unavailable || len(body.Details) != 1 || body.Details[0].Reason != staleReason {
+		t.Fatalf("unexpected error %s", rec.Body)
+	}
+	metadata := body.Details[0].Metadata
+	if age, _ := strconv.Atoi(metadata["newest_age_seconds"]); metadata["max_age_seconds"] != "60" || metadata["stale_prices"] != "2" || age < 600 {
+		t.Errorf("unexpected metadata %v", metadata)
+	}
+}
+
+func TestStalenessProtobuf(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected call of the next handler")
+	})
+	handler := StalenessConfig{MaxAge: 60}.middleware(runtime.NewServeMux(), protobufHandler((&Server{}).newGatewayMux(), agedQueryClient{}, CostConfig{}, nil, next))
+
+	resp := postProtobuf(t, handler, &query.QueryPricesRequest{SignalIds: []string{"stale"}})
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503, got %d", resp.StatusCode)
+	}
+	var s spb.Status
+	if err := readProtobuf(resp, &s); err != nil {
+		t.Fatal(err)
+	}
+	if codes.Code(s.Code) != codes.Unavailable || len(s.Details) != 1 {
+		t.Errorf("unexpected error %v", &s)
+	}
+}
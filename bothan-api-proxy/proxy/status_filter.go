@@ -0,0 +1,85 @@
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+const (
+	// statusParam is the query parameter of GET /prices/{signal_ids} that takes comma
+	// separated price statuses, e.g. ?status=available,unavailable.
+	statusParam = "status"
+	// statusesParam is the query parameter of the statuses field of the request, which the
+	// gateway maps to the upstream query.
+	statusesParam = "statuses"
+)
+
+// filterStatuses translates the status parameter of the GET /prices/{signal_ids} requests of
+// the gateway to the statuses field of the request, so that the server only returns the
+// prices with one of the given statuses. Statuses are given by the lower case suffix of their
+// enum name, or by the full name; requests with an unknown status are rejected with a gateway
+// error. Only the statuses of the PriceStatus enum are accepted: staleness is not a status
+// the server reports but an age the proxy checks, so stale is rejected in favour of max_age.
+func filterStatuses(mux *runtime.ServeMux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		values := r.URL.Query()
+		if r.Method != http.MethodGet || !strings.HasPrefix(r.URL.Path, pricesPath+"/") || !values.Has(statusParam) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		for _, param := range values[statusParam] {
+			for _, name := range strings.Split(param, ",") {
+				s, err := parsePriceStatus(name)
+				if err != nil {
+					runtime.HTTPError(r.Context(), mux, &runtime.JSONPb{}, w, r, status.Error(codes.InvalidArgument, err.Error()))
+					return
+				}
+				values.Add(statusesParam, s.String())
+			}
+		}
+		values.Del(statusParam)
+
+		u := *r.URL
+		u.RawQuery = values.Encode()
+		r2 := *r
+		r2.URL = &u
+		next.ServeHTTP(w, &r2)
+	})
+}
+
+// parsePriceStatus parses a price status given as e.g. available or PRICE_STATUS_AVAILABLE.
+func parsePriceStatus(name string) (query.PriceStatus, error) {
+	enum := strings.ToUpper(strings.TrimSpace(name))
+	if !strings.HasPrefix(enum, "PRICE_STATUS_") {
+		enum = "PRICE_STATUS_" + enum
+	}
+
+	value, ok := query.PriceStatus_value[enum]
+	if enum == "PRICE_STATUS_STALE" {
+		return 0, fmt.Errorf("unknown price status %q, use %s to reject stale prices", name, maxAgeParam)
+	}
+	if !ok || value == int32(query.PriceStatus_PRICE_STATUS_UNSPECIFIED) {
+		return 0, fmt.Errorf("unknown price status %q, expected one of %s", name, strings.Join(priceStatusNames(), ", "))
+	}
+	return query.PriceStatus(value), nil
+}
+
+// priceStatusNames returns the short names of the price statuses accepted by the filter.
+func priceStatusNames() []string {
+	var names []string
+	for value := int32(1); ; value++ {
+		name, ok := query.PriceStatus_name[value]
+		if !ok {
+			return names
+		}
+		names = append(names, strings.ToLower(strings.TrimPrefix(name, "PRICE_STATUS_")))
+	}
+}
@@ -0,0 +1,103 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestFilterStatuses(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		expected []string
+		code     int
+	}{
+		{"no filter", "/prices/btc", nil, http.StatusOK},
+		{"short names", "/prices/btc?status=available,unavailable", []string{"PRICE_STATUS_AVAILABLE", "PRICE_STATUS_UNAVAILABLE"}, http.StatusOK},
+		{"repeated parameter", "/prices/btc?status=available&status=unsupported", []string{"PRICE_STATUS_AVAILABLE", "PRICE_STATUS_UNSUPPORTED"}, http.StatusOK},
+		{"full name", "/prices/btc?status=PRICE_STATUS_AVAILABLE", []string{"PRICE_STATUS_AVAILABLE"}, http.StatusOK},
+		{"unknown status", "/prices/btc?status=available,stale", nil, http.StatusBadRequest},
+		{"unspecified status", "/prices/btc?status=unspecified", nil, http.StatusBadRequest},
+		{"empty status", "/prices/btc?status=available,", nil, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var statuses []string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Has(statusParam) {
+					t.Error("expected the status parameter to be translated")
+				}
+				statuses = r.URL.Query()[statusesParam]
+			})
+
+			rec := httptest.NewRecorder()
+			filterStatuses(runtime.NewServeMux(), next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if rec.Code != tt.code {
+				t.Fatalf("expected status %d, got %d", tt.code, rec.Code)
+			}
+			if !slices.Equal(statuses, tt.expected) {
+				t.Errorf("expected statuses %v, got %v", tt.expected, statuses)
+			}
+		})
+	}
+}
+
+func TestFilterStatusesRejectsStale(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected the request to be rejected")
+	})
+
+	rec := httptest.NewRecorder()
+	filterStatuses(runtime.NewServeMux(), next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prices/btc?status=available,stale", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), maxAgeParam) {
+		t.Errorf("expected the error to point to %s, got %s", maxAgeParam, rec.Body)
+	}
+}
+
+func TestPriceStatusNames(t *testing.T) {
+	expected := []string{"unsupported", "unavailable", "available"}
+	if names := priceStatusNames(); !slices.Equal(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
+
+type recordingQueryClient struct {
+	stubQueryClient
+	requests chan *query.QueryPricesRequest
+}
+
+func (c recordingQueryClient) Prices(ctx context.Context, in *query.QueryPricesRequest, opts ...grpc.CallOption) (*query.QueryPricesResponse, error) {
+	c.requests <- in
+	return c.stubQueryClient.Prices(ctx, in, opts...)
+}
+
+func TestFilterStatusesGateway(t *testing.T) {
+	client := recordingQueryClient{requests: make(chan *query.QueryPricesRequest, 1)}
+	gwmux := runtime.NewServeMux()
+	if err := query.RegisterQueryHandlerClient(context.Background(), gwmux, client); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	filterStatuses(gwmux, gwmux).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prices/btc?status=available,unsupported", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d: %s", rec.Code, rec.Body)
+	}
+
+	expected := []query.PriceStatus{query.PriceStatus_PRICE_STATUS_AVAILABLE, query.PriceStatus_PRICE_STATUS_UNSUPPORTED}
+	if req := <-client.requests; !slices.Equal(req.Statuses, expected) {
+		t.Errorf("expected statuses %v upstream, got %v", expected, req.Statuses)
+	}
+}
@@ -0,0 +1,158 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/websocket"
+)
+
+// streamBridge exposes the server-streaming methods of the gateway to browsers. The gateway
+// writes every message of a stream as a line of JSON on a chunked response. Requests that
+// accept text/event-stream receive each line as a Server-Sent Event instead, and WebSocket
+// requests receive each line as a text message. All other requests are passed to next
+// unchanged, which also serves unary methods under both bridges as a single event or message.
+// WebSocket handshakes are checked with checkOrigin, as browsers do not apply the same-origin
+// policy to WebSockets and would otherwise let any page read the streams with their cookies.
+func streamBridge(next http.Handler, checkOrigin func(*websocket.Config, *http.Request) error) http.Handler {
+	ws := websocket.Server{
+		Handshake: checkOrigin,
+		Handler: func(conn *websocket.Conn) {
+			// The context of a hijacked request is not cancelled when the client goes away.
+			// Clients send nothing on a stream, so the first failed read means they are gone.
+			ctx, cancel := context.WithCancel(conn.Request().Context())
+			defer cancel()
+			go func() {
+				_, _ = io.Copy(io.Discard, conn)
+				cancel()
+			}()
+
+			r := conn.Request().WithContext(ctx)
+			w := &lineWriter{header: http.Header{}, emit: func(line []byte) error {
+				_, err := conn.Write(line)
+				return err
+			}}
+			next.ServeHTTP(w, r)
+			w.Flush()
+		},
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case isWebSocket(r):
+			ws.ServeHTTP(w, r)
+		case acceptsEvents(r):
+			serveEvents(next, w, r)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// isWebSocket reports whether the request asks to be bridged to a WebSocket.
+func isWebSocket(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+// acceptsEvents reports whether the request asks to be bridged to Server-Sent Events.
+func acceptsEvents(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+// serveEvents serves the request with next and writes every line of its response as a
+// Server-Sent Event.
+func serveEvents(next http.Handler, w http.ResponseWriter, r *http.Request) {
+	flusher, _ := w.(http.Flusher)
+	lw := &lineWriter{header: w.Header(), emit: func(line []byte) error {
+		if _, err := w.Write([]byte("data: ")); err != nil {
+			return err
+		}
+		if _, err := w.Write(line); err != nil {
+			return err
+		}
+		if _, err := w.Write([]byte("\n\n")); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	}}
+	lw.onHeader = func(status int) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Del("Content-Length")
+		w.WriteHeader(status)
+	}
+
+	next.ServeHTTP(lw, r)
+	lw.Flush()
+}
+
+// lineWriter is a ResponseWriter that splits the response body into lines and emits each
+// non-empty line on its own.
+type lineWriter struct {
+	header   http.Header
+	emit     func(line []byte) error
+	onHeader func(status int)
+
+	wroteHeader bool
+	buf         bytes.Buffer
+	err         error
+}
+
+func (w *lineWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *lineWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if w.onHeader != nil {
+		w.onHeader(status)
+	}
+}
+
+func (w *lineWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	w.buf.Write(b)
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			return len(b), nil
+		}
+		line := w.buf.Next(i + 1)
+		if err := w.emitLine(line[:i]); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Flush emits the remaining partial line, which is the whole body of unary responses.
+func (w *lineWriter) Flush() {
+	w.WriteHeader(http.StatusOK)
+	if w.err == nil && w.buf.Len() > 0 {
+		_ = w.emitLine(w.buf.Next(w.buf.Len()))
+	}
+}
+
+func (w *lineWriter) emitLine(line []byte) error {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return nil
+	}
+	if w.err = w.emit(line); w.err != nil {
+		return w.err
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/websocket"
+)
+
+// streamingHandler writes two messages the way the gateway writes server streams.
+var streamingHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"result":{"price":"1"}}` + "\n"))
+	w.(http.Flusher).Flush()
+	_, _ = w.Write([]byte(`{"result":{"price":"2"}}` + "\n"))
+})
+
+func TestStreamBridgeServerSentEvents(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/stream", nil)
+	r.Header.Set("Accept", "text/event-stream")
+	rec := httptest.NewRecorder()
+	streamBridge(streamingHandler, TunnelConfig{}.checkOrigin).ServeHTTP(rec, r)
+
+	if ct := rec.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected content type text/event-stream, got %q", ct)
+	}
+	expected := "data: {\"result\":{\"price\":\"1\"}}\n\ndata: {\"result\":{\"price\":\"2\"}}\n\n"
+	if rec.Body.String() != expected {
+		t.Errorf("expected %q, got %q", expected, rec.Body.String())
+	}
+}
+
+func TestStreamBridgeWebSocket(t *testing.T) {
+	server := httptest.NewServer(streamBridge(streamingHandler, TunnelConfig{}.checkOrigin))
+	defer server.Close()
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/stream", "", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	for _, expected := range []string{`{"result":{"price":"1"}}`, `{"result":{"price":"2"}}`} {
+		var msg string
+		if err := websocket.Message.Receive(ws, &msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg != expected {
+			t.Errorf("expected %q, got %q", expected, msg)
+		}
+	}
+}
+
+func TestStreamBridgeWebSocketOrigin(t *testing.T) {
+	config := TunnelConfig{AllowedOrigins: []string{"https://dashboard.example.com"}}
+	server := httptest.NewServer(streamBridge(streamingHandler, config.checkOrigin))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/stream"
+
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{server.URL, true},
+		{"https://dashboard.example.com", true},
+		{"https://evil.example.com", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			ws, err := websocket.Dial(url, "", tt.origin)
+			if err == nil {
+				ws.Close()
+			}
+			if allowed := err == nil; allowed != tt.allowed {
+				t.Errorf("expected allowed %v, got error %v", tt.allowed, err)
+			}
+		})
+	}
+}
+
+func TestStreamBridgePassesPlainRequests(t *testing.T) {
+	rec := httptest.NewRecorder()
+	streamBridge(streamingHandler, TunnelConfig{}.checkOrigin).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream", nil))
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected the response to pass unchanged, got content type %q", ct)
+	}
+}
@@ -0,0 +1,140 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const defaultRouteLabel = "default"
+
+// TimeoutConfig defines the deadlines of the upstream calls made for HTTP requests.
+type TimeoutConfig struct {
+	// Default is the deadline of requests without a matching route, e.g. "10s". Requests
+	// have no deadline if it is empty.
+	Default string `toml:"default"`
+	// Routes overrides the default deadline for specific routes.
+	Routes []RouteTimeout `toml:"routes"`
+}
+
+// RouteTimeout defines the deadline of the requests whose path starts with Path. If Method is
+// set, only requests with that method match. When several routes match, the one with the
+// longest path wins, and a route with a method wins over one without.
+type RouteTimeout struct {
+	Path    string `toml:"path"`
+	Method  string `toml:"method"`
+	Timeout string `toml:"timeout"`
+}
+
+type routeTimeout struct {
+	path    string
+	method  string
+	timeout time.Duration
+}
+
+// timeouts applies the deadlines of a TimeoutConfig to requests.
+type timeouts struct {
+	fallback time.Duration
+	routes   []routeTimeout
+	exceeded *prometheus.CounterVec
+}
+
+func newTimeouts(config TimeoutConfig, registerer prometheus.Registerer) (*timeouts, error) {
+	fallback, err := parseTimeout(config.Default)
+	if err != nil {
+		return nil, fmt.Errorf("invalid default timeout: %w", err)
+	}
+
+	routes := make([]routeTimeout, 0, len(config.Routes))
+	for _, route := range config.Routes {
+		if !strings.HasPrefix(route.Path, "/") {
+			return nil, fmt.Errorf("invalid timeout route %q: path must start with /", route.Path)
+		}
+		timeout, err := parseTimeout(route.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout for route %q: %w", route.Path, err)
+		}
+		routes = append(routes, routeTimeout{
+			path:    route.Path,
+			method:  strings.ToUpper(route.Method),
+			timeout: timeout,
+		})
+	}
+
+	exceeded := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "bothan_proxy_deadline_exceeded_total",
+		Help: "Number of requests whose upstream deadline was exceeded, by route.",
+	}, []string{"route"})
+	if err := registerer.Register(exceeded); err != nil {
+		return nil, err
+	}
+
+	return &timeouts{fallback: fallback, routes: routes, exceeded: exceeded}, nil
+}
+
+func parseTimeout(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if timeout < 0 {
+		return 0, errors.New("timeout must not be negative")
+	}
+
+	return timeout, nil
+}
+
+// match returns the route label and deadline for the given request.
+func (t *timeouts) match(r *http.Request) (string, time.Duration) {
+	label, timeout := defaultRouteLabel, t.fallback
+
+	var best *routeTimeout
+	for i := range t.routes {
+		route := &t.routes[i]
+		if !strings.HasPrefix(r.URL.Path, route.path) || (route.method != "" && route.method != r.Method) {
+			continue
+		}
+		if best == nil || len(route.path) > len(best.path) ||
+			(len(route.path) == len(best.path) && best.method == "" && route.method != "") {
+			best = route
+		}
+	}
+	if best != nil {
+		label, timeout = best.path, best.timeout
+		if best.method != "" {
+			label = best.method + " " + best.path
+		}
+	}
+
+	return label, timeout
+}
+
+// middleware bounds every request by the deadline of its route and counts the requests that
+// exceed it.
+func (t *timeouts) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		label, timeout := t.match(r)
+		if timeout == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			t.exceeded.WithLabelValues(label).Inc()
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestTimeoutsMatch(t *testing.T) {
+	timeouts, err := newTimeouts(TimeoutConfig{
+		Default: "10s",
+		Routes: []RouteTimeout{
+			{Path: "/prices", Timeout: "2s"},
+			{Path: "/prices", Method: "post", Timeout: "3s"},
+			{Path: "/registry", Timeout: "60s"},
+		},
+	}, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		method  string
+		path    string
+		label   string
+		timeout time.Duration
+	}{
+		{http.MethodGet, "/prices/crypto_price.btcusd", "/prices", 2 * time.Second},
+		{http.MethodPost, "/prices/crypto_price.btcusd", "POST /prices", 3 * time.Second},
+		{http.MethodGet, "/registry", "/registry", 60 * time.Second},
+		{http.MethodGet, "/info", defaultRouteLabel, 10 * time.Second},
+	}
+	for _, tt := range tests {
+		label, timeout := timeouts.match(httptest.NewRequest(tt.method, tt.path, nil))
+		if label != tt.label || timeout != tt.timeout {
+			t.Errorf("%s %s: expected %s %v, got %s %v", tt.method, tt.path, tt.label, tt.timeout, label, timeout)
+		}
+	}
+}
+
+func TestTimeoutsCountExceededDeadlines(t *testing.T) {
+	timeouts, err := newTimeouts(TimeoutConfig{
+		Routes: []RouteTimeout{{Path: "/prices", Timeout: "1ms"}},
+	}, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := timeouts.middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/prices/a", nil))
+
+	if got := testutil.ToFloat64(timeouts.exceeded.WithLabelValues("/prices")); got != 1 {
+		t.Errorf("expected 1 exceeded deadline, got %v", got)
+	}
+}
+
+func TestTimeoutsRejectInvalidConfig(t *testing.T) {
+	configs := []TimeoutConfig{
+		{Default: "soon"},
+		{Routes: []RouteTimeout{{Path: "prices", Timeout: "1s"}}},
+		{Routes: []RouteTimeout{{Path: "/prices", Timeout: "-1s"}}},
+	}
+	for _, config := range configs {
+		if _, err := newTimeouts(config, prometheus.NewRegistry()); err == nil {
+			t.Errorf("expected error for %+v", config)
+		}
+	}
+}
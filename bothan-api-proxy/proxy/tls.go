@@ -0,0 +1,61 @@
+package proxy
+
+import (
+	"crypto/tls"
+	"sync"
+)
+
+// TLSConfig enables TLS on the HTTP server of the proxy. The certificate and key are reloaded
+// when their files change, so certificates can be rotated without a restart.
+type TLSConfig struct {
+	CertFile string `toml:"cert_file"`
+	KeyFile  string `toml:"key_file"`
+}
+
+func (c TLSConfig) enabled() bool {
+	return c.CertFile != "" || c.KeyFile != ""
+}
+
+// certReloader serves the most recently loaded certificate of a TLSConfig.
+type certReloader struct {
+	config TLSConfig
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+func newCertReloader(config TLSConfig) (*certReloader, error) {
+	r := &certReloader{config: config}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// reload loads the certificate from disk. The previous certificate is kept if loading fails.
+func (r *certReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+
+	return nil
+}
+
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+func (r *certReloader) tlsConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.getCertificate,
+	}
+}
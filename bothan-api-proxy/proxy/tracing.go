@@ -0,0 +1,36 @@
+package proxy
+
+import (
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// traceHeaders are the trace context headers of W3C Trace Context and B3 that are forwarded
+// unchanged to the upstream server, so that tracing infrastructure can join the HTTP and gRPC
+// hops of a request.
+var traceHeaders = map[string]struct{}{
+	"traceparent":       {},
+	"tracestate":        {},
+	"b3":                {},
+	"x-b3-traceid":      {},
+	"x-b3-spanid":       {},
+	"x-b3-parentspanid": {},
+	"x-b3-sampled":      {},
+	"x-b3-flags":        {},
+}
+
+// traceHeaderMatcher forwards the trace context headers and the consumer name as gRPC
+// metadata under their own names and handles all other headers like the default matcher of
+// the gateway.
+func traceHeaderMatcher(key string) (string, bool) {
+	key = strings.ToLower(key)
+	if _, ok := traceHeaders[key]; ok {
+		return key, true
+	}
+	if key == strings.ToLower(ConsumerHeader) {
+		return key, true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
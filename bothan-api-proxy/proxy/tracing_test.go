@@ -0,0 +1,26 @@
+package proxy
+
+import "testing"
+
+func TestTraceHeaderMatcher(t *testing.T) {
+	tests := []struct {
+		header string
+		key    string
+		ok     bool
+	}{
+		{"Traceparent", "traceparent", true},
+		{"tracestate", "tracestate", true},
+		{"X-B3-TraceId", "x-b3-traceid", true},
+		{"B3", "b3", true},
+		{"X-Bothan-Consumer", "x-bothan-consumer", true},
+		{"Grpc-Metadata-Foo", "Foo", true},
+		{"Authorization", "grpcgateway-Authorization", true},
+		{"X-Unrelated", "", false},
+	}
+	for _, tt := range tests {
+		key, ok := traceHeaderMatcher(tt.header)
+		if key != tt.key || ok != tt.ok {
+			t.Errorf("%s: expected %q %v, got %q %v", tt.header, tt.key, tt.ok, key, ok)
+		}
+	}
+}
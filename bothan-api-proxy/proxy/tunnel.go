@@ -0,0 +1,116 @@
+package proxy
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+const (
+	defaultTunnelPath = "/tunnel"
+	tunnelDialTimeout = 10 * time.Second
+)
+
+// TunnelConfig defines the WebSocket tunnel that carries raw gRPC traffic to the upstream
+// server, for clients that can only reach the proxy over HTTP(S). The tunnel requires the
+// managed API keys like the other routes, or the token if they are disabled.
+type TunnelConfig struct {
+	Enabled bool   `toml:"enabled"`
+	Path    string `toml:"path"`
+	// Token is required as a bearer token to open the tunnel. It must be set unless the
+	// managed API keys are enabled. Unlike the admin token, it is only read at start.
+	Token Secret `toml:"token"`
+	// AllowedOrigins are the origins of the browsers allowed to open the tunnel, and the
+	// WebSockets of the streaming routes, besides the origin of the proxy itself, e.g.
+	// "https://dashboard.example.com". Requests without an Origin header do not come from
+	// browsers and are not checked.
+	AllowedOrigins []string `toml:"allowed_origins"`
+}
+
+func (c TunnelConfig) path() string {
+	if c.Path == "" {
+		return defaultTunnelPath
+	}
+
+	return c.Path
+}
+
+// checkOrigin rejects the WebSocket handshakes of browsers on other origins than the proxy
+// and the allowed origins.
+func (c TunnelConfig) checkOrigin(_ *websocket.Config, r *http.Request) error {
+	origin := r.Header.Get("Origin")
+	if origin == "" || slices.Contains(c.AllowedOrigins, origin) {
+		return nil
+	}
+	if u, err := url.Parse(origin); err == nil && u.Host == r.Host {
+		return nil
+	}
+
+	return fmt.Errorf("origin %s is not allowed", origin)
+}
+
+// tunnelHandler returns a handler that pipes the frames of every WebSocket connection to a
+// new TCP connection to the first reachable of the given upstream gRPC servers. Requests
+// without the token of the configuration, if it has one, are rejected with 401.
+func tunnelHandler(config TunnelConfig, upstreams []string) (http.Handler, error) {
+	token, err := config.Token.Resolve()
+	if err != nil {
+		return nil, fmt.Errorf("error resolving tunnel token: %w", err)
+	}
+
+	ws := websocket.Server{
+		Handshake: config.checkOrigin,
+		Handler: func(ws *websocket.Conn) {
+			ws.PayloadType = websocket.BinaryFrame
+
+			conn, err := dialFirst(upstreams)
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+
+			done := make(chan struct{}, 2)
+			go func() {
+				_, _ = io.Copy(conn, ws)
+				done <- struct{}{}
+			}()
+			go func() {
+				_, _ = io.Copy(ws, conn)
+				done <- struct{}{}
+			}()
+
+			// Either side closing ends the tunnel, the WebSocket is closed once the handler returns.
+			<-done
+		},
+	}
+	if token == "" {
+		return ws, nil
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(r, token) {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		ws.ServeHTTP(w, r)
+	}), nil
+}
+
+// dialFirst connects to the first of the given addresses that accepts a connection.
+func dialFirst(addrs []string) (net.Conn, error) {
+	var err error
+	for _, addr := range addrs {
+		var conn net.Conn
+		if conn, err = net.DialTimeout("tcp", addr, tunnelDialTimeout); err == nil {
+			return conn, nil
+		}
+	}
+
+	return nil, err
+}
@@ -0,0 +1,168 @@
+package proxy
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc"
+
+	client "github.com/bandprotocol/bothan/bothan-api/client/go-client"
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestTunnelPipesBytesToUpstream(t *testing.T) {
+	upstream, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer upstream.Close()
+
+	// Echo everything back to the tunnel.
+	go func() {
+		conn, err := upstream.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = io.Copy(conn, conn)
+	}()
+
+	handler, err := tunnelHandler(TunnelConfig{}, []string{"127.0.0.1:1", upstream.Addr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + defaultTunnelPath
+	ws, err := websocket.Dial(url, "", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	ws.PayloadType = websocket.BinaryFrame
+
+	payload := []byte("PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n")
+	if _, err := ws.Write(payload); err != nil {
+		t.Fatal(err)
+	}
+
+	echoed := make([]byte, len(payload))
+	if _, err := io.ReadFull(ws, echoed); err != nil {
+		t.Fatal(err)
+	}
+	if string(echoed) != string(payload) {
+		t.Errorf("expected %q, got %q", payload, echoed)
+	}
+}
+
+func TestTunnelEndToEnd(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	upstream := grpc.NewServer()
+	query.RegisterQueryServer(upstream, &staticQueryServer{price: "42"})
+	go upstream.Serve(listener)
+	defer upstream.Stop()
+
+	handler, err := tunnelHandler(TunnelConfig{Token: "secret"}, []string{listener.Addr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + defaultTunnelPath
+
+	c, err := client.NewGRPC("passthrough:///bothan", 5*time.Second, client.WithWebSocketTunnel(url, client.WithTunnelHeaders(map[string]string{"Authorization": "Bearer secret"})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	prices, err := c.QueryPrices([]string{"crypto_price.btcusd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 1 || prices[0].Price != "42" {
+		t.Fatalf("expected the price of the upstream, got %v", prices)
+	}
+
+	// Without the token, the tunnel is not opened.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if conn, err := client.DialWebSocketTunnel(ctx, url); err == nil {
+		conn.Close()
+		t.Error("expected the tunnel to require the token")
+	}
+}
+
+func TestTunnelAuthentication(t *testing.T) {
+	handler, err := tunnelHandler(TunnelConfig{Token: "secret", AllowedOrigins: []string{"https://dashboard.example.com"}}, []string{"127.0.0.1:1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		token  string
+		origin string
+		code   int
+	}{
+		{"no token", "", "", http.StatusUnauthorized},
+		{"wrong token", "other", "", http.StatusUnauthorized},
+		{"other origin", "secret", "https://evil.example.com", http.StatusForbidden},
+		// Without an upstream the tunnel closes right after the upgrade.
+		{"no origin", "secret", "", http.StatusSwitchingProtocols},
+		{"own origin", "secret", server.URL, http.StatusSwitchingProtocols},
+		{"allowed origin", "secret", "https://dashboard.example.com", http.StatusSwitchingProtocols},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, server.URL+defaultTunnelPath, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Upgrade", "websocket")
+			req.Header.Set("Connection", "Upgrade")
+			req.Header.Set("Sec-WebSocket-Version", "13")
+			req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.code {
+				t.Errorf("expected status %d, got %d", tt.code, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestTunnelRequiresAuthentication(t *testing.T) {
+	config := Config{Grpc: GrpcConfig{Addr: "localhost:50051"}, Tunnel: TunnelConfig{Enabled: true}}
+	if _, err := New(config); err == nil {
+		t.Error("expected a tunnel without a token or API keys to be rejected")
+	}
+
+	config.Tunnel.Token = "secret"
+	if _, err := New(config); err != nil {
+		t.Errorf("unexpected error with a token: %v", err)
+	}
+}
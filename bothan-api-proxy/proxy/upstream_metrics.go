@@ -0,0 +1,82 @@
+package proxy
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/connectivity"
+)
+
+// upstreamStates are the connectivity states exported per upstream.
+var upstreamStates = []connectivity.State{
+	connectivity.Idle,
+	connectivity.Connecting,
+	connectivity.Ready,
+	connectivity.TransientFailure,
+	connectivity.Shutdown,
+}
+
+// upstreamMetrics exports the connectivity state transitions of the upstream connections,
+// which makes an upstream that keeps flapping visible even while failover hides it.
+type upstreamMetrics struct {
+	state       *prometheus.GaugeVec
+	transitions *prometheus.CounterVec
+	reconnects  *prometheus.CounterVec
+
+	mu        sync.Mutex
+	connected map[string]bool
+}
+
+func newUpstreamMetrics(registerer prometheus.Registerer) (*upstreamMetrics, error) {
+	state := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "bothan_proxy_upstream_state",
+		Help: "Whether the connection to the upstream is in the state, e.g. READY or TRANSIENT_FAILURE.",
+	}, []string{"target", "state"})
+	transitions := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "bothan_proxy_upstream_state_transitions_total",
+		Help: "Number of times the connection to the upstream entered the state.",
+	}, []string{"target", "state"})
+	reconnects := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "bothan_proxy_upstream_reconnects_total",
+		Help: "Number of attempts to reconnect to the upstream after the connection was lost.",
+	}, []string{"target"})
+	for _, collector := range []prometheus.Collector{state, transitions, reconnects} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return &upstreamMetrics{
+		state:       state,
+		transitions: transitions,
+		reconnects:  reconnects,
+		connected:   make(map[string]bool),
+	}, nil
+}
+
+// observe records that the connection to the target went from the previous to the current
+// state. The previous state is the current one for the first observation.
+func (m *upstreamMetrics) observe(target string, previous, current connectivity.State) {
+	for _, state := range upstreamStates {
+		value := 0.0
+		if state == current {
+			value = 1
+		}
+		m.state.WithLabelValues(target, state.String()).Set(value)
+	}
+
+	if previous == current {
+		return
+	}
+	m.transitions.WithLabelValues(target, current.String()).Inc()
+
+	// Every connection attempt after the first one is a reconnect.
+	if current == connectivity.Connecting {
+		m.mu.Lock()
+		if m.connected[target] {
+			m.reconnects.WithLabelValues(target).Inc()
+		}
+		m.connected[target] = true
+		m.mu.Unlock()
+	}
+}
@@ -0,0 +1,47 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc/connectivity"
+)
+
+func TestUpstreamMetrics(t *testing.T) {
+	m, err := newUpstreamMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	states := []connectivity.State{
+		connectivity.Idle,
+		connectivity.Connecting,
+		connectivity.Ready,
+		connectivity.Idle,
+		connectivity.Connecting,
+		connectivity.TransientFailure,
+		connectivity.Connecting,
+	}
+	previous := states[0]
+	for _, state := range states {
+		m.observe("a", previous, state)
+		previous = state
+	}
+
+	if got := testutil.ToFloat64(m.state.WithLabelValues("a", "CONNECTING")); got != 1 {
+		t.Errorf("expected the current state to be set, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.state.WithLabelValues("a", "READY")); got != 0 {
+		t.Errorf("expected past states to be cleared, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.transitions.WithLabelValues("a", "CONNECTING")); got != 3 {
+		t.Errorf("expected 3 transitions to CONNECTING, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.transitions.WithLabelValues("a", "TRANSIENT_FAILURE")); got != 1 {
+		t.Errorf("expected 1 transition to TRANSIENT_FAILURE, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.reconnects.WithLabelValues("a")); got != 2 {
+		t.Errorf("expected 2 reconnects, got %v", got)
+	}
+}
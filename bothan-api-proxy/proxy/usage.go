@@ -0,0 +1,488 @@
+package proxy
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/grpclog"
+)
+
+const defaultAPIKeyHeader = "X-API-Key"
+
+// ConsumerHeader is the header in which downstream services name themselves, so that load can
+// be attributed to them.
+const ConsumerHeader = "X-Bothan-Consumer"
+
+// maxConsumerNameLength bounds the length of a consumer name to keep the metric labels small.
+const maxConsumerNameLength = 64
+
+// defaultMaxConsumers is the number of consumers whose usage is kept if the configuration
+// does not say.
+const defaultMaxConsumers = 10000
+
+// otherConsumerName is the name label of the requests of consumers whose declared name is not
+// in UsageConfig.ConsumerNames.
+const otherConsumerName = "other"
+
+// UsageConfig defines how the proxy identifies consumers for usage accounting.
+type UsageConfig struct {
+	// APIKeyHeader is the header carrying the consumer's API key. Bearer tokens in the
+	// Authorization header are also accepted.
+	APIKeyHeader string `toml:"api_key_header"`
+	// TrustForwardedFor uses the rightmost X-Forwarded-For address that is not one of the
+	// TrustedProxies as the consumer IP, for requests received from one of the
+	// TrustedProxies. Only enable this when the proxy sits behind a trusted load balancer.
+	TrustForwardedFor bool `toml:"trust_forwarded_for"`
+	// TrustedProxies are the addresses or CIDR ranges of the load balancers in front of the
+	// proxy. X-Forwarded-For is ignored on requests from other addresses, and the entries of
+	// the trusted proxies in it are skipped.
+	TrustedProxies []string `toml:"trusted_proxies"`
+	// ConsumerNames are the consumer names that get their own label in the metrics. Requests
+	// declaring any other name are labelled "other".
+	ConsumerNames []string `toml:"consumer_names"`
+	// MaxConsumers is the number of consumers whose usage is kept. The least recently seen
+	// consumer is forgotten when a new one is seen. Defaults to 10000.
+	MaxConsumers int `toml:"max_consumers"`
+	// AdminToken protects the admin endpoints. If empty, the admin endpoints reject every
+	// request.
+	AdminToken Secret `toml:"admin_token"`
+}
+
+// Consumer identifies the origin of a request.
+type Consumer struct {
+	// Kind is either "api_key" or "ip".
+	Kind string `json:"kind"`
+	// ID is the fingerprint of the API key or the IP address of the consumer.
+	ID string `json:"id"`
+	// Name is the name the consumer declared in the ConsumerHeader, if any. It is not
+	// authenticated and only serves analytics.
+	Name string `json:"name,omitempty"`
+}
+
+// ConsumerUsage holds the request counts of a single consumer.
+type ConsumerUsage struct {
+	Consumer
+	Requests uint64    `json:"requests"`
+	Errors   uint64    `json:"errors"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// UsageReport is the response body of the usage endpoint.
+type UsageReport struct {
+	Since     time.Time       `json:"since"`
+	Consumers []ConsumerUsage `json:"consumers"`
+}
+
+// UsageTracker counts the requests made by each consumer. The request counter is labelled
+// with the fingerprint of the API key, but not with the IP address, of a consumer, and only
+// while its usage is kept, so that the number of series is bounded.
+type UsageTracker struct {
+	since    time.Time
+	requests *prometheus.CounterVec
+
+	mu             sync.Mutex
+	config         UsageConfig
+	adminToken     string
+	trustedProxies []*net.IPNet
+	consumerNames  map[string]bool
+	// usage holds the elements of recent, which orders the consumers from the most to the
+	// least recently seen.
+	usage  map[Consumer]*list.Element
+	recent *list.List
+	// keys counts the consumers kept per API key fingerprint, which may be seen under
+	// several names.
+	keys map[string]int
+}
+
+// NewUsageTracker creates a new UsageTracker and registers its counters on the given registerer.
+func NewUsageTracker(config UsageConfig, registerer prometheus.Registerer) (*UsageTracker, error) {
+	requests := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "bothan_proxy_consumer_requests_total",
+			Help: "Total number of requests handled by the proxy per consumer.",
+		},
+		[]string{"kind", "consumer", "name", "code"},
+	)
+	if err := registerer.Register(requests); err != nil {
+		return nil, err
+	}
+
+	tracker := &UsageTracker{
+		since:    time.Now(),
+		requests: requests,
+		usage:    make(map[Consumer]*list.Element),
+		recent:   list.New(),
+		keys:     make(map[string]int),
+	}
+	if err := tracker.SetConfig(config); err != nil {
+		return nil, err
+	}
+
+	return tracker, nil
+}
+
+// SetConfig replaces the configuration of the tracker. Usage recorded so far is kept, up to
+// the new maximum number of consumers. The configuration is left unchanged if the admin token
+// cannot be resolved or a trusted proxy is invalid.
+func (t *UsageTracker) SetConfig(config UsageConfig) error {
+	if config.APIKeyHeader == "" {
+		config.APIKeyHeader = defaultAPIKeyHeader
+	}
+	if config.MaxConsumers <= 0 {
+		config.MaxConsumers = defaultMaxConsumers
+	}
+
+	adminToken, err := config.AdminToken.Resolve()
+	if err != nil {
+		return fmt.Errorf("error resolving admin token: %w", err)
+	}
+	trustedProxies, err := parseNetworks(config.TrustedProxies)
+	if err != nil {
+		return fmt.Errorf("invalid trusted proxy: %w", err)
+	}
+	consumerNames := make(map[string]bool, len(config.ConsumerNames))
+	for _, name := range config.ConsumerNames {
+		consumerNames[name] = true
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.config = config
+	t.adminToken = adminToken
+	t.trustedProxies = trustedProxies
+	t.consumerNames = consumerNames
+	for t.recent.Len() > config.MaxConsumers {
+		t.evictLocked()
+	}
+
+	return nil
+}
+
+// reloadSecrets resolves the admin token again, e.g. after its file changed.
+func (t *UsageTracker) reloadSecrets() error {
+	return t.SetConfig(t.getConfig())
+}
+
+// watchAdminToken reloads the admin token whenever its file changes, until the context is
+// cancelled. The watched file follows the configuration, so that a reload pointing the admin
+// token at another file takes effect without a restart.
+func (t *UsageTracker) watchAdminToken(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	path := t.getConfig().AdminToken.path()
+	watcher := newFileWatcher(path)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// SetConfig already resolved the token of a new file.
+		if current := t.getConfig().AdminToken.path(); current != path {
+			path, watcher = current, newFileWatcher(current)
+			continue
+		}
+		if watcher.changed() {
+			if err := t.reloadSecrets(); err != nil {
+				grpclog.Errorf("error reloading admin token: %v", err)
+			}
+		}
+	}
+}
+
+func (t *UsageTracker) getConfig() UsageConfig {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.config
+}
+
+// Identify returns the consumer of the given request. Requests carrying an API key are
+// attributed to the key, all other requests are attributed to the client IP. Either is
+// tagged with the consumer name declared in the request.
+func (t *UsageTracker) Identify(r *http.Request) Consumer {
+	t.mu.Lock()
+	config, trustedProxies := t.config, t.trustedProxies
+	t.mu.Unlock()
+
+	name := consumerName(r)
+	if key := apiKey(r, config.APIKeyHeader); key != "" {
+		return Consumer{Kind: "api_key", ID: fingerprint(key), Name: name}
+	}
+
+	ip := remoteIP(r)
+	if config.TrustForwardedFor {
+		ip = forwardedIP(r, ip, trustedProxies)
+	}
+
+	return Consumer{Kind: "ip", ID: ip, Name: name}
+}
+
+// Record records a completed request of the given consumer.
+func (t *UsageTracker) Record(consumer Consumer, code int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	element, ok := t.usage[consumer]
+	if ok {
+		t.recent.MoveToFront(element)
+	} else {
+		if t.recent.Len() >= t.config.MaxConsumers {
+			t.evictLocked()
+		}
+		element = t.recent.PushFront(&ConsumerUsage{Consumer: consumer})
+		t.usage[consumer] = element
+		if consumer.Kind == "api_key" {
+			t.keys[consumer.ID]++
+		}
+	}
+
+	usage := element.Value.(*ConsumerUsage)
+	usage.Requests++
+	if code >= http.StatusBadRequest {
+		usage.Errors++
+	}
+	usage.LastSeen = time.Now()
+
+	t.requests.With(t.labelsLocked(consumer, code)).Inc()
+}
+
+// evictLocked forgets the least recently seen consumer and deletes its series of the request
+// counter. API keys are the only consumers with series of their own.
+func (t *UsageTracker) evictLocked() {
+	element := t.recent.Back()
+	usage := t.recent.Remove(element).(*ConsumerUsage)
+	delete(t.usage, usage.Consumer)
+
+	if usage.Kind != "api_key" {
+		return
+	}
+	if t.keys[usage.ID]--; t.keys[usage.ID] == 0 {
+		delete(t.keys, usage.ID)
+		t.requests.DeletePartialMatch(prometheus.Labels{"kind": usage.Kind, "consumer": usage.ID})
+	}
+}
+
+// labelsLocked returns the labels of the request counter for a request of the consumer.
+func (t *UsageTracker) labelsLocked(consumer Consumer, code int) prometheus.Labels {
+	id := consumer.ID
+	if consumer.Kind == "ip" {
+		id = ""
+	}
+	name := consumer.Name
+	if name != "" && !t.consumerNames[name] {
+		name = otherConsumerName
+	}
+
+	return prometheus.Labels{"kind": consumer.Kind, "consumer": id, "name": name, "code": strconv.Itoa(code)}
+}
+
+// Report returns a snapshot of the usage of all consumers, ordered by request count.
+func (t *UsageTracker) Report() UsageReport {
+	t.mu.Lock()
+	consumers := make([]ConsumerUsage, 0, t.recent.Len())
+	for element := t.recent.Front(); element != nil; element = element.Next() {
+		consumers = append(consumers, *element.Value.(*ConsumerUsage))
+	}
+	t.mu.Unlock()
+
+	sort.Slice(consumers, func(i, j int) bool {
+		if consumers[i].Requests != consumers[j].Requests {
+			return consumers[i].Requests > consumers[j].Requests
+		}
+		return consumers[i].ID < consumers[j].ID
+	})
+
+	return UsageReport{Since: t.since, Consumers: consumers}
+}
+
+// ServeHTTP serves the usage report as JSON.
+func (t *UsageTracker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if !t.authorized(r) {
+		unauthorized(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(t.Report())
+}
+
+// requireAdmin only passes requests carrying the admin token to the given handler.
+func (t *UsageTracker) requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !t.authorized(r) {
+			unauthorized(w)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (t *UsageTracker) authorized(r *http.Request) bool {
+	t.mu.Lock()
+	adminToken := t.adminToken
+	t.mu.Unlock()
+
+	return authorized(r, adminToken)
+}
+
+// unauthorized rejects a request, asking browsers for the admin token as a Basic password.
+func unauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `Basic realm="bothan-proxy"`)
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
+
+func apiKey(r *http.Request, header string) string {
+	if key := r.Header.Get(header); key != "" {
+		return key
+	}
+
+	return bearerToken(r)
+}
+
+func bearerToken(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	if len(auth) > 7 && strings.EqualFold(auth[:7], "bearer ") {
+		return strings.TrimSpace(auth[7:])
+	}
+
+	return ""
+}
+
+// authorized accepts the token as a bearer token or, for browsers, as the password of Basic
+// authentication with any user name. Without a token every request is rejected.
+func authorized(r *http.Request, token string) bool {
+	if token == "" {
+		return false
+	}
+
+	given := bearerToken(r)
+	if given == "" {
+		_, given, _ = r.BasicAuth()
+	}
+
+	return subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
+
+// fingerprint returns a short, non-reversible identifier of an API key so that keys never
+// appear in reports or metrics.
+func fingerprint(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// consumerName returns the consumer name declared in the request. Names are truncated and
+// limited to letters, digits, '.', '_' and '-', other characters are replaced by '_'.
+func consumerName(r *http.Request) string {
+	name := r.Header.Get(ConsumerHeader)
+	if len(name) > maxConsumerNameLength {
+		name = name[:maxConsumerNameLength]
+	}
+
+	return strings.Map(func(c rune) rune {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '.', c == '_', c == '-':
+			return c
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+// remoteIP returns the IP address the request was received from.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// forwardedIP returns the rightmost address of the X-Forwarded-For headers that is not a
+// trusted proxy. The entries left of it may be forged by the client, the entries right of it
+// were added by the trusted proxies. It returns the given remote IP if the request was not
+// received from a trusted proxy, as the headers may then be forged, if every entry is trusted,
+// or if the entry is malformed.
+func forwardedIP(r *http.Request, remote string, trustedProxies []*net.IPNet) string {
+	if ip := net.ParseIP(remote); ip == nil || !containsIP(trustedProxies, ip) {
+		return remote
+	}
+
+	var entries []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		entries = append(entries, strings.Split(header, ",")...)
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := strings.TrimSpace(entries[i])
+		if entry == "" {
+			continue
+		}
+		ip := net.ParseIP(entry)
+		if ip == nil {
+			// A malformed entry is not a trusted proxy, so nothing left of it can be trusted,
+			// and it cannot identify a consumer either.
+			return remote
+		}
+		if !containsIP(trustedProxies, ip) {
+			return ip.String()
+		}
+	}
+
+	return remote
+}
+
+// parseNetworks parses addresses and CIDR ranges, e.g. "10.0.0.0/8" or "192.168.1.1".
+func parseNetworks(values []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(values))
+	for _, value := range values {
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("%q is not an IP address", value)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
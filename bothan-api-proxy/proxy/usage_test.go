@@ -0,0 +1,169 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestUsageTracker(t *testing.T) {
+	tracker, err := NewUsageTracker(UsageConfig{}, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withKey := httptest.NewRequest(http.MethodGet, "/prices/a", nil)
+	withKey.Header.Set("X-API-Key", "key")
+	tracker.Record(tracker.Identify(withKey), http.StatusOK)
+	tracker.Record(tracker.Identify(withKey), http.StatusOK)
+
+	withBearer := httptest.NewRequest(http.MethodGet, "/missing", nil)
+	withBearer.Header.Set("Authorization", "Bearer key")
+	tracker.Record(tracker.Identify(withBearer), http.StatusNotFound)
+
+	anonymous := httptest.NewRequest(http.MethodGet, "/prices/a", nil)
+	anonymous.RemoteAddr = "10.0.0.1:1234"
+	tracker.Record(tracker.Identify(anonymous), http.StatusOK)
+
+	report := tracker.Report()
+	if len(report.Consumers) != 2 {
+		t.Fatalf("expected 2 consumers, got %d", len(report.Consumers))
+	}
+
+	keyUsage := report.Consumers[0]
+	if keyUsage.Kind != "api_key" || keyUsage.ID != fingerprint("key") {
+		t.Errorf("unexpected consumer %+v", keyUsage.Consumer)
+	}
+	if keyUsage.Requests != 3 || keyUsage.Errors != 1 {
+		t.Errorf("expected 3 requests and 1 error, got %d and %d", keyUsage.Requests, keyUsage.Errors)
+	}
+
+	ipUsage := report.Consumers[1]
+	if ipUsage.Kind != "ip" || ipUsage.ID != "10.0.0.1" || ipUsage.Requests != 1 {
+		t.Errorf("unexpected usage %+v", ipUsage)
+	}
+}
+
+func TestUsageEndpointRequiresAdminToken(t *testing.T) {
+	tracker, err := NewUsageTracker(UsageConfig{AdminToken: "secret"}, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	tracker.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/usage", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/usage", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	tracker.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+}
+
+func TestIdentifyConsumerName(t *testing.T) {
+	tracker, err := NewUsageTracker(UsageConfig{}, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/prices/a", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	r.Header.Set(ConsumerHeader, "band-chain/validator 1")
+
+	consumer := tracker.Identify(r)
+	expected := Consumer{Kind: "ip", ID: "10.0.0.1", Name: "band-chain_validator_1"}
+	if consumer != expected {
+		t.Errorf("expected %+v, got %+v", expected, consumer)
+	}
+}
+
+func TestUsageTrackerBoundsConsumers(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	tracker, err := NewUsageTracker(UsageConfig{MaxConsumers: 2, ConsumerNames: []string{"feeder"}}, registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tracker.Record(Consumer{Kind: "api_key", ID: "a", Name: "feeder"}, http.StatusOK)
+	tracker.Record(Consumer{Kind: "ip", ID: "10.0.0.1", Name: "band-chain_validator_1"}, http.StatusOK)
+	tracker.Record(Consumer{Kind: "ip", ID: "10.0.0.2"}, http.StatusOK)
+	tracker.Record(Consumer{Kind: "ip", ID: "10.0.0.2"}, http.StatusOK)
+
+	report := tracker.Report()
+	if len(report.Consumers) != 2 || report.Consumers[0].ID != "10.0.0.2" || report.Consumers[1].ID != "10.0.0.1" {
+		t.Fatalf("expected the least recently seen consumer to be forgotten, got %+v", report.Consumers)
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var series []string
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			series = append(series, labels["kind"]+"/"+labels["consumer"]+"/"+labels["name"]+"="+strconv.FormatFloat(metric.GetCounter().GetValue(), 'f', -1, 64))
+		}
+	}
+	sort.Strings(series)
+	// The evicted API key has no series left, IP addresses and names outside the allow-list
+	// are not labels.
+	expected := []string{"ip//=2", "ip//other=1"}
+	if !slices.Equal(series, expected) {
+		t.Errorf("expected series %v, got %v", expected, series)
+	}
+}
+
+func TestIdentifyForwardedFor(t *testing.T) {
+	tracker, err := NewUsageTracker(UsageConfig{
+		TrustForwardedFor: true,
+		TrustedProxies:    []string{"10.0.0.0/8", "192.168.1.1"},
+	}, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		remote    string
+		forwarded []string
+		expected  string
+	}{
+		{"no header", "10.1.1.1", nil, "10.1.1.1"},
+		{"single entry", "10.1.1.1", []string{"203.0.113.7"}, "203.0.113.7"},
+		{"forged entries on the left", "10.1.1.1", []string{"1.2.3.4, 203.0.113.7"}, "203.0.113.7"},
+		{"trusted proxies on the right", "10.1.1.1", []string{"1.2.3.4, 203.0.113.7, 10.2.3.4", "192.168.1.1"}, "203.0.113.7"},
+		{"only trusted proxies", "10.1.1.1", []string{"10.2.3.4, 192.168.1.1"}, "10.1.1.1"},
+		{"malformed entry", "10.1.1.1", []string{"1.2.3.4, unknown, 10.2.3.4"}, "10.1.1.1"},
+		{"untrusted remote", "203.0.113.9", []string{"1.2.3.4"}, "203.0.113.9"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/prices/a", nil)
+			r.RemoteAddr = test.remote + ":1234"
+			for _, forwarded := range test.forwarded {
+				r.Header.Add("X-Forwarded-For", forwarded)
+			}
+			if consumer := tracker.Identify(r); consumer.ID != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, consumer.ID)
+			}
+		})
+	}
+
+	if _, err := NewUsageTracker(UsageConfig{TrustedProxies: []string{"10.0.0.0/33"}}, prometheus.NewRegistry()); err == nil {
+		t.Error("expected an error for an invalid trusted proxy")
+	}
+}
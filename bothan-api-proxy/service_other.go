@@ -0,0 +1,11 @@
+//go:build !windows
+
+package main
+
+import "github.com/bandprotocol/bothan/bothan-api-proxy/proxy"
+
+// runService reports false as services are only supported on Windows. On Linux, the proxy
+// integrates with systemd through sd_notify instead.
+func runService(*proxy.Server) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,69 @@
+//go:build windows
+
+package main
+
+import (
+	"context"
+
+	"golang.org/x/sys/windows/svc"
+	"google.golang.org/grpc/grpclog"
+
+	"github.com/bandprotocol/bothan/bothan-api-proxy/proxy"
+)
+
+const serviceName = "bothan-api-proxy"
+
+// runService runs the server under the Windows service control manager if the process was
+// started as a service. It reports false if the process runs interactively.
+func runService(server *proxy.Server) (bool, error) {
+	isService, err := svc.IsWindowsService()
+	if err != nil || !isService {
+		return false, err
+	}
+
+	return true, svc.Run(serviceName, &service{server: server})
+}
+
+// service adapts the server to the service control handler of Windows.
+type service struct {
+	server *proxy.Server
+}
+
+func (s *service) Execute(_ []string, requests <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	changes <- svc.Status{State: svc.StartPending}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.server.Run(ctx)
+	}()
+
+	running := svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+	changes <- running
+
+	for {
+		select {
+		case err := <-errCh:
+			if err != nil {
+				grpclog.Error(err)
+				return false, 1
+			}
+			return false, 0
+		case req := <-requests:
+			switch req.Cmd {
+			case svc.Interrogate:
+				changes <- req.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				changes <- svc.Status{State: svc.StopPending}
+				cancel()
+				if err := <-errCh; err != nil {
+					grpclog.Error(err)
+					return false, 1
+				}
+				return false, 0
+			}
+		}
+	}
+}
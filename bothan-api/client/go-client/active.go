@@ -0,0 +1,35 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// SignalActivator is implemented by the clients that can make the server keep signals up to
+// date, see ActiveSignalIDs of the clients for the signals that are.
+type SignalActivator interface {
+	// SetActiveSignalIds makes the server keep the given signals up to date. The server
+	// subscribes the sources of a signal the first time its price is queried, so the signals
+	// are activated with a price query. Signals stay active until the server restarts, as it
+	// cannot deactivate them: leaving a signal out does not deactivate it. The error wraps
+	// ErrSignalUnsupported if no source supports some of the signals, which are not active.
+	SetActiveSignalIds(signalIDs []string) error
+}
+
+// checkActivated returns an error wrapping ErrSignalUnsupported with the signals of the
+// prices that no source supports, which the server does not keep up to date.
+func checkActivated(prices []*proto.PriceData) error {
+	var unsupported []string
+	for _, price := range prices {
+		if price.PriceStatus == proto.PriceStatus_PRICE_STATUS_UNSUPPORTED {
+			unsupported = append(unsupported, price.SignalId)
+		}
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("%w: %s", ErrSignalUnsupported, strings.Join(unsupported, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package client
+
+import (
+	"google.golang.org/grpc"
+
+	clientv2 "github.com/bandprotocol/bothan/bothan-api/client/go-client/v2"
+)
+
+// TokenSource returns the bearer token to authenticate a call with, see the type of the same
+// name in v2.
+type TokenSource = clientv2.TokenSource
+
+// WithToken returns a dial option for NewGRPC that authenticates every call with the given
+// bearer token. Tokens are only sent over TLS connections, see WithTLS.
+func WithToken(token string) grpc.DialOption {
+	return WithTokenSource(clientv2.StaticToken(token))
+}
+
+// WithTokenSource returns a dial option for NewGRPC that authenticates every call with a
+// bearer token of the given source. Tokens are only sent over TLS connections, see WithTLS.
+func WithTokenSource(source TokenSource) grpc.DialOption {
+	return grpc.WithPerRPCCredentials(clientv2.TokenCredentials(source))
+}
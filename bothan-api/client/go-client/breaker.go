@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+	clientv2 "github.com/bandprotocol/bothan/bothan-api/client/go-client/v2"
+)
+
+var (
+	_ Client       = &CircuitBreakerClient{}
+	_ PriceMapper  = &CircuitBreakerClient{}
+	_ SignalLister = &CircuitBreakerClient{}
+)
+
+// ErrCircuitOpen is the error of calls rejected by an open circuit breaker.
+var ErrCircuitOpen = clientv2.ErrCircuitOpen
+
+// CircuitBreaker opens after a number of consecutive failed calls, see the type of the same
+// name in v2.
+type CircuitBreaker = clientv2.CircuitBreaker
+
+// NewCircuitBreaker creates a circuit breaker that opens after threshold consecutive failures
+// for the given cool-down.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return clientv2.NewCircuitBreaker(threshold, cooldown)
+}
+
+// CircuitBreakerClient decorates a Client with a circuit breaker, so that calls to a dead
+// server fail fast with ErrCircuitOpen instead of piling up timeouts.
+type CircuitBreakerClient struct {
+	client  Client
+	breaker *CircuitBreaker
+}
+
+// NewCircuitBreakerClient wraps the given client with the given circuit breaker, which may be
+// shared with other clients of the same server.
+func NewCircuitBreakerClient(c Client, breaker *CircuitBreaker) *CircuitBreakerClient {
+	return &CircuitBreakerClient{client: c, breaker: breaker}
+}
+
+func (c *CircuitBreakerClient) QueryPrices(signalIds []string) ([]*proto.PriceData, error) {
+	if err := c.breaker.Allow(); err != nil {
+		return nil, err
+	}
+
+	prices, err := c.client.QueryPrices(signalIds)
+	c.breaker.Record(err)
+	return prices, err
+}
+
+func (c *CircuitBreakerClient) GetPriceMap(ctx context.Context, signalIds []string) (map[string]PriceResult, error) {
+	if err := c.breaker.Allow(); err != nil {
+		return nil, err
+	}
+
+	results, err := GetPriceMap(ctx, c.client, signalIds)
+	c.breaker.Record(err)
+	return results, err
+}
+
+// ListSignals lists the signals with the wrapped client, which must implement SignalLister.
+func (c *CircuitBreakerClient) ListSignals(ctx context.Context) ([]*proto.SignalInfo, error) {
+	lister, ok := c.client.(SignalLister)
+	if !ok {
+		return nil, errors.New("client cannot list signals")
+	}
+	if err := c.breaker.Allow(); err != nil {
+		return nil, err
+	}
+
+	signals, err := lister.ListSignals(ctx)
+	c.breaker.Record(err)
+	return signals, err
+}
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCircuitBreakerClient(t *testing.T) {
+	stub := &stubClient{err: status.Error(codes.DeadlineExceeded, "context deadline exceeded")}
+	c := NewCircuitBreakerClient(stub, NewCircuitBreaker(2, time.Minute))
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetPriceMap(context.Background(), nil); status.Code(err) != codes.DeadlineExceeded {
+			t.Fatalf("call %d: expected the error of the server, got %v", i, err)
+		}
+	}
+	if _, err := c.QueryPrices(nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected the circuit to open after 2 timeouts, got %v", err)
+	}
+	if _, err := c.ListSignals(context.Background()); err == nil {
+		t.Error("expected an error for a client that cannot list signals")
+	}
+}
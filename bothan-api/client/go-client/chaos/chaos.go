@@ -0,0 +1,194 @@
+// Package chaos injects faults into Bothan price queries so that integrators can test how
+// their applications handle latency, errors, stale prices and partial responses. It must not
+// be used in production.
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// MaxLatency is the maximum latency that can be injected, so that a fault specification
+// cannot hold calls, or the connections of a proxy, for arbitrarily long.
+const MaxLatency = 30 * time.Second
+
+// Config defines the faults to inject. Rates are probabilities between 0 and 1.
+type Config struct {
+	// Latency is added to every call, up to MaxLatency.
+	Latency time.Duration
+	// ErrorRate is the probability of a call failing with ErrorCode.
+	ErrorRate float64
+	// ErrorCode is the code of injected errors, codes.Unavailable if unset.
+	ErrorCode codes.Code
+	// StaleRate is the probability of a price being replaced by the previous price seen for
+	// the same signal.
+	StaleRate float64
+	// PartialRate is the probability of a price being dropped from the response.
+	PartialRate float64
+}
+
+// Parse parses a fault specification such as
+// "latency=200ms,error=0.1,code=UNAVAILABLE,stale=0.2,partial=0.5".
+func Parse(spec string) (Config, error) {
+	var config Config
+	for _, field := range strings.Split(spec, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return Config{}, fmt.Errorf("chaos: invalid field %q", field)
+		}
+
+		var err error
+		switch strings.TrimSpace(key) {
+		case "latency":
+			config.Latency, err = parseLatency(value)
+		case "error":
+			config.ErrorRate, err = parseRate(value)
+		case "code":
+			config.ErrorCode, err = parseCode(value)
+		case "stale":
+			config.StaleRate, err = parseRate(value)
+		case "partial":
+			config.PartialRate, err = parseRate(value)
+		default:
+			err = fmt.Errorf("unknown fault %q", key)
+		}
+		if err != nil {
+			return Config{}, fmt.Errorf("chaos: invalid field %q: %w", field, err)
+		}
+	}
+
+	return config, nil
+}
+
+func parseLatency(value string) (time.Duration, error) {
+	latency, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if latency < 0 || latency > MaxLatency {
+		return 0, fmt.Errorf("latency %v is not between 0 and %v", latency, MaxLatency)
+	}
+
+	return latency, nil
+}
+
+func parseRate(value string) (float64, error) {
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	if rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("rate %v is not between 0 and 1", rate)
+	}
+
+	return rate, nil
+}
+
+func parseCode(value string) (codes.Code, error) {
+	var code codes.Code
+	if err := code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(value)))); err != nil {
+		return 0, err
+	}
+
+	return code, nil
+}
+
+// Injector applies the faults of a Config. It remembers the last price of every signal to
+// serve stale prices, so one Injector should be shared by all calls of a client.
+type Injector struct {
+	config  Config
+	history *history
+}
+
+type history struct {
+	mu   sync.Mutex
+	last map[string]*proto.PriceData
+}
+
+// NewInjector creates a new Injector for the given config.
+func NewInjector(config Config) *Injector {
+	return newInjector(config, &history{last: make(map[string]*proto.PriceData)})
+}
+
+func newInjector(config Config, history *history) *Injector {
+	if config.ErrorCode == codes.OK {
+		config.ErrorCode = codes.Unavailable
+	}
+	config.Latency = min(config.Latency, MaxLatency)
+
+	return &Injector{config, history}
+}
+
+// With returns an Injector applying the given config that shares the price history of i.
+func (i *Injector) With(config Config) *Injector {
+	return newInjector(config, i.history)
+}
+
+// Delay waits for the configured latency. It returns the error of the context if the context
+// is done first.
+func (i *Injector) Delay(ctx context.Context) error {
+	if i.config.Latency <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(i.config.Latency)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Err returns an injected error according to the configured error rate, or nil.
+func (i *Injector) Err() error {
+	if !happens(i.config.ErrorRate) {
+		return nil
+	}
+
+	return status.Error(i.config.ErrorCode, "chaos: injected fault")
+}
+
+// Apply drops prices and replaces them with stale ones according to the configured rates.
+func (i *Injector) Apply(prices []*proto.PriceData) []*proto.PriceData {
+	i.history.mu.Lock()
+	defer i.history.mu.Unlock()
+
+	result := make([]*proto.PriceData, 0, len(prices))
+	for _, price := range prices {
+		if happens(i.config.PartialRate) {
+			continue
+		}
+
+		if last, ok := i.history.last[price.SignalId]; ok && happens(i.config.StaleRate) {
+			result = append(result, last)
+			continue
+		}
+
+		i.history.last[price.SignalId] = price
+		result = append(result, price)
+	}
+
+	return result
+}
+
+func happens(rate float64) bool {
+	return rate > 0 && rand.Float64() < rate
+}
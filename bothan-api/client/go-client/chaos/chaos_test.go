@@ -0,0 +1,89 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestParse(t *testing.T) {
+	config, err := Parse("latency=200ms, error=0.5,code=resource_exhausted,stale=1,partial=0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Config{
+		Latency:     200 * time.Millisecond,
+		ErrorRate:   0.5,
+		ErrorCode:   codes.ResourceExhausted,
+		StaleRate:   1,
+		PartialRate: 0,
+	}
+	if config != expected {
+		t.Errorf("expected %+v, got %+v", expected, config)
+	}
+
+	for _, spec := range []string{"latency", "error=2", "code=NOPE", "unknown=1", "latency=fast"} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Parse(%q): expected an error", spec)
+		}
+	}
+}
+
+func TestInjector(t *testing.T) {
+	price := func(id, value string) *proto.PriceData {
+		return &proto.PriceData{SignalId: id, Price: value, PriceStatus: proto.PriceStatus_PRICE_STATUS_AVAILABLE}
+	}
+
+	stale := NewInjector(Config{StaleRate: 1})
+	stale.Apply([]*proto.PriceData{price("a", "1")})
+	prices := stale.Apply([]*proto.PriceData{price("a", "2"), price("b", "3")})
+	if len(prices) != 2 || prices[0].Price != "1" || prices[1].Price != "3" {
+		t.Errorf("expected the previous price of a and the first price of b, got %v", prices)
+	}
+
+	partial := NewInjector(Config{PartialRate: 1})
+	if prices := partial.Apply([]*proto.PriceData{price("a", "1")}); len(prices) != 0 {
+		t.Errorf("expected every price to be dropped, got %v", prices)
+	}
+
+	failing := NewInjector(Config{ErrorRate: 1})
+	if code := status.Code(failing.Err()); code != codes.Unavailable {
+		t.Errorf("expected code %v, got %v", codes.Unavailable, code)
+	}
+	if err := NewInjector(Config{}).Err(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestDelay(t *testing.T) {
+	if _, err := Parse("latency=1h"); err == nil {
+		t.Error("expected an error for a latency above MaxLatency")
+	}
+	if _, err := Parse("latency=-1s"); err == nil {
+		t.Error("expected an error for a negative latency")
+	}
+	if injector := NewInjector(Config{Latency: time.Hour}); injector.config.Latency != MaxLatency {
+		t.Errorf("expected the latency to be capped at %v, got %v", MaxLatency, injector.config.Latency)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := NewInjector(Config{Latency: MaxLatency}).Delay(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the context error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the delay to stop with the context, took %v", elapsed)
+	}
+
+	if err := NewInjector(Config{Latency: time.Millisecond}).Delay(context.Background()); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
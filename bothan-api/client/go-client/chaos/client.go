@@ -0,0 +1,82 @@
+package chaos
+
+import (
+	"context"
+	"sync"
+
+	client "github.com/bandprotocol/bothan/bothan-api/client/go-client"
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+var (
+	_ client.Client      = &Client{}
+	_ client.PriceMapper = &Client{}
+)
+
+// Client decorates a client.Client with fault injection.
+type Client struct {
+	client   client.Client
+	injector *Injector
+
+	mu sync.Mutex
+	// last is the last result seen for every signal, to serve stale results of GetPriceMap.
+	last map[string]client.PriceResult
+}
+
+// NewClient wraps the given client so that its calls are subject to the faults of config.
+func NewClient(c client.Client, config Config) *Client {
+	return &Client{client: c, injector: NewInjector(config), last: make(map[string]client.PriceResult)}
+}
+
+func (c *Client) QueryPrices(signalIds []string) ([]*proto.PriceData, error) {
+	if err := c.inject(context.Background()); err != nil {
+		return nil, err
+	}
+
+	prices, err := c.client.QueryPrices(signalIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.injector.Apply(prices), nil
+}
+
+// GetPriceMap applies the faults to GetPriceMap of the wrapped client. The context also
+// cancels the injected latency. Dropped prices are reported with ErrPriceMissing, like the
+// prices missing from a response.
+func (c *Client) GetPriceMap(ctx context.Context, signalIds []string) (map[string]client.PriceResult, error) {
+	if err := c.inject(ctx); err != nil {
+		return nil, err
+	}
+
+	results, err := client.GetPriceMap(ctx, c.client, signalIds)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for signalID, result := range results {
+		if happens(c.injector.config.PartialRate) {
+			results[signalID] = client.PriceResult{Timestamp: result.Timestamp, Err: client.ErrPriceMissing}
+			continue
+		}
+		if last, ok := c.last[signalID]; ok && happens(c.injector.config.StaleRate) {
+			results[signalID] = last
+			continue
+		}
+		c.last[signalID] = result
+	}
+
+	return results, nil
+}
+
+// inject waits for the injected latency and returns the injected error, if any.
+func (c *Client) inject(ctx context.Context) error {
+	if err := c.injector.Delay(ctx); err != nil {
+		return err
+	}
+
+	return c.injector.Err()
+}
@@ -0,0 +1,49 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	client "github.com/bandprotocol/bothan/bothan-api/client/go-client"
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/clienttest"
+)
+
+func TestClientGetPriceMap(t *testing.T) {
+	wrapped := clienttest.New()
+	wrapped.SetPrice("btc", "60000")
+
+	stale := NewClient(wrapped, Config{StaleRate: 1})
+	if _, err := stale.GetPriceMap(context.Background(), []string{"btc"}); err != nil {
+		t.Fatal(err)
+	}
+	wrapped.SetPrice("btc", "61000")
+	results, err := stale.GetPriceMap(context.Background(), []string{"btc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results["btc"].Price != "60000" {
+		t.Errorf("expected the previous price, got %v", results["btc"])
+	}
+
+	// The calls go to GetPriceMap of the wrapped client, so its own context handling applies.
+	for _, call := range wrapped.Calls() {
+		if call.Method != "GetPriceMap" {
+			t.Errorf("expected only GetPriceMap calls, got %v", call)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := stale.GetPriceMap(ctx, []string{"btc"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the context error of the wrapped client, got %v", err)
+	}
+
+	partial := NewClient(wrapped, Config{PartialRate: 1})
+	results, err = partial.GetPriceMap(context.Background(), []string{"btc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(results["btc"].Err, client.ErrPriceMissing) {
+		t.Errorf("expected the price to be dropped, got %v", results["btc"])
+	}
+}
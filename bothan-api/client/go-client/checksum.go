@@ -0,0 +1,45 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// ContentDigestHeader is the response header of RFC 9530 in which the proxy sends the SHA-256
+// hash of the response body, see WithRestChecksumVerification.
+const ContentDigestHeader = "Content-Digest"
+
+// ErrChecksumMismatch is returned, wrapped, by a RestClient with WithRestChecksumVerification
+// when a response body does not match its Content-Digest header on every proxy.
+var ErrChecksumMismatch = errors.New("response body does not match its checksum")
+
+// verifyContentDigest checks the body against the sha-256 digest of the Content-Digest header,
+// e.g. "sha-256=:47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=:". Bodies without a header or a
+// sha-256 digest, like those of proxies that predate it, are accepted.
+func verifyContentDigest(header string, body []byte) error {
+	for _, member := range strings.Split(header, ",") {
+		algorithm, value, ok := strings.Cut(strings.TrimSpace(member), "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(algorithm), "sha-256") {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) < 2 || value[0] != ':' || value[len(value)-1] != ':' {
+			return ErrChecksumMismatch
+		}
+		digest, err := base64.StdEncoding.DecodeString(value[1 : len(value)-1])
+		if err != nil {
+			return ErrChecksumMismatch
+		}
+		sum := sha256.Sum256(body)
+		if !bytes.Equal(digest, sum[:]) {
+			return ErrChecksumMismatch
+		}
+		return nil
+	}
+
+	return nil
+}
@@ -1,9 +1,20 @@
 package client
 
 import (
+	"context"
+
 	bothanproto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
 )
 
 type Client interface {
 	QueryPrices(signalIDs []string) ([]*bothanproto.PriceData, error)
 }
+
+// PriceMapper is implemented by the clients that can query prices with a context and return
+// them keyed by signal ID. Use the GetPriceMap function to query any Client this way.
+type PriceMapper interface {
+	// GetPriceMap queries the prices of the given signals and returns them keyed by signal ID.
+	// The returned error is only set if the query itself failed, errors of individual signals
+	// are reported in their PriceResult.
+	GetPriceMap(ctx context.Context, signalIDs []string) (map[string]PriceResult, error)
+}
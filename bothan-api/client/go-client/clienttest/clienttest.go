@@ -0,0 +1,157 @@
+// Package clienttest provides an in-memory client.Client, so that applications can unit-test
+// their use of Bothan without a running server. Prices are set by the test, errors can be
+// injected, and every call is recorded.
+package clienttest
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	protobuf "google.golang.org/protobuf/proto"
+
+	client "github.com/bandprotocol/bothan/bothan-api/client/go-client"
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+var (
+	_ client.Client      = &Client{}
+	_ client.PriceMapper = &Client{}
+)
+
+// Call records a call of the client.
+type Call struct {
+	// Method is the name of the called method, e.g. "QueryPrices".
+	Method string
+	// SignalIDs are the signal IDs the method was called with.
+	SignalIDs []string
+}
+
+// Client is a client.Client that answers queries from the prices set on it. Like a server,
+// it reports signals without a price as unsupported. It is safe for concurrent use.
+type Client struct {
+	mu     sync.Mutex
+	prices map[string]*proto.PriceData
+	err    error
+	next   []error
+	calls  []Call
+}
+
+// New creates a client without prices.
+func New() *Client {
+	return &Client{prices: make(map[string]*proto.PriceData)}
+}
+
+// SetPrice makes the price of the signal available with the given decimal value.
+func (c *Client) SetPrice(signalID, price string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.prices[signalID] = &proto.PriceData{
+		SignalId:    signalID,
+		Price:       price,
+		PriceStatus: proto.PriceStatus_PRICE_STATUS_AVAILABLE,
+		Timestamp:   uint64(time.Now().Unix()),
+	}
+}
+
+// SetStatus sets the status of the signal without a price, e.g. PRICE_STATUS_UNAVAILABLE for
+// a signal whose sources have not reported yet.
+func (c *Client) SetStatus(signalID string, status proto.PriceStatus) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.prices[signalID] = &proto.PriceData{SignalId: signalID, PriceStatus: status}
+}
+
+// Remove removes the price of the signal, which is then reported as unsupported.
+func (c *Client) Remove(signalID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.prices, signalID)
+}
+
+// SetError makes every following call fail with err, until it is called again with nil.
+func (c *Client) SetError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.err = err
+}
+
+// FailNext makes the next calls fail with the given errors, one call per error, before the
+// error of SetError applies again, e.g. to test retries.
+func (c *Client) FailNext(errs ...error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.next = append(c.next, errs...)
+}
+
+// Calls returns the calls made so far, in order, including the failed ones.
+func (c *Client) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.calls)
+}
+
+// ResetCalls forgets the calls made so far.
+func (c *Client) ResetCalls() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = nil
+}
+
+func (c *Client) QueryPrices(signalIDs []string) ([]*proto.PriceData, error) {
+	return c.query("QueryPrices", signalIDs)
+}
+
+// GetPriceMap queries the prices like QueryPrices, after checking the context.
+func (c *Client) GetPriceMap(ctx context.Context, signalIDs []string) (map[string]client.PriceResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	prices, err := c.query("GetPriceMap", signalIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.NewPriceMap(signalIDs, prices, time.Now()), nil
+}
+
+// query records the call and returns the prices of the signals, or the injected error.
+func (c *Client) query(method string, signalIDs []string) ([]*proto.PriceData, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = append(c.calls, Call{Method: method, SignalIDs: slices.Clone(signalIDs)})
+	if len(c.next) > 0 {
+		err := c.next[0]
+		c.next = c.next[1:]
+		return nil, err
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	signalIDs, err := client.NormalizeSignalIDs(signalIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make([]*proto.PriceData, 0, len(signalIDs))
+	for _, signalID := range signalIDs {
+		price, ok := c.prices[signalID]
+		if !ok {
+			price = &proto.PriceData{SignalId: signalID, PriceStatus: proto.PriceStatus_PRICE_STATUS_UNSUPPORTED}
+		}
+		prices = append(prices, protobuf.Clone(price).(*proto.PriceData))
+	}
+
+	return prices, nil
+}
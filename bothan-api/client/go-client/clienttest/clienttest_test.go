@@ -0,0 +1,93 @@
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	client "github.com/bandprotocol/bothan/bothan-api/client/go-client"
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestClient(t *testing.T) {
+	c := New()
+	c.SetPrice("btc", "67012.125")
+	c.SetStatus("eth", proto.PriceStatus_PRICE_STATUS_UNAVAILABLE)
+
+	prices, err := c.QueryPrices([]string{"btc", "eth", "doge", "btc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 3 {
+		t.Fatalf("expected 3 prices, got %v", prices)
+	}
+	if prices[0].Price != "67012.125" || prices[0].PriceStatus != proto.PriceStatus_PRICE_STATUS_AVAILABLE || prices[0].Timestamp == 0 {
+		t.Errorf("unexpected price of btc %v", prices[0])
+	}
+	if prices[1].PriceStatus != proto.PriceStatus_PRICE_STATUS_UNAVAILABLE || prices[2].PriceStatus != proto.PriceStatus_PRICE_STATUS_UNSUPPORTED {
+		t.Errorf("unexpected statuses %v", prices[1:])
+	}
+
+	// The returned prices are copies.
+	prices[0].Price = "0"
+	results, err := c.GetPriceMap(context.Background(), []string{"btc", "eth"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results["btc"].Price != "67012.125" || !errors.Is(results["eth"].Err, client.ErrPriceUnavailable) {
+		t.Errorf("unexpected results %v", results)
+	}
+
+	c.Remove("btc")
+	if results, _ := c.GetPriceMap(context.Background(), []string{"btc"}); !errors.Is(results["btc"].Err, client.ErrSignalUnsupported) {
+		t.Errorf("expected btc to be unsupported, got %v", results["btc"])
+	}
+
+	expected := []Call{
+		{Method: "QueryPrices", SignalIDs: []string{"btc", "eth", "doge", "btc"}},
+		{Method: "GetPriceMap", SignalIDs: []string{"btc", "eth"}},
+		{Method: "GetPriceMap", SignalIDs: []string{"btc"}},
+	}
+	if calls := c.Calls(); !slices.EqualFunc(calls, expected, func(a, b Call) bool {
+		return a.Method == b.Method && slices.Equal(a.SignalIDs, b.SignalIDs)
+	}) {
+		t.Errorf("expected calls %v, got %v", expected, calls)
+	}
+	c.ResetCalls()
+	if calls := c.Calls(); len(calls) != 0 {
+		t.Errorf("expected no calls after ResetCalls, got %v", calls)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	c := New()
+	errDown := errors.New("down")
+	errTimeout := errors.New("timeout")
+
+	c.SetError(errDown)
+	c.FailNext(errTimeout)
+	if _, err := c.QueryPrices([]string{"btc"}); !errors.Is(err, errTimeout) {
+		t.Errorf("expected the error of FailNext first, got %v", err)
+	}
+	if _, err := c.QueryPrices([]string{"btc"}); !errors.Is(err, errDown) {
+		t.Errorf("expected the error of SetError, got %v", err)
+	}
+	c.SetError(nil)
+	if _, err := c.QueryPrices([]string{"btc"}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if _, err := c.QueryPrices([]string{""}); !errors.Is(err, client.ErrEmptySignalID) {
+		t.Errorf("expected ErrEmptySignalID, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.GetPriceMap(ctx, []string{"btc"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the context error, got %v", err)
+	}
+	if calls := c.Calls(); len(calls) != 4 {
+		t.Errorf("expected the cancelled call not to be recorded, got %v", calls)
+	}
+}
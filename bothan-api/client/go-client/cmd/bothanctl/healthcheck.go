@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	client "github.com/bandprotocol/bothan/bothan-api/client/go-client"
+)
+
+const (
+	defaultEndpoint           = "localhost:50051"
+	defaultHealthcheckTimeout = 5 * time.Second
+)
+
+// runHealthcheck exits non-zero unless the server answers and at least one of the signals has
+// an available price, for use as a Docker HEALTHCHECK or a Kubernetes exec probe. If
+// BOTHANCTL_RELEASES_URL is set, it also warns about outdated and known broken server versions,
+// without failing.
+func runHealthcheck(args []string) error {
+	fs := flag.NewFlagSet("healthcheck", flag.ExitOnError)
+	endpoint := fs.String("endpoint", defaultEndpoint, "address of the server")
+	var flags signalFlags
+	fs.StringVar(&flags.signals, "signals", "", "comma separated signal IDs, all signals of the registry if empty")
+	fs.DurationVar(&flags.timeout, "timeout", defaultHealthcheckTimeout, "timeout of each query")
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return errors.New("unexpected arguments, the server is given with -endpoint")
+	}
+
+	c, err := client.NewGRPC(*endpoint, flags.timeout)
+	if err != nil {
+		return err
+	}
+
+	signalIDs, err := flags.signalIDs(c)
+	if err != nil {
+		return err
+	}
+	if len(signalIDs) == 0 {
+		return errors.New("the registry has no signals")
+	}
+
+	results, err := c.GetPriceMap(context.Background(), signalIDs)
+	if err != nil {
+		return fmt.Errorf("error querying %s: %w", *endpoint, err)
+	}
+
+	available := 0
+	for _, result := range results {
+		if result.Err == nil {
+			available++
+		}
+	}
+	if available == 0 {
+		return fmt.Errorf("none of the %d signals has an available price", len(results))
+	}
+	fmt.Printf("Healthy: %d of %d signals available\n", available, len(results))
+	checkVersion(c, flags.timeout, os.Stderr)
+
+	return nil
+}
@@ -0,0 +1,64 @@
+// Command bothanctl is a command line tool for operating Bothan servers.
+//
+// Usage:
+//
+//	bothanctl snapshot [-signals ids] [-o file] <addr>
+//	bothanctl diff [-signals ids] <addr|file> <addr|file>
+//	bothanctl healthcheck [-endpoint addr] [-signals ids]
+//	bothanctl registry init -pairs BTC-USD,ETH-USD -sources binance,coinbase [-o file]
+//	bothanctl registry show [-endpoint addr] [-file registry.json] [-json]
+//	bothanctl slo -ids ids [-endpoint addr] [-freshness 10s] [-window 1h] [-format json|csv]
+//	bothanctl version [-endpoint addr] [-releases url]
+//	bothanctl replay -log access.log -target url [-speed 2x]
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// command is a subcommand of bothanctl.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+var commands = []command{
+	{"snapshot", "save the prices of a server to a snapshot file", runSnapshot},
+	{"diff", "compare the prices of two servers or snapshot files", runDiff},
+	{"healthcheck", "exit non-zero unless a server has available prices", runHealthcheck},
+	{"registry", "generate a registry, or show the registry a server loaded", runRegistry},
+	{"slo", "report how often signals met a freshness and availability target", runSLO},
+	{"replay", "replay the read traffic of a proxy access log against another proxy", runReplay},
+	{"version", "print the version of a server and check it against the latest release", runVersion},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: bothanctl <command> [flags] [args]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", cmd.name, cmd.summary)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == os.Args[1] {
+			if err := cmd.run(os.Args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	usage()
+	os.Exit(2)
+}
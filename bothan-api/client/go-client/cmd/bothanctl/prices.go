@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	client "github.com/bandprotocol/bothan/bothan-api/client/go-client"
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/prices"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// signalFlags are the flags shared by the commands that query prices.
+type signalFlags struct {
+	signals string
+	timeout time.Duration
+}
+
+func (f *signalFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.signals, "signals", "", "comma separated signal IDs, all signals of the registry if empty")
+	fs.DurationVar(&f.timeout, "timeout", defaultTimeout, "timeout of each query")
+}
+
+// snapshot queries the prices of a server, or loads them if source is a snapshot file.
+func (f *signalFlags) snapshot(source string) (prices.PriceSnapshot, error) {
+	if _, err := os.Stat(source); err == nil {
+		return prices.LoadSnapshot(source)
+	}
+
+	c, err := client.NewGRPC(source, f.timeout)
+	if err != nil {
+		return prices.PriceSnapshot{}, err
+	}
+
+	signalIDs, err := f.signalIDs(c)
+	if err != nil {
+		return prices.PriceSnapshot{}, err
+	}
+
+	data, err := c.QueryPrices(signalIDs)
+	if err != nil {
+		return prices.PriceSnapshot{}, fmt.Errorf("error querying %s: %w", source, err)
+	}
+
+	return prices.NewSnapshot(data, time.Now()), nil
+}
+
+func (f *signalFlags) signalIDs(c *client.GRPC) ([]string, error) {
+	if f.signals != "" {
+		return strings.Split(f.signals, ","), nil
+	}
+
+	signals, err := c.ListSignals(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error listing signals: %w", err)
+	}
+
+	signalIDs := make([]string, 0, len(signals))
+	for _, signal := range signals {
+		signalIDs = append(signalIDs, signal.SignalId)
+	}
+
+	return signalIDs, nil
+}
+
+func runSnapshot(args []string) error {
+	fs := flag.NewFlagSet("snapshot", flag.ExitOnError)
+	var flags signalFlags
+	flags.register(fs)
+	output := fs.String("o", "snapshot.json", "file to save the snapshot to")
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errors.New("expected the address of a server")
+	}
+
+	snapshot, err := flags.snapshot(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if err := snapshot.Save(*output); err != nil {
+		return err
+	}
+	fmt.Printf("Saved %d prices to %s\n", len(snapshot.Prices), *output)
+
+	return nil
+}
+
+func runDiff(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	var flags signalFlags
+	flags.register(fs)
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		return errors.New("expected two server addresses or snapshot files")
+	}
+
+	a, err := flags.snapshot(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	b, err := flags.snapshot(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	diff := prices.Diff(a, b)
+	if diff.Empty() {
+		fmt.Println("No differences")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "SIGNAL\tOLD\tNEW\tDELTA")
+	for _, signalID := range diff.Removed {
+		fmt.Fprintf(w, "%s\t%s\t-\tremoved\n", signalID, a.Prices[signalID])
+	}
+	for _, signalID := range diff.Added {
+		fmt.Fprintf(w, "%s\t-\t%s\tadded\n", signalID, b.Prices[signalID])
+	}
+	for _, change := range diff.Changed {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%+.4f%%\n", change.SignalID, change.Old, change.New, change.PercentDelta)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,327 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	client "github.com/bandprotocol/bothan/bothan-api/client/go-client"
+)
+
+const (
+	// signalPrefix is the prefix of the IDs of the signals generated by registry init.
+	signalPrefix = "crypto_price."
+	// usdtSignalID is the signal that prices quoted in USDT are converted to USD with.
+	usdtSignalID = signalPrefix + "usdtusd"
+	// defaultMinSourceCount is the min_source_count of the median processor, lowered to the
+	// number of sources of signals with fewer.
+	defaultMinSourceCount = 3
+)
+
+// registrySignal is a signal of a Bothan registry, see bothan-api/server/src/registry.rs.
+type registrySignal struct {
+	Prerequisites  []string          `json:"prerequisites"`
+	Sources        []registrySource  `json:"sources"`
+	Processor      registryProcessor `json:"processor"`
+	PostProcessors []json.RawMessage `json:"post_processors"`
+}
+
+type registrySource struct {
+	SourceID string          `json:"source_id"`
+	ID       string          `json:"id"`
+	Routes   []registryRoute `json:"routes"`
+}
+
+type registryRoute struct {
+	SignalID  string `json:"signal_id"`
+	Operation string `json:"operation"`
+}
+
+type registryProcessor struct {
+	Function string         `json:"function"`
+	Params   map[string]int `json:"params"`
+}
+
+// sourceID returns the ID of the pair on a source, and whether the source quotes the pair in
+// USDT instead of USD, so that its price has to be converted with usdtSignalID. It returns
+// false if the source does not list the pair.
+type sourceID func(base, quote string) (id string, viaUSDT bool, ok bool)
+
+// usdtQuoted returns a sourceID of an exchange that lists USD pairs against USDT, formatting
+// the base and quote of the pair with the given function.
+func usdtQuoted(format func(base, quote string) string) sourceID {
+	return func(base, quote string) (string, bool, bool) {
+		switch {
+		case quote != "USD":
+			return format(base, quote), false, true
+		case base == "USDT":
+			return "", false, false
+		default:
+			return format(base, "USDT"), true, true
+		}
+	}
+}
+
+// sourceIDs are the sources whose IDs follow from the pair. CoinGecko and CoinMarketCap use
+// IDs of their own, which have to be looked up and added by hand.
+var sourceIDs = map[string]sourceID{
+	"binance": usdtQuoted(func(base, quote string) string { return strings.ToLower(base + quote) }),
+	"bybit":   usdtQuoted(func(base, quote string) string { return base + quote }),
+	"htx":     usdtQuoted(func(base, quote string) string { return strings.ToLower(base + quote) }),
+	"okx":     usdtQuoted(func(base, quote string) string { return base + "-" + quote }),
+	"coinbase": func(base, quote string) (string, bool, bool) {
+		return base + "-" + quote, false, true
+	},
+	"kraken": func(base, quote string) (string, bool, bool) {
+		return base + "/" + quote, false, true
+	},
+	// CryptoCompare only serves USD prices.
+	"cryptocompare": func(base, quote string) (string, bool, bool) {
+		return base, false, quote == "USD"
+	},
+}
+
+func runRegistry(args []string) error {
+	if len(args) == 0 {
+		return errors.New("expected a registry subcommand: init or show")
+	}
+
+	switch args[0] {
+	case "init":
+		return runRegistryInit(args[1:])
+	case "show":
+		return runRegistryShow(args[1:])
+	default:
+		return fmt.Errorf("unknown registry subcommand %q, expected init or show", args[0])
+	}
+}
+
+func runRegistryInit(args []string) error {
+	fs := flag.NewFlagSet("registry init", flag.ExitOnError)
+	pairs := fs.String("pairs", "", "comma separated pairs, e.g. BTC-USD,ETH-USD")
+	sources := fs.String("sources", "", "comma separated source IDs, e.g. binance,coinbase")
+	output := fs.String("o", "registry.json", "file to write the registry to")
+	_ = fs.Parse(args)
+
+	if *pairs == "" || *sources == "" {
+		return errors.New("both -pairs and -sources are required")
+	}
+
+	registry, err := scaffoldRegistry(strings.Split(*pairs, ","), strings.Split(*sources, ","))
+	if err != nil {
+		return err
+	}
+	if err := validateRegistry(registry); err != nil {
+		return fmt.Errorf("generated an invalid registry: %w", err)
+	}
+
+	b, err := json.MarshalIndent(registry, "", "    ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*output, append(b, '\n'), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %d signals to %s\n", len(registry), *output)
+
+	return nil
+}
+
+// runRegistryShow prints the version and the hash of the registry a server loaded, and with
+// -file, fails unless the server loaded that file.
+func runRegistryShow(args []string) error {
+	fs := flag.NewFlagSet("registry show", flag.ExitOnError)
+	endpoint := fs.String("endpoint", defaultEndpoint, "address of the server")
+	file := fs.String("file", "", "registry file the server is expected to have loaded")
+	printJSON := fs.Bool("json", false, "print the loaded registry as JSON")
+	timeout := fs.Duration("timeout", defaultHealthcheckTimeout, "timeout of the query")
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return errors.New("unexpected arguments, the server is given with -endpoint")
+	}
+
+	c, err := client.NewGRPC(*endpoint, *timeout)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	resp, err := c.Registry(context.Background())
+	if err != nil {
+		return fmt.Errorf("error querying %s: %w", *endpoint, err)
+	}
+
+	if *printJSON {
+		var b bytes.Buffer
+		if err := json.Indent(&b, []byte(resp.Registry), "", "    "); err != nil {
+			return fmt.Errorf("invalid registry from %s: %w", *endpoint, err)
+		}
+		fmt.Println(b.String())
+		return verifyRegistryFile(*file, resp.Hash)
+	}
+
+	var registry map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(resp.Registry), &registry); err != nil {
+		return fmt.Errorf("invalid registry from %s: %w", *endpoint, err)
+	}
+	fmt.Println("Version:", resp.Version)
+	fmt.Println("Hash:   ", resp.Hash)
+	fmt.Println("Signals:", len(registry))
+
+	return verifyRegistryFile(*file, resp.Hash)
+}
+
+// verifyRegistryFile checks that the SHA-256 hash of the file at path is the hash the server
+// reported for its registry. An empty path is not checked.
+func verifyRegistryFile(path, hash string) error {
+	if path == "" {
+		return nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if fileHash := fmt.Sprintf("%x", sha256.Sum256(content)); fileHash != hash {
+		return fmt.Errorf("the server loaded another registry than %s: hash %s, expected %s", path, hash, fileHash)
+	}
+	fmt.Println("The server loaded", path)
+
+	return nil
+}
+
+// scaffoldRegistry returns a registry with a signal per pair, priced by the median of the
+// given sources. If any source quotes a pair in USDT, the registry also gets a USDT signal
+// priced by the sources that quote USDT in USD.
+func scaffoldRegistry(pairs, sources []string) (map[string]registrySignal, error) {
+	for _, source := range sources {
+		if _, ok := sourceIDs[source]; !ok {
+			return nil, fmt.Errorf("unsupported source %q, supported sources are %s", source, supportedSources())
+		}
+	}
+
+	registry := make(map[string]registrySignal)
+	needsUSDT := false
+	for _, pair := range pairs {
+		base, quote, ok := strings.Cut(strings.ToUpper(strings.TrimSpace(pair)), "-")
+		if !ok || base == "" || quote == "" {
+			return nil, fmt.Errorf("invalid pair %q, expected e.g. BTC-USD", pair)
+		}
+
+		signalID, signal, err := scaffoldSignal(base, quote, sources)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", pair, err)
+		}
+		if _, ok := registry[signalID]; ok {
+			return nil, fmt.Errorf("duplicate pair %q", pair)
+		}
+		registry[signalID] = signal
+		needsUSDT = needsUSDT || len(signal.Prerequisites) > 0
+	}
+
+	if _, ok := registry[usdtSignalID]; needsUSDT && !ok {
+		_, signal, err := scaffoldSignal("USDT", "USD", sources)
+		if err != nil {
+			return nil, fmt.Errorf("%s is needed to convert USDT prices: %w", usdtSignalID, err)
+		}
+		registry[usdtSignalID] = signal
+	}
+
+	return registry, nil
+}
+
+func scaffoldSignal(base, quote string, sources []string) (string, registrySignal, error) {
+	signal := registrySignal{Prerequisites: []string{}, PostProcessors: []json.RawMessage{}}
+	for _, source := range sources {
+		id, viaUSDT, ok := sourceIDs[source](base, quote)
+		if !ok {
+			// The pair is priced by the other sources.
+			continue
+		}
+
+		routes := []registryRoute{}
+		if viaUSDT {
+			routes = append(routes, registryRoute{SignalID: usdtSignalID, Operation: "*"})
+			signal.Prerequisites = []string{usdtSignalID}
+		}
+		signal.Sources = append(signal.Sources, registrySource{SourceID: source, ID: id, Routes: routes})
+	}
+
+	if len(signal.Sources) == 0 {
+		return "", registrySignal{}, errors.New("none of the sources supports the pair")
+	}
+	signal.Processor = registryProcessor{
+		Function: "median",
+		Params:   map[string]int{"min_source_count": min(defaultMinSourceCount, len(signal.Sources))},
+	}
+
+	return signalPrefix + strings.ToLower(base+quote), signal, nil
+}
+
+// validateRegistry checks the registry like the Validator of the server does: the routes of
+// every source refer to prerequisites of their signal, and the prerequisites exist and do not
+// form a cycle.
+func validateRegistry(registry map[string]registrySignal) error {
+	for id, signal := range registry {
+		for _, source := range signal.Sources {
+			for _, route := range source.Routes {
+				if !slices.Contains(signal.Prerequisites, route.SignalID) {
+					return fmt.Errorf("%s: route of %s refers to %s, which is not a prerequisite", id, source.SourceID, route.SignalID)
+				}
+			}
+		}
+	}
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int, len(registry))
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch state[id] {
+		case visiting:
+			return fmt.Errorf("%s depends on itself", id)
+		case done:
+			return nil
+		}
+
+		signal, ok := registry[id]
+		if !ok {
+			return fmt.Errorf("unknown prerequisite %s", id)
+		}
+		state[id] = visiting
+		for _, prerequisite := range signal.Prerequisites {
+			if err := visit(prerequisite); err != nil {
+				return err
+			}
+		}
+		state[id] = done
+		return nil
+	}
+	for id := range registry {
+		if err := visit(id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func supportedSources() string {
+	names := make([]string, 0, len(sourceIDs))
+	for name := range sourceIDs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
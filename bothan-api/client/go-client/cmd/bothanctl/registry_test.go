@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScaffoldRegistry(t *testing.T) {
+	registry, err := scaffoldRegistry([]string{"btc-usd", "ETH-BTC"}, []string{"binance", "kraken", "cryptocompare"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := validateRegistry(registry); err != nil {
+		t.Fatal(err)
+	}
+
+	btc, ok := registry["crypto_price.btcusd"]
+	if !ok {
+		t.Fatalf("expected crypto_price.btcusd, got %v", registry)
+	}
+	if len(btc.Prerequisites) != 1 || btc.Prerequisites[0] != usdtSignalID {
+		t.Errorf("expected the USDT conversion as prerequisite, got %v", btc.Prerequisites)
+	}
+	expected := []string{"binance:btcusdt:1", "kraken:BTC/USD:0", "cryptocompare:BTC:0"}
+	for i, source := range btc.Sources {
+		if got := fmt.Sprintf("%s:%s:%d", source.SourceID, source.ID, len(source.Routes)); got != expected[i] {
+			t.Errorf("expected source %s, got %s", expected[i], got)
+		}
+	}
+
+	// CryptoCompare has no BTC quotes, so only two sources are left for ETH-BTC.
+	if eth := registry["crypto_price.ethbtc"]; len(eth.Sources) != 2 || eth.Processor.Params["min_source_count"] != 2 {
+		t.Errorf("unexpected crypto_price.ethbtc %+v", eth)
+	}
+
+	// Binance quotes USDT against nothing, so the conversion is priced by the others.
+	if usdt := registry[usdtSignalID]; len(usdt.Sources) != 2 || len(usdt.Prerequisites) != 0 {
+		t.Errorf("unexpected %s %+v", usdtSignalID, usdt)
+	}
+}
+
+func TestScaffoldRegistryErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		pairs   []string
+		sources []string
+		err     string
+	}{
+		{"unsupported source", []string{"BTC-USD"}, []string{"coingecko"}, "unsupported source"},
+		{"invalid pair", []string{"BTCUSD"}, []string{"kraken"}, "invalid pair"},
+		{"duplicate pair", []string{"BTC-USD", "btc-usd"}, []string{"kraken"}, "duplicate pair"},
+		{"no source for the pair", []string{"ETH-BTC"}, []string{"cryptocompare"}, "none of the sources"},
+		{"no source for USDT", []string{"BTC-USD"}, []string{"binance"}, usdtSignalID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := scaffoldRegistry(tt.pairs, tt.sources)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("expected an error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestValidateRegistry(t *testing.T) {
+	route := []registryRoute{{SignalID: "b", Operation: "*"}}
+	tests := map[string]map[string]registrySignal{
+		"route without prerequisite": {
+			"a": {Sources: []registrySource{{SourceID: "binance", Routes: route}}},
+			"b": {},
+		},
+		"unknown prerequisite": {
+			"a": {Prerequisites: []string{"b"}},
+		},
+		"cycle": {
+			"a": {Prerequisites: []string{"b"}},
+			"b": {Prerequisites: []string{"a"}},
+		},
+	}
+	for name, registry := range tests {
+		if err := validateRegistry(registry); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestVerifyRegistryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.json")
+	if err := os.WriteFile(path, []byte("{}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The output of sha256sum for the file.
+	hash := "ca3d163bab055381827226140568f3bef7eaac187cebd76878e0b63e9e442356"
+	if err := verifyRegistryFile(path, hash); err != nil {
+		t.Errorf("expected the hash to match, got %v", err)
+	}
+	if err := verifyRegistryFile(path, strings.Repeat("0", len(hash))); err == nil {
+		t.Error("expected a mismatching hash to fail")
+	}
+	if err := verifyRegistryFile("", "anything"); err != nil {
+		t.Errorf("expected no check without a file, got %v", err)
+	}
+}
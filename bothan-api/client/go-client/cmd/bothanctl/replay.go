@@ -0,0 +1,290 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+const defaultReplayConcurrency = 64
+
+// accessLogEntry is a line of the access log of bothan-api-proxy, see its [access_log]
+// configuration.
+type accessLogEntry struct {
+	Time       time.Time `json:"time"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Query      string    `json:"query"`
+	Status     int       `json:"status"`
+	DurationMs float64   `json:"duration_ms"`
+}
+
+// runReplay replays the read requests of a proxy access log against another proxy, keeping
+// their relative timing, and compares the status codes and latencies with the captured ones.
+// It fails if any request failed or returned another status than it did when captured.
+func runReplay(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	logPath := fs.String("log", "", "access log of the proxy to replay")
+	target := fs.String("target", "", "base URL of the proxy to replay against, e.g. http://localhost:8081")
+	speed := fs.String("speed", "1x", "speed of the replay relative to the captured traffic, e.g. 2x")
+	concurrency := fs.Int("concurrency", defaultReplayConcurrency, "maximum number of requests in flight")
+	timeout := fs.Duration("timeout", defaultHealthcheckTimeout, "timeout of each request")
+	apiKey := fs.String("api-key", "", "API key sent as a bearer token, if the target requires one")
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return errors.New("unexpected arguments, the log is given with -log and the proxy with -target")
+	}
+	if *logPath == "" || *target == "" {
+		return errors.New("both -log and -target are required")
+	}
+	factor, err := parseSpeed(*speed)
+	if err != nil {
+		return err
+	}
+	if *concurrency <= 0 {
+		return fmt.Errorf("invalid concurrency %d", *concurrency)
+	}
+
+	f, err := os.Open(*logPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	entries, skipped, err := readAccessLog(f)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return errors.New("the access log has no read requests")
+	}
+
+	// An interrupt ends the replay early, and the requests replayed so far are still reported.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	r := replayer{
+		target:      strings.TrimRight(*target, "/"),
+		speed:       factor,
+		concurrency: *concurrency,
+		apiKey:      *apiKey,
+		httpClient:  &http.Client{Timeout: *timeout},
+	}
+	report := r.replay(ctx, entries)
+	report.Skipped = skipped
+	report.write(os.Stdout)
+
+	if report.Errors > 0 || report.Mismatches > 0 {
+		return fmt.Errorf("%d requests failed and %d returned another status than captured", report.Errors, report.Mismatches)
+	}
+	return nil
+}
+
+// parseSpeed parses a speed like "2x", "0.5x" or "2".
+func parseSpeed(s string) (float64, error) {
+	speed, err := strconv.ParseFloat(strings.TrimSuffix(s, "x"), 64)
+	if err != nil || speed <= 0 {
+		return 0, fmt.Errorf("invalid speed %q", s)
+	}
+	return speed, nil
+}
+
+// readAccessLog returns the read requests of an access log in the order they were made, and
+// the number of other requests, which are not replayed.
+func readAccessLog(r io.Reader) ([]accessLogEntry, int, error) {
+	var entries []accessLogEntry
+	skipped := 0
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var entry accessLogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, 0, fmt.Errorf("invalid access log line %d: %w", line, err)
+		}
+		if entry.Method != http.MethodGet && entry.Method != http.MethodHead {
+			skipped++
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+	return entries, skipped, nil
+}
+
+type replayer struct {
+	target      string
+	speed       float64
+	concurrency int
+	apiKey      string
+	httpClient  *http.Client
+}
+
+// replayResult is the outcome of a replayed request.
+type replayResult struct {
+	entry    accessLogEntry
+	status   int
+	duration time.Duration
+	err      error
+}
+
+// replay sends the requests at the times they were captured, relative to the first one and
+// divided by the speed, until all are sent or the context is done.
+func (r replayer) replay(ctx context.Context, entries []accessLogEntry) replayReport {
+	results := make(chan replayResult, len(entries))
+	inFlight := make(chan struct{}, r.concurrency)
+	var wg sync.WaitGroup
+
+	start := time.Now()
+	first := entries[0].Time
+	for _, entry := range entries {
+		delay := time.Duration(float64(entry.Time.Sub(first))/r.speed) - time.Since(start)
+		if delay > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(delay):
+			}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		inFlight <- struct{}{}
+		wg.Add(1)
+		go func(entry accessLogEntry) {
+			defer wg.Done()
+			defer func() { <-inFlight }()
+			results <- r.send(ctx, entry)
+		}(entry)
+	}
+	wg.Wait()
+	close(results)
+
+	report := replayReport{Target: r.target, Speed: r.speed, Duration: time.Since(start)}
+	var captured, replayed []time.Duration
+	for result := range results {
+		report.Requests++
+		captured = append(captured, time.Duration(result.entry.DurationMs*float64(time.Millisecond)))
+		if result.err != nil {
+			report.Errors++
+			continue
+		}
+		replayed = append(replayed, result.duration)
+		if result.status != result.entry.Status {
+			report.Mismatches++
+			report.addMismatch(result)
+		}
+	}
+	report.Captured = newLatencies(captured)
+	report.Replayed = newLatencies(replayed)
+	return report
+}
+
+func (r replayer) send(ctx context.Context, entry accessLogEntry) replayResult {
+	url := r.target + entry.Path
+	if entry.Query != "" {
+		url += "?" + entry.Query
+	}
+	req, err := http.NewRequestWithContext(ctx, entry.Method, url, nil)
+	if err != nil {
+		return replayResult{entry: entry, err: err}
+	}
+	if r.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+r.apiKey)
+	}
+
+	start := time.Now()
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return replayResult{entry: entry, err: err}
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return replayResult{entry: entry, status: resp.StatusCode, duration: time.Since(start)}
+}
+
+// maxReplayMismatches bounds the number of mismatching requests listed in a report.
+const maxReplayMismatches = 10
+
+// replayReport is the outcome of a replay.
+type replayReport struct {
+	Target     string
+	Speed      float64
+	Duration   time.Duration
+	Requests   int
+	Skipped    int
+	Errors     int
+	Mismatches int
+	// MismatchExamples are the first mismatching requests, as "GET /path: 200 -> 404".
+	MismatchExamples []string
+	Captured         latencies
+	Replayed         latencies
+}
+
+func (r *replayReport) addMismatch(result replayResult) {
+	if len(r.MismatchExamples) >= maxReplayMismatches {
+		return
+	}
+	path := result.entry.Path
+	if result.entry.Query != "" {
+		path += "?" + result.entry.Query
+	}
+	r.MismatchExamples = append(r.MismatchExamples, fmt.Sprintf("%s %s: %d -> %d", result.entry.Method, path, result.entry.Status, result.status))
+}
+
+func (r replayReport) write(out io.Writer) {
+	fmt.Fprintf(out, "Replayed %d requests against %s at %gx in %s", r.Requests, r.Target, r.Speed, r.Duration.Round(time.Millisecond))
+	if r.Skipped > 0 {
+		fmt.Fprintf(out, ", skipped %d requests that are not reads", r.Skipped)
+	}
+	fmt.Fprintf(out, "\nErrors: %d, status mismatches: %d\n", r.Errors, r.Mismatches)
+	for _, example := range r.MismatchExamples {
+		fmt.Fprintln(out, "  "+example)
+	}
+
+	fmt.Fprintln(out)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LATENCY\tP50\tP90\tP99\tMAX")
+	for _, row := range []struct {
+		name string
+		l    latencies
+	}{{"captured", r.Captured}, {"replayed", r.Replayed}} {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", row.name, row.l.P50, row.l.P90, row.l.P99, row.l.Max)
+	}
+	w.Flush()
+}
+
+// latencies are percentiles of request durations.
+type latencies struct {
+	P50, P90, P99, Max time.Duration
+}
+
+func newLatencies(durations []time.Duration) latencies {
+	if len(durations) == 0 {
+		return latencies{}
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	percentile := func(p float64) time.Duration {
+		return durations[int(p*float64(len(durations)-1))].Round(time.Microsecond)
+	}
+	return latencies{P50: percentile(0.5), P90: percentile(0.9), P99: percentile(0.99), Max: percentile(1)}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReadAccessLog(t *testing.T) {
+	log := `{"time":"2026-01-01T00:00:01Z","method":"GET","path":"/signals","status":200,"duration_ms":2}
+{"time":"2026-01-01T00:00:00Z","method":"GET","path":"/prices/btc","query":"status=available","status":200,"duration_ms":1.5}
+
+{"time":"2026-01-01T00:00:02Z","method":"POST","path":"/prices","status":200,"duration_ms":1}
+`
+	entries, skipped, err := readAccessLog(strings.NewReader(log))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skipped != 1 || len(entries) != 2 || entries[0].Path != "/prices/btc" || entries[1].Path != "/signals" {
+		t.Fatalf("unexpected entries %+v, %d skipped", entries, skipped)
+	}
+
+	if _, _, err := readAccessLog(strings.NewReader("not json\n")); err == nil {
+		t.Error("expected an error for an invalid line")
+	}
+}
+
+func TestReplay(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.RequestURI())
+		mu.Unlock()
+		if r.Header.Get("Authorization") != "Bearer key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/signals" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := []accessLogEntry{
+		{Time: start, Method: "GET", Path: "/prices/btc", Query: "status=available", Status: 200, DurationMs: 1},
+		{Time: start.Add(time.Second), Method: "GET", Path: "/signals", Status: 200, DurationMs: 3},
+	}
+
+	r := replayer{target: server.URL, speed: 100, concurrency: 1, apiKey: "key", httpClient: server.Client()}
+	begin := time.Now()
+	report := r.replay(context.Background(), entries)
+	if elapsed := time.Since(begin); elapsed < 10*time.Millisecond {
+		t.Errorf("expected the requests to be spread over 10ms at 100x, took %s", elapsed)
+	}
+
+	if report.Requests != 2 || report.Errors != 0 || report.Mismatches != 1 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if len(report.MismatchExamples) != 1 || report.MismatchExamples[0] != "GET /signals: 200 -> 503" {
+		t.Errorf("unexpected mismatches %v", report.MismatchExamples)
+	}
+	if len(paths) != 2 || paths[0] != "/prices/btc?status=available" {
+		t.Errorf("unexpected replayed requests %v", paths)
+	}
+	if report.Captured.Max != 3*time.Millisecond || report.Replayed.Max == 0 {
+		t.Errorf("unexpected latencies %+v and %+v", report.Captured, report.Replayed)
+	}
+
+	var out bytes.Buffer
+	report.write(&out)
+	if !strings.Contains(out.String(), "status mismatches: 1") {
+		t.Errorf("unexpected output %s", out.String())
+	}
+}
+
+func TestParseSpeed(t *testing.T) {
+	for s, expected := range map[string]float64{"2x": 2, "0.5x": 0.5, "3": 3} {
+		if speed, err := parseSpeed(s); err != nil || speed != expected {
+			t.Errorf("parseSpeed(%q) = %v, %v", s, speed, err)
+		}
+	}
+	for _, s := range []string{"0x", "-1x", "fast"} {
+		if _, err := parseSpeed(s); err == nil {
+			t.Errorf("expected an error for %q", s)
+		}
+	}
+}
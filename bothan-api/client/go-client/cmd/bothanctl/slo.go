@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	client "github.com/bandprotocol/bothan/bothan-api/client/go-client"
+)
+
+const (
+	defaultSLOFreshness = 10 * time.Second
+	defaultSLOWindow    = time.Hour
+	defaultSLOInterval  = time.Second
+)
+
+// runSLO samples the prices of a server for a window and reports, per signal, how much of the
+// time the signal had an available price and how much of the time its last available price
+// was no older than the freshness target. The server does not report when a price was
+// updated, so freshness is measured from the last sample that returned an available price.
+func runSLO(args []string) error {
+	fs := flag.NewFlagSet("slo", flag.ExitOnError)
+	endpoint := fs.String("endpoint", defaultEndpoint, "address of the server")
+	ids := fs.String("ids", "", "comma separated signal IDs")
+	freshness := fs.Duration("freshness", defaultSLOFreshness, "maximum age of the last available price")
+	window := fs.Duration("window", defaultSLOWindow, "how long to sample for")
+	interval := fs.Duration("interval", defaultSLOInterval, "time between samples")
+	timeout := fs.Duration("timeout", defaultHealthcheckTimeout, "timeout of each query")
+	format := fs.String("format", "json", "output format, json or csv")
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return errors.New("unexpected arguments, the server is given with -endpoint")
+	}
+	if *ids == "" {
+		return errors.New("no signal IDs given with -ids")
+	}
+	if *format != "json" && *format != "csv" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	c, err := client.NewGRPC(*endpoint, *timeout)
+	if err != nil {
+		return err
+	}
+
+	// An interrupt ends the window early, and the samples taken so far are still reported.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, *window)
+	defer cancel()
+
+	tracker := newSLOTracker(strings.Split(*ids, ","), *freshness)
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	for ctx.Err() == nil {
+		results, err := c.GetPriceMap(ctx, tracker.signalIDs)
+		if ctx.Err() != nil {
+			break
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error querying", *endpoint+":", err)
+		}
+		tracker.observe(time.Now(), results)
+
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+	}
+
+	report := tracker.report(*endpoint)
+	if *format == "csv" {
+		return report.writeCSV(os.Stdout)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// sloTracker accumulates the samples of a set of signals. Every sample accounts for the time
+// since the previous one, by the state of the signals at the sample.
+type sloTracker struct {
+	signalIDs []string
+	freshness time.Duration
+
+	start    time.Time
+	last     time.Time
+	samples  int
+	failures int
+	signals  map[string]*signalSLO
+}
+
+type signalSLO struct {
+	lastAvailable time.Time
+	available     time.Duration
+	fresh         time.Duration
+	maxStaleness  time.Duration
+}
+
+func newSLOTracker(signalIDs []string, freshness time.Duration) *sloTracker {
+	signals := make(map[string]*signalSLO, len(signalIDs))
+	for _, id := range signalIDs {
+		signals[id] = &signalSLO{}
+	}
+
+	return &sloTracker{signalIDs: signalIDs, freshness: freshness, signals: signals}
+}
+
+// observe records a sample taken at the given time. Nil results mean the query failed, which
+// counts as no signal being available.
+func (t *sloTracker) observe(now time.Time, results map[string]client.PriceResult) {
+	var elapsed time.Duration
+	if t.samples == 0 {
+		t.start = now
+	} else {
+		elapsed = now.Sub(t.last)
+	}
+	t.last = now
+	t.samples++
+	if results == nil {
+		t.failures++
+	}
+
+	for _, id := range t.signalIDs {
+		s := t.signals[id]
+		if result, ok := results[id]; ok && result.Err == nil {
+			s.lastAvailable = now
+			s.available += elapsed
+		}
+
+		// A signal that was never available is as stale as the sampling has been running.
+		staleness := now.Sub(t.start)
+		if !s.lastAvailable.IsZero() {
+			staleness = now.Sub(s.lastAvailable)
+			if staleness <= t.freshness {
+				s.fresh += elapsed
+			}
+		}
+		s.maxStaleness = max(s.maxStaleness, staleness)
+	}
+}
+
+// sloReport is the outcome of an SLO run, in the order of the requested signals.
+type sloReport struct {
+	Endpoint  string          `json:"endpoint"`
+	Freshness string          `json:"freshness"`
+	Duration  string          `json:"duration"`
+	Samples   int             `json:"samples"`
+	Failures  int             `json:"failures"`
+	Signals   []sloSignalStat `json:"signals"`
+}
+
+type sloSignalStat struct {
+	SignalID         string  `json:"signal_id"`
+	AvailablePercent float64 `json:"available_percent"`
+	FreshPercent     float64 `json:"fresh_percent"`
+	MaxStaleness     float64 `json:"max_staleness_seconds"`
+}
+
+func (t *sloTracker) report(endpoint string) sloReport {
+	duration := t.last.Sub(t.start)
+	report := sloReport{
+		Endpoint:  endpoint,
+		Freshness: t.freshness.String(),
+		Duration:  duration.String(),
+		Samples:   t.samples,
+		Failures:  t.failures,
+		Signals:   make([]sloSignalStat, 0, len(t.signalIDs)),
+	}
+
+	for _, id := range t.signalIDs {
+		s := t.signals[id]
+		report.Signals = append(report.Signals, sloSignalStat{
+			SignalID:         id,
+			AvailablePercent: percent(s.available, duration),
+			FreshPercent:     percent(s.fresh, duration),
+			MaxStaleness:     s.maxStaleness.Seconds(),
+		})
+	}
+
+	return report
+}
+
+func (r sloReport) writeCSV(out io.Writer) error {
+	w := csv.NewWriter(out)
+	_ = w.Write([]string{"signal_id", "available_percent", "fresh_percent", "max_staleness_seconds"})
+	for _, s := range r.Signals {
+		_ = w.Write([]string{
+			s.SignalID,
+			strconv.FormatFloat(s.AvailablePercent, 'f', 3, 64),
+			strconv.FormatFloat(s.FreshPercent, 'f', 3, 64),
+			strconv.FormatFloat(s.MaxStaleness, 'f', 3, 64),
+		})
+	}
+	w.Flush()
+
+	return w.Error()
+}
+
+// percent returns the share of the total, or 0 for an empty total, as a single sample has no
+// duration to judge.
+func percent(part, total time.Duration) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return 100 * float64(part) / float64(total)
+}
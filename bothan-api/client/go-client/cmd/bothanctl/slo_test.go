@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	client "github.com/bandprotocol/bothan/bothan-api/client/go-client"
+)
+
+func TestSLOTracker(t *testing.T) {
+	tracker := newSLOTracker([]string{"btc", "eth"}, 10*time.Second)
+	available := func(ids ...string) map[string]client.PriceResult {
+		results := map[string]client.PriceResult{"btc": {Err: client.ErrPriceUnavailable}, "eth": {Err: client.ErrPriceUnavailable}}
+		for _, id := range ids {
+			results[id] = client.PriceResult{}
+		}
+		return results
+	}
+
+	start := time.Unix(0, 0)
+	// btc is available throughout, eth only at the first sample. The failed query at 20s makes
+	// btc unavailable for that interval, but its price from 10s ago is still fresh.
+	tracker.observe(start, available("btc", "eth"))
+	tracker.observe(start.Add(10*time.Second), available("btc"))
+	tracker.observe(start.Add(20*time.Second), nil)
+	tracker.observe(start.Add(40*time.Second), available("btc"))
+
+	report := tracker.report("localhost:50051")
+	if report.Samples != 4 || report.Failures != 1 || report.Duration != "40s" {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	expected := []sloSignalStat{
+		{SignalID: "btc", AvailablePercent: 75, FreshPercent: 100, MaxStaleness: 10},
+		{SignalID: "eth", AvailablePercent: 0, FreshPercent: 25, MaxStaleness: 40},
+	}
+	for i, stat := range report.Signals {
+		if stat != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], stat)
+		}
+	}
+}
+
+func TestSLOReportCSV(t *testing.T) {
+	report := sloReport{Signals: []sloSignalStat{{SignalID: "btc", AvailablePercent: 99.5, FreshPercent: 98, MaxStaleness: 12}}}
+
+	var buf bytes.Buffer
+	if err := report.writeCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	expected := "signal_id,available_percent,fresh_percent,max_staleness_seconds\nbtc,99.500,98.000,12.000\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	client "github.com/bandprotocol/bothan/bothan-api/client/go-client"
+)
+
+// releasesURLEnv opts into the release check of the version and healthcheck commands if
+// -releases is not given.
+const releasesURLEnv = "BOTHANCTL_RELEASES_URL"
+
+// releases is the document served by the releases endpoint, e.g.
+//
+//	{"latest": "0.2.0", "broken": [{"version": "0.1.1", "reason": "timestamps in milliseconds"}]}
+type releases struct {
+	Latest string          `json:"latest"`
+	Broken []brokenRelease `json:"broken"`
+}
+
+// brokenRelease is a release with a known bug operators should upgrade from.
+type brokenRelease struct {
+	Version string `json:"version"`
+	Reason  string `json:"reason"`
+}
+
+// runVersion prints the version of a server and, if a releases endpoint is given, advises
+// whether to upgrade. It fails if the server runs a known broken release.
+func runVersion(args []string) error {
+	fs := flag.NewFlagSet("version", flag.ExitOnError)
+	endpoint := fs.String("endpoint", defaultEndpoint, "address of the server")
+	releasesURL := fs.String("releases", os.Getenv(releasesURLEnv), "URL of the releases document, no release check if empty")
+	timeout := fs.Duration("timeout", defaultHealthcheckTimeout, "timeout of each request")
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		return errors.New("unexpected arguments, the server is given with -endpoint")
+	}
+
+	c, err := client.NewGRPC(*endpoint, *timeout)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	version, err := c.ServerVersion(context.Background())
+	if err != nil {
+		return fmt.Errorf("error querying %s: %w", *endpoint, err)
+	}
+	if version == "" {
+		fmt.Println("Server version: unknown, the server predates version reporting")
+	} else {
+		fmt.Println("Server version:", version)
+	}
+
+	if *releasesURL == "" {
+		return nil
+	}
+	r, err := fetchReleases(*releasesURL, *timeout)
+	if err != nil {
+		return err
+	}
+	advice, err := r.advise(version)
+	if advice != "" {
+		fmt.Println(advice)
+	}
+	return err
+}
+
+// checkVersion writes the advice of the releases endpoint of releasesURLEnv about the version
+// of the server to w, if the endpoint is set. Failures of the check are reported to w as well,
+// as the check is only advisory.
+func checkVersion(c *client.GRPC, timeout time.Duration, w io.Writer) {
+	releasesURL := os.Getenv(releasesURLEnv)
+	if releasesURL == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	version, err := c.ServerVersion(ctx)
+	if err != nil {
+		fmt.Fprintln(w, "Warning: error querying the server version:", err)
+		return
+	}
+	r, err := fetchReleases(releasesURL, timeout)
+	if err != nil {
+		fmt.Fprintln(w, "Warning:", err)
+		return
+	}
+	advice, err := r.advise(version)
+	if err != nil {
+		fmt.Fprintln(w, "Warning:", err)
+	} else if advice != "" {
+		fmt.Fprintln(w, advice)
+	}
+}
+
+func fetchReleases(url string, timeout time.Duration) (*releases, error) {
+	httpClient := http.Client{Timeout: timeout}
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching the releases: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching the releases: %s", resp.Status)
+	}
+
+	var r releases
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, fmt.Errorf("error decoding the releases: %w", err)
+	}
+	return &r, nil
+}
+
+// advise returns an upgrade advice for the given server version, if any, and an error if the
+// version is a known broken release.
+func (r *releases) advise(version string) (string, error) {
+	if version == "" {
+		if r.Latest == "" {
+			return "", nil
+		}
+		return fmt.Sprintf("The latest release is %s, consider upgrading to a release that reports its version", r.Latest), nil
+	}
+
+	advice := ""
+	if r.Latest != "" && compareVersions(version, r.Latest) < 0 {
+		advice = fmt.Sprintf("A newer release is available: %s (running %s)", r.Latest, version)
+	}
+	for _, broken := range r.Broken {
+		if compareVersions(version, broken.Version) == 0 {
+			return advice, fmt.Errorf("version %s is known to be broken: %s", version, broken.Reason)
+		}
+	}
+	return advice, nil
+}
+
+// compareVersions compares two versions like 1.2.3 or v1.2.3-rc.1 by their numeric parts,
+// ordering pre-releases before the release. It returns -1, 0 or 1 if a is older than, equal to
+// or newer than b. Parts that are not numbers compare as 0.
+func compareVersions(a, b string) int {
+	a, aPre, _ := strings.Cut(strings.TrimPrefix(a, "v"), "-")
+	b, bPre, _ := strings.Cut(strings.TrimPrefix(b, "v"), "-")
+
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(aParts), len(bParts)); i++ {
+		if c := versionPart(aParts, i) - versionPart(bParts, i); c != 0 {
+			if c < 0 {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	default:
+		return strings.Compare(aPre, bPre)
+	}
+}
+
+func versionPart(parts []string, i int) int {
+	if i >= len(parts) {
+		return 0
+	}
+	n, _ := strconv.Atoi(parts[i])
+	return n
+}
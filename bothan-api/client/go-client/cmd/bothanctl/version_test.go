@@ -0,0 +1,53 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"0.1.0", "0.1.0", 0},
+		{"v0.1.0", "0.1.0", 0},
+		{"0.1.0", "0.2.0", -1},
+		{"0.10.0", "0.9.1", 1},
+		{"1.0", "1.0.0", 0},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0-rc.2", "1.0.0-rc.1", 1},
+	}
+	for _, c := range cases {
+		if got := compareVersions(c.a, c.b); got != c.expected {
+			t.Errorf("compareVersions(%q, %q) = %d, expected %d", c.a, c.b, got, c.expected)
+		}
+	}
+}
+
+func TestReleasesAdvise(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"latest": "0.2.0", "broken": [{"version": "0.1.1", "reason": "timestamps in milliseconds"}]}`))
+	}))
+	defer server.Close()
+
+	r, err := fetchReleases(server.URL, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if advice, err := r.advise("0.2.0"); advice != "" || err != nil {
+		t.Errorf("expected no advice for the latest release, got %q, %v", advice, err)
+	}
+	if advice, err := r.advise("0.1.0"); advice == "" || err != nil {
+		t.Errorf("expected an upgrade advice, got %q, %v", advice, err)
+	}
+	if advice, err := r.advise("0.1.1"); advice == "" || err == nil {
+		t.Errorf("expected an upgrade advice and an error for a broken release, got %q, %v", advice, err)
+	}
+	if advice, err := r.advise(""); advice == "" || err != nil {
+		t.Errorf("expected an upgrade advice for an unknown version, got %q, %v", advice, err)
+	}
+}
@@ -0,0 +1,114 @@
+// Command signalgen generates a Go package with a constant per signal ID of a Bothan registry,
+// so that code referring to signals is checked at compile time. It is meant to be run with
+// go:generate.
+//
+// Usage:
+//
+//	signalgen -registry crypto_price.json [-pkg signals] [-o signals.go]
+//
+// Signal IDs are turned into constant names by upper casing them and replacing every other
+// character than a letter or digit by an underscore, e.g. crypto_price.btcusd becomes
+// CRYPTO_PRICE_BTCUSD.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+func main() {
+	registryPath := flag.String("registry", "", "path of the registry JSON file")
+	pkg := flag.String("pkg", "signals", "name of the generated package")
+	out := flag.String("o", "signals.go", "path of the generated file")
+	flag.Parse()
+
+	if *registryPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*registryPath, *pkg, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(registryPath, pkg, out string) error {
+	b, err := os.ReadFile(registryPath)
+	if err != nil {
+		return err
+	}
+
+	// Only the signal IDs, the keys of the registry, are needed.
+	var registry map[string]json.RawMessage
+	if err := json.Unmarshal(b, &registry); err != nil {
+		return fmt.Errorf("error parsing registry: %w", err)
+	}
+
+	ids := make([]string, 0, len(registry))
+	for id := range registry {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	src, err := generate(filepath.Base(registryPath), pkg, ids)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(out, src, 0o644)
+}
+
+// generate returns the formatted source of a package with a constant per signal ID and the
+// list of all signal IDs.
+func generate(source, pkg string, ids []string) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by signalgen from %s. DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+
+	names := make(map[string]string, len(ids))
+	buf.WriteString("const (\n")
+	for _, id := range ids {
+		name := constName(id)
+		if other, ok := names[name]; ok {
+			return nil, fmt.Errorf("signal IDs %q and %q map to the same constant %s", other, id, name)
+		}
+		names[name] = id
+		fmt.Fprintf(&buf, "\t%s = %q\n", name, id)
+	}
+	buf.WriteString(")\n\n")
+
+	buf.WriteString("// All lists every signal ID of the registry, sorted.\n")
+	buf.WriteString("var All = []string{\n")
+	for _, id := range ids {
+		fmt.Fprintf(&buf, "\t%s,\n", constName(id))
+	}
+	buf.WriteString("}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// constName returns the constant name of a signal ID.
+func constName(id string) string {
+	name := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, id)
+
+	// Identifiers must start with a letter, and should be exported.
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		name = "S_" + name
+	}
+
+	return name
+}
@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestConstName(t *testing.T) {
+	tests := map[string]string{
+		"crypto_price.btcusd": "CRYPTO_PRICE_BTCUSD",
+		"CS:BTC-USD":          "CS_BTC_USD",
+		"1inch":               "S_1INCH",
+	}
+	for id, expected := range tests {
+		if name := constName(id); name != expected {
+			t.Errorf("%s: expected %s, got %s", id, expected, name)
+		}
+	}
+}
+
+func TestGenerateRejectsCollisions(t *testing.T) {
+	if _, err := generate("registry.json", "signals", []string{"a.b", "a_b"}); err == nil {
+		t.Error("expected an error for signal IDs mapping to the same constant")
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !jsoniter
+
+package client
+
+import (
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// decodePrices decodes the gateway JSON of a QueryPricesResponse with protojson, which
+// implements the whole JSON mapping of protobuf. Building with the jsoniter tag replaces it by
+// a faster decoder, see codec_jsoniter.go.
+func decodePrices(body []byte, resp *proto.QueryPricesResponse) error {
+	return unmarshalOptions.Unmarshal(body, resp)
+}
@@ -0,0 +1,144 @@
+//go:build jsoniter
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	jsoniter "github.com/json-iterator/go"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+var jsonAPI = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// decodePrices decodes the gateway JSON of a QueryPricesResponse with jsoniter, which is
+// considerably faster than protojson on large responses. It decodes into structs mirroring the
+// response, which must be kept in sync with query.proto, and accepts the same JSON as
+// protojson does for this message: camel case or snake case field names, enums as names or
+// numbers and 64-bit integers as strings or numbers.
+func decodePrices(body []byte, resp *proto.QueryPricesResponse) error {
+	var wire wirePricesResponse
+	if err := jsonAPI.Unmarshal(body, &wire); err != nil {
+		return err
+	}
+
+	resp.Prices = make([]*proto.PriceData, len(wire.Prices))
+	for i, price := range wire.Prices {
+		resp.Prices[i] = &proto.PriceData{
+			SignalId:    either(price.SignalID, price.SignalIDSnake),
+			Price:       price.Price,
+			PriceStatus: proto.PriceStatus(either(price.PriceStatus, price.PriceStatusSnake)),
+		}
+	}
+	resp.Expansions = make([]*proto.GroupExpansion, len(wire.Expansions))
+	for i, expansion := range wire.Expansions {
+		resp.Expansions[i] = &proto.GroupExpansion{
+			Group:     expansion.Group,
+			SignalIds: append(expansion.SignalIDs, expansion.SignalIDsSnake...),
+		}
+	}
+	if timing := eitherPtr(wire.ServerTiming, wire.ServerTimingSnake); timing != nil {
+		resp.ServerTiming = &proto.ServerTiming{
+			QueueTimeUs:      uint64(either(timing.QueueTimeUs, timing.QueueTimeUsSnake)),
+			ProcessingTimeUs: uint64(either(timing.ProcessingTimeUs, timing.ProcessingTimeUsSnake)),
+		}
+	}
+
+	return nil
+}
+
+// The wire structs have a field per JSON name of every proto field, as jsoniter cannot map
+// two names to one field.
+type wirePricesResponse struct {
+	Prices            []wirePriceData      `json:"prices"`
+	Expansions        []wireGroupExpansion `json:"expansions"`
+	ServerTiming      *wireServerTiming    `json:"serverTiming"`
+	ServerTimingSnake *wireServerTiming    `json:"server_timing"`
+}
+
+type wirePriceData struct {
+	SignalID         string          `json:"signalId"`
+	SignalIDSnake    string          `json:"signal_id"`
+	Price            string          `json:"price"`
+	PriceStatus      wirePriceStatus `json:"priceStatus"`
+	PriceStatusSnake wirePriceStatus `json:"price_status"`
+}
+
+type wireGroupExpansion struct {
+	Group          string   `json:"group"`
+	SignalIDs      []string `json:"signalIds"`
+	SignalIDsSnake []string `json:"signal_ids"`
+}
+
+type wireServerTiming struct {
+	QueueTimeUs           wireUint64 `json:"queueTimeUs"`
+	QueueTimeUsSnake      wireUint64 `json:"queue_time_us"`
+	ProcessingTimeUs      wireUint64 `json:"processingTimeUs"`
+	ProcessingTimeUsSnake wireUint64 `json:"processing_time_us"`
+}
+
+// either returns a unless it is the zero value, in which case it returns b.
+func either[T comparable](a, b T) T {
+	var zero T
+	if a != zero {
+		return a
+	}
+	return b
+}
+
+func eitherPtr[T any](a, b *T) *T {
+	if a != nil {
+		return a
+	}
+	return b
+}
+
+// wirePriceStatus decodes an enum given by name or by number. Unknown names decode to the
+// unspecified status, like protojson with DiscardUnknown.
+type wirePriceStatus int32
+
+func (s *wirePriceStatus) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	if len(b) > 0 && b[0] == '"' {
+		*s = wirePriceStatus(proto.PriceStatus_value[string(bytes.Trim(b, `"`))])
+		return nil
+	}
+
+	n, err := strconv.ParseInt(string(b), 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid price status %s", b)
+	}
+	*s = wirePriceStatus(n)
+	return nil
+}
+
+// wireUint64 decodes a 64-bit integer given as a string, as protojson writes them, or as a
+// number. Like protojson, it accepts integral values in exponent or fractional notation, e.g.
+// 1e3 or 1.0 as written by JavaScript serializers, and decodes them exactly rather than
+// through a float64, which cannot represent integers above 2^53.
+type wireUint64 uint64
+
+func (n *wireUint64) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	s := string(bytes.Trim(b, `"`))
+	if v, err := strconv.ParseUint(s, 10, 64); err == nil {
+		*n = wireUint64(v)
+		return nil
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || !r.IsInt() || !r.Num().IsUint64() {
+		return fmt.Errorf("invalid integer %s", b)
+	}
+	*n = wireUint64(r.Num().Uint64())
+	return nil
+}
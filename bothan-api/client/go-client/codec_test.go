@@ -0,0 +1,95 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	protobuf "google.golang.org/protobuf/proto"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// TestDecodePricesMatchesProtojson checks that the decoder selected by the build tags decodes
+// gateway JSON like protojson does. Run it with -tags jsoniter to check the jsoniter decoder.
+func TestDecodePricesMatchesProtojson(t *testing.T) {
+	bodies := []string{
+		`{}`,
+		`{"prices":[{"signalId":"btc","price":"1","priceStatus":"PRICE_STATUS_AVAILABLE"}]}`,
+		`{"prices":[{"signal_id":"btc","price":"1","price_status":"PRICE_STATUS_AVAILABLE"}]}`,
+		`{"prices":[{"signalId":"btc","priceStatus":3},{"signalId":"eth","priceStatus":"PRICE_STATUS_STALE"}]}`,
+		`{"prices":[{"signalId":"btc","priceStatus":null}],"unknown":{"nested":[1,2]}}`,
+		`{"expansions":[{"group":"@majors","signalIds":["btc","eth"]}]}`,
+		`{"serverTiming":{"queueTimeUs":"10","processingTimeUs":20}}`,
+		`{"server_timing":{"queue_time_us":"10","processing_time_us":"20"}}`,
+		benchmarkPricesBody(300),
+	}
+	// 64-bit integers around 2^53, above which float64 loses precision, and at the maximum, in
+	// every notation protojson accepts.
+	for _, n := range []string{"9007199254740992", "9007199254740993", "18446744073709551615"} {
+		bodies = append(bodies,
+			`{"serverTiming":{"queueTimeUs":"`+n+`","processingTimeUs":`+n+`}}`,
+			`{"serverTiming":{"queueTimeUs":`+n+`.0,"processingTimeUs":"`+n+`.0"}}`,
+		)
+	}
+	bodies = append(bodies, `{"serverTiming":{"queueTimeUs":1e3,"processingTimeUs":"9.007199254740993e15"}}`)
+	for _, body := range bodies {
+		var want, got proto.QueryPricesResponse
+		if err := unmarshalOptions.Unmarshal([]byte(body), &want); err != nil {
+			t.Fatal(err)
+		}
+		if err := decodePrices([]byte(body), &got); err != nil {
+			t.Fatalf("%.60s: %v", body, err)
+		}
+		if !protobuf.Equal(&got, &want) {
+			t.Errorf("%.60s: decoded %v, protojson decoded %v", body, &got, &want)
+		}
+	}
+}
+
+func TestDecodePricesRejectsInvalidIntegers(t *testing.T) {
+	for _, n := range []string{"-1", "1.5", "18446744073709551616", "1e20", `"abc"`, "true"} {
+		body := `{"serverTiming":{"queueTimeUs":` + n + `}}`
+		if err := unmarshalOptions.Unmarshal([]byte(body), &proto.QueryPricesResponse{}); err == nil {
+			t.Fatalf("%s: expected protojson to reject the value", n)
+		}
+		if err := decodePrices([]byte(body), &proto.QueryPricesResponse{}); err == nil {
+			t.Errorf("%s: expected an error", n)
+		}
+	}
+}
+
+// BenchmarkDecodePrices compares the selected decoder with protojson on a response of 300
+// prices, e.g.
+//
+//	go test -run - -bench DecodePrices -tags jsoniter
+func BenchmarkDecodePrices(b *testing.B) {
+	body := []byte(benchmarkPricesBody(300))
+
+	b.Run("protojson", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var resp proto.QueryPricesResponse
+			if err := unmarshalOptions.Unmarshal(body, &resp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("selected", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var resp proto.QueryPricesResponse
+			if err := decodePrices(body, &resp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func benchmarkPricesBody(n int) string {
+	prices := make([]string, n)
+	for i := range prices {
+		prices[i] = fmt.Sprintf(`{"signalId":"CS:TOKEN%d-USD","price":"%d.123456789","priceStatus":"PRICE_STATUS_AVAILABLE"}`, i, 1000+i)
+	}
+	return `{"prices":[` + strings.Join(prices, ",") + `],"serverTiming":{"queueTimeUs":"12","processingTimeUs":"345"}}`
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// SignalLister is implemented by the clients that can list the signals of the server.
+type SignalLister interface {
+	ListSignals(ctx context.Context) ([]*proto.SignalInfo, error)
+}
+
+var (
+	_ SignalLister = &GRPC{}
+	_ SignalLister = &RestClient{}
+	_ Client       = &Composite{}
+	_ PriceMapper  = &Composite{}
+	_ SignalLister = &Composite{}
+)
+
+// Composite routes each method to the client configured for it, for deployments that expose
+// the gRPC and REST transports under different access policies. A typical setup queries prices
+// over gRPC, where latency matters, and everything else over REST through an authenticated
+// proxy.
+type Composite struct {
+	// Prices serves QueryPrices and GetPriceMap.
+	Prices Client
+	// Signals serves ListSignals.
+	Signals SignalLister
+}
+
+// NewComposite creates a client that queries prices with the given client and lists signals
+// with the given lister.
+func NewComposite(prices Client, signals SignalLister) *Composite {
+	return &Composite{Prices: prices, Signals: signals}
+}
+
+func (c *Composite) QueryPrices(signalIds []string) ([]*proto.PriceData, error) {
+	return c.Prices.QueryPrices(signalIds)
+}
+
+func (c *Composite) GetPriceMap(ctx context.Context, signalIds []string) (map[string]PriceResult, error) {
+	return GetPriceMap(ctx, c.Prices, signalIds)
+}
+
+func (c *Composite) ListSignals(ctx context.Context) ([]*proto.SignalInfo, error) {
+	return c.Signals.ListSignals(ctx)
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestComposite(t *testing.T) {
+	// The gateway renders field names in camel case and pages with page_token.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page_token") == "" {
+			_, _ = w.Write([]byte(`{"signals":[{"signalId":"crypto_price.btcusd","priceStatus":"PRICE_STATUS_AVAILABLE"}],"nextPageToken":"crypto_price.btcusd"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"signals":[{"signalId":"crypto_price.ethusd"}],"nextPageToken":""}`))
+	}))
+	defer server.Close()
+
+	stub := &stubClient{prices: []*proto.PriceData{{SignalId: "crypto_price.btcusd", Price: "60000"}}}
+	c := NewComposite(stub, NewRest(server.URL, time.Second))
+
+	prices, err := c.QueryPrices([]string{"crypto_price.btcusd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 1 || prices[0].Price != "60000" {
+		t.Errorf("expected the prices of the price client, got %v", prices)
+	}
+
+	signals, err := c.ListSignals(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signals) != 2 || signals[0].SignalId != "crypto_price.btcusd" || signals[1].SignalId != "crypto_price.ethusd" {
+		t.Errorf("expected both pages of signals, got %v", signals)
+	}
+	if signals[0].PriceStatus != proto.PriceStatus_PRICE_STATUS_AVAILABLE {
+		t.Errorf("expected an available price status, got %v", signals[0].PriceStatus)
+	}
+}
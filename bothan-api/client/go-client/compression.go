@@ -0,0 +1,12 @@
+package client
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+// WithCompression returns a dial option for NewGRPC that compresses the calls with gzip, see
+// the option of the same name in v2.
+func WithCompression() grpc.DialOption {
+	return grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name))
+}
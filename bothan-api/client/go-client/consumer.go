@@ -0,0 +1,18 @@
+package client
+
+import (
+	"google.golang.org/grpc"
+
+	clientv2 "github.com/bandprotocol/bothan/bothan-api/client/go-client/v2"
+)
+
+// ConsumerHeader is the header, and in lower case the gRPC metadata key, in which a client
+// names the service it queries for. The proxy labels its usage metrics with it, so that load
+// can be attributed to downstream services.
+const ConsumerHeader = clientv2.ConsumerHeader
+
+// WithConsumer returns a dial option for NewGRPC that tags every call with the given consumer
+// name.
+func WithConsumer(name string) grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(clientv2.ConsumerInterceptor(name))
+}
@@ -0,0 +1,102 @@
+// Package cosmos converts Bothan prices into the decimal types of the Cosmos SDK without going
+// through float64, which silently loses precision.
+//
+// It is a separate module so that the client does not depend on the Cosmos SDK.
+package cosmos
+
+import (
+	"fmt"
+	"strings"
+
+	"cosmossdk.io/math"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/feeds"
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// ToLegacyDec converts a decimal price as returned by Bothan into a LegacyDec. It fails with
+// feeds.ErrPrecisionLoss if the price has non-zero digits beyond math.LegacyPrecision decimals
+// and with feeds.ErrPriceOverflow if it exceeds the range of a LegacyDec.
+func ToLegacyDec(price string) (math.LegacyDec, error) {
+	integer, fraction, err := splitDecimal(price)
+	if err != nil {
+		return math.LegacyDec{}, err
+	}
+
+	if len(fraction) > math.LegacyPrecision {
+		if strings.TrimRight(fraction[math.LegacyPrecision:], "0") != "" {
+			return math.LegacyDec{}, fmt.Errorf("%w: %q has more than %d decimals", feeds.ErrPrecisionLoss, price, math.LegacyPrecision)
+		}
+		fraction = fraction[:math.LegacyPrecision]
+	}
+
+	return newLegacyDec(price, integer, fraction)
+}
+
+// ToLegacyDecTruncated is like ToLegacyDec but truncates the decimals beyond
+// math.LegacyPrecision instead of failing.
+func ToLegacyDecTruncated(price string) (math.LegacyDec, error) {
+	integer, fraction, err := splitDecimal(price)
+	if err != nil {
+		return math.LegacyDec{}, err
+	}
+
+	if len(fraction) > math.LegacyPrecision {
+		fraction = fraction[:math.LegacyPrecision]
+	}
+
+	return newLegacyDec(price, integer, fraction)
+}
+
+// PriceDataToLegacyDec converts the price of a PriceData, which must be available.
+func PriceDataToLegacyDec(price *proto.PriceData) (math.LegacyDec, error) {
+	if price.PriceStatus != proto.PriceStatus_PRICE_STATUS_AVAILABLE {
+		return math.LegacyDec{}, fmt.Errorf("%w: %s is %s", feeds.ErrInvalidPrice, price.SignalId, price.PriceStatus)
+	}
+
+	return ToLegacyDec(price.Price)
+}
+
+// FromLegacyDec formats a LegacyDec like Bothan formats prices, without trailing zeros.
+func FromLegacyDec(d math.LegacyDec) string {
+	s := strings.TrimRight(d.String(), "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+func newLegacyDec(price, integer, fraction string) (math.LegacyDec, error) {
+	if integer == "" {
+		integer = "0"
+	}
+	s := integer
+	if fraction != "" {
+		s += "." + fraction
+	}
+
+	// The input is validated, so the only remaining error is a value out of range.
+	d, err := math.LegacyNewDecFromStr(s)
+	if err != nil {
+		return math.LegacyDec{}, fmt.Errorf("%w: %q: %v", feeds.ErrPriceOverflow, price, err)
+	}
+
+	return d, nil
+}
+
+// splitDecimal splits a non-negative decimal string into its integer and fraction digits.
+func splitDecimal(price string) (string, string, error) {
+	integer, fraction, _ := strings.Cut(price, ".")
+	if (integer == "" && fraction == "") || !isDigits(integer) || !isDigits(fraction) {
+		return "", "", fmt.Errorf("%w: %q", feeds.ErrInvalidPrice, price)
+	}
+
+	return integer, fraction, nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,78 @@
+package cosmos
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/feeds"
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestToLegacyDec(t *testing.T) {
+	tests := []struct {
+		price    string
+		expected string
+		err      error
+	}{
+		{"67012.123456789123", "67012.123456789123000000", nil},
+		{"0.000000000000000001", "0.000000000000000001", nil},
+		{"1.0000000000000000000", "1.000000000000000000", nil},
+		{".5", "0.500000000000000000", nil},
+		{"5.", "5.000000000000000000", nil},
+		// A float64 would round this to 9007199254740992.
+		{"9007199254740993.000000000000000001", "9007199254740993.000000000000000001", nil},
+		{"0.0000000000000000001", "", feeds.ErrPrecisionLoss},
+		{"1" + strings.Repeat("0", 80), "", feeds.ErrPriceOverflow},
+		{"-1", "", feeds.ErrInvalidPrice},
+		{"1e5", "", feeds.ErrInvalidPrice},
+		{"", "", feeds.ErrInvalidPrice},
+		{".", "", feeds.ErrInvalidPrice},
+	}
+	for _, tt := range tests {
+		d, err := ToLegacyDec(tt.price)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%q: expected error %v, got %v", tt.price, tt.err, err)
+			continue
+		}
+		if err == nil && d.String() != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.price, tt.expected, d.String())
+		}
+	}
+}
+
+func TestToLegacyDecTruncated(t *testing.T) {
+	d, err := ToLegacyDecTruncated("1.0000000000000000019")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.String() != "1.000000000000000001" {
+		t.Errorf("expected the extra decimal to be truncated, got %s", d)
+	}
+}
+
+func TestPriceDataToLegacyDec(t *testing.T) {
+	if _, err := PriceDataToLegacyDec(&proto.PriceData{SignalId: "btc", PriceStatus: proto.PriceStatus_PRICE_STATUS_UNAVAILABLE}); !errors.Is(err, feeds.ErrInvalidPrice) {
+		t.Errorf("expected an error for an unavailable price, got %v", err)
+	}
+
+	d, err := PriceDataToLegacyDec(&proto.PriceData{SignalId: "btc", Price: "60000.5", PriceStatus: proto.PriceStatus_PRICE_STATUS_AVAILABLE})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := FromLegacyDec(d); s != "60000.5" {
+		t.Errorf("expected 60000.5, got %s", s)
+	}
+}
+
+func TestFromLegacyDec(t *testing.T) {
+	for _, price := range []string{"60000.5", "60000", "0.000000000000000001"} {
+		d, err := ToLegacyDec(price)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := FromLegacyDec(d); s != price {
+			t.Errorf("expected %s, got %s", price, s)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+module github.com/bandprotocol/bothan/bothan-api/client/go-client/cosmos
+
+go 1.22.0
+
+replace github.com/bandprotocol/bothan/bothan-api/client/go-client => ../
+
+replace github.com/bandprotocol/bothan/bothan-api/client/go-client/v2 => ../v2
+
+replace github.com/bandprotocol/bothan/bothan-api/client/go-client/query => ../query
+
+require (
+	cosmossdk.io/math v1.3.0
+	github.com/bandprotocol/bothan/bothan-api/client/go-client v0.1.0
+	github.com/bandprotocol/bothan/bothan-api/client/go-client/query v0.1.0
+)
+
+require (
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.19.1 // indirect
+	golang.org/x/exp v0.0.0-20221205204356-47842c84f3db // indirect
+	golang.org/x/net v0.21.0 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	google.golang.org/genproto/googleapis/api v0.0.0-20240506185236-b8a5c65736ae // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240429193739-8cf5692501f6 // indirect
+	google.golang.org/grpc v1.63.2 // indirect
+	google.golang.org/protobuf v1.34.1 // indirect
+)
@@ -0,0 +1,201 @@
+package dnscache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	resolvConfPath = "/etc/resolv.conf"
+	hostsPath      = "/etc/hosts"
+	queryTimeout   = 2 * time.Second
+)
+
+// errTruncated is returned for an answer that did not fit in a UDP packet and must be
+// retried over TCP, which the resolver of the standard library does.
+var errTruncated = errors.New("dnscache: truncated answer")
+
+// systemLookup resolves a host with the name servers of /etc/resolv.conf, which unlike the
+// resolver of the standard library reports the TTL of the records. It uses the standard
+// library, without a TTL, for hosts of the hosts file and if the name servers cannot answer,
+// e.g. for names that are only resolvable through search domains or truncated answers.
+func systemLookup(ctx context.Context, host string) ([]net.IP, time.Duration, error) {
+	if servers := nameservers(resolvConfPath); len(servers) > 0 && !inHosts(hostsPath, host) {
+		if ips, ttl, err := query(ctx, servers, host); err == nil && len(ips) > 0 {
+			return ips, ttl, nil
+		}
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ips := make([]net.IP, 0, len(addrs))
+	for _, addr := range addrs {
+		ips = append(ips, addr.IP)
+	}
+
+	return ips, 0, nil
+}
+
+// nameservers returns the addresses of the name servers listed in the given resolv.conf.
+func nameservers(path string) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var servers []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			servers = append(servers, net.JoinHostPort(fields[1], "53"))
+		}
+	}
+
+	return servers
+}
+
+// inHosts reports whether the host is listed in the given hosts file.
+func inHosts(path, host string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	host = strings.TrimSuffix(host, ".")
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		for _, name := range fields[min(1, len(fields)):] {
+			if strings.EqualFold(name, host) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// query asks the given name servers in order for the A and AAAA records of the host and
+// returns the addresses with the lowest TTL among them.
+func query(ctx context.Context, servers []string, host string) ([]net.IP, time.Duration, error) {
+	name, err := dnsmessage.NewName(strings.TrimSuffix(host, ".") + ".")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var errs []error
+	for _, server := range servers {
+		var ips []net.IP
+		var ttl time.Duration
+		var serverErr error
+		for _, qtype := range []dnsmessage.Type{dnsmessage.TypeA, dnsmessage.TypeAAAA} {
+			answerIPs, answerTTL, err := exchange(ctx, server, name, qtype)
+			if err != nil {
+				serverErr = err
+				break
+			}
+			ips = append(ips, answerIPs...)
+			if len(answerIPs) > 0 && (ttl == 0 || answerTTL < ttl) {
+				ttl = answerTTL
+			}
+		}
+		if serverErr == nil {
+			return ips, ttl, nil
+		}
+		errs = append(errs, serverErr)
+	}
+
+	return nil, 0, errors.Join(errs...)
+}
+
+// exchange sends a single query over UDP and parses the address records of the answer.
+func exchange(ctx context.Context, server string, name dnsmessage.Name, qtype dnsmessage.Type) ([]net.IP, time.Duration, error) {
+	id := uint16(rand.Uint32())
+	msg := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: id, RecursionDesired: true},
+		Questions: []dnsmessage.Question{{Name: name, Type: qtype, Class: dnsmessage.ClassINET}},
+	}
+	packet, err := msg.Pack()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", server)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(queryTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, 0, err
+	}
+
+	if _, err := conn.Write(packet); err != nil {
+		return nil, 0, err
+	}
+
+	buf := make([]byte, 1232)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var resp dnsmessage.Message
+		if err := resp.Unpack(buf[:n]); err != nil || resp.ID != id || !resp.Response {
+			// Ignore unrelated or malformed packets until the deadline.
+			continue
+		}
+		if resp.Truncated {
+			return nil, 0, errTruncated
+		}
+		if resp.RCode != dnsmessage.RCodeSuccess {
+			return nil, 0, errors.New("dnscache: " + resp.RCode.String())
+		}
+
+		return addresses(resp.Answers)
+	}
+}
+
+func addresses(answers []dnsmessage.Resource) ([]net.IP, time.Duration, error) {
+	var ips []net.IP
+	var ttl uint32
+	for _, answer := range answers {
+		var ip net.IP
+		switch body := answer.Body.(type) {
+		case *dnsmessage.AResource:
+			ip = net.IP(body.A[:])
+		case *dnsmessage.AAAAResource:
+			ip = net.IP(body.AAAA[:])
+		default:
+			continue
+		}
+
+		ips = append(ips, ip)
+		if len(ips) == 1 || answer.Header.TTL < ttl {
+			ttl = answer.Header.TTL
+		}
+	}
+
+	return ips, time.Duration(ttl) * time.Second, nil
+}
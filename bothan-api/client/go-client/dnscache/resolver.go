@@ -0,0 +1,181 @@
+// Package dnscache provides a DNS resolver that caches lookups for as long as their TTL
+// allows and keeps serving expired records while the DNS servers are unreachable.
+//
+// To learn the TTL, the resolver queries the name servers of /etc/resolv.conf directly over
+// UDP. Host names of /etc/hosts and truncated answers are resolved by the resolver of the
+// standard library instead, without a TTL, but other sources configured in nsswitch.conf,
+// like mDNS or LDAP, are not consulted.
+package dnscache
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// minDialTimeout is the least time DialContext gives an address if the deadline allows.
+	minDialTimeout = 2 * time.Second
+
+	defaultMinTTL   = 5 * time.Second
+	defaultMaxTTL   = 5 * time.Minute
+	defaultStaleTTL = 10 * time.Minute
+)
+
+// Config defines the caching behavior of a Resolver. Zero values are replaced by defaults.
+type Config struct {
+	// MinTTL is the minimum time records are cached, regardless of their TTL. It is also the
+	// cache time of lookups whose TTL is unknown.
+	MinTTL time.Duration
+	// MaxTTL is the maximum time records are cached, regardless of their TTL.
+	MaxTTL time.Duration
+	// StaleTTL is how long expired records are still served when a new lookup fails.
+	StaleTTL time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.MinTTL <= 0 {
+		c.MinTTL = defaultMinTTL
+	}
+	if c.MaxTTL <= 0 {
+		c.MaxTTL = defaultMaxTTL
+	}
+	if c.MaxTTL < c.MinTTL {
+		c.MaxTTL = c.MinTTL
+	}
+	if c.StaleTTL < 0 {
+		c.StaleTTL = 0
+	} else if c.StaleTTL == 0 {
+		c.StaleTTL = defaultStaleTTL
+	}
+
+	return c
+}
+
+// lookupFunc resolves a host and returns its addresses together with their TTL. A TTL of
+// zero means the TTL is unknown.
+type lookupFunc func(ctx context.Context, host string) ([]net.IP, time.Duration, error)
+
+type entry struct {
+	ips     []net.IP
+	expires time.Time
+}
+
+// Resolver is a caching DNS resolver. It is safe for concurrent use.
+type Resolver struct {
+	config Config
+	lookup lookupFunc
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+// New creates a new Resolver that queries the name servers of the system.
+func New(config Config) *Resolver {
+	return newResolver(config, systemLookup)
+}
+
+func newResolver(config Config, lookup lookupFunc) *Resolver {
+	return &Resolver{
+		config:  config.withDefaults(),
+		lookup:  lookup,
+		now:     time.Now,
+		entries: make(map[string]entry),
+	}
+}
+
+// LookupIP returns the addresses of the given host, from the cache if possible.
+func (r *Resolver) LookupIP(ctx context.Context, host string) ([]net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IP{ip}, nil
+	}
+
+	now := r.now()
+	r.mu.Lock()
+	cached, ok := r.entries[host]
+	r.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.ips, nil
+	}
+
+	ips, ttl, err := r.lookup(ctx, host)
+	if err == nil && len(ips) == 0 {
+		err = &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	if err != nil {
+		if ok && now.Before(cached.expires.Add(r.config.StaleTTL)) {
+			return cached.ips, nil
+		}
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.entries[host] = entry{ips: ips, expires: now.Add(r.clampTTL(ttl))}
+	r.mu.Unlock()
+
+	return ips, nil
+}
+
+func (r *Resolver) clampTTL(ttl time.Duration) time.Duration {
+	switch {
+	case ttl < r.config.MinTTL:
+		return r.config.MinTTL
+	case ttl > r.config.MaxTTL:
+		return r.config.MaxTTL
+	default:
+		return ttl
+	}
+}
+
+// DialContext connects to the address on the named network like net.Dialer.DialContext, but
+// resolves the host through the cache. The addresses of the host are tried in order, each
+// with its share of the remaining deadline, so that an unreachable address does not use up
+// the time of the others.
+func (r *Resolver) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	ips, err := r.LookupIP(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	var dialer net.Dialer
+	var errs []error
+	for i, ip := range ips {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		dialCtx, cancel := ctx, context.CancelFunc(func() {})
+		if deadline, ok := ctx.Deadline(); ok {
+			dialCtx, cancel = context.WithDeadline(ctx, partialDeadline(time.Now(), deadline, len(ips)-i))
+		}
+		conn, err := dialer.DialContext(dialCtx, network, net.JoinHostPort(ip.String(), port))
+		cancel()
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, err)
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// partialDeadline returns the deadline of the next of the remaining addresses, which share
+// the time until the deadline equally, but get at least minDialTimeout if there is that much
+// time left. It follows the dialer of the standard library.
+func partialDeadline(now, deadline time.Time, remaining int) time.Time {
+	left := deadline.Sub(now)
+	timeout := left / time.Duration(remaining)
+	if timeout < minDialTimeout {
+		timeout = min(minDialTimeout, left)
+	}
+	return now.Add(timeout)
+}
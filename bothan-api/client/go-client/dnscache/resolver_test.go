@@ -0,0 +1,189 @@
+package dnscache
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+type fakeLookup struct {
+	calls int
+	ips   []net.IP
+	ttl   time.Duration
+	err   error
+}
+
+func (f *fakeLookup) lookup(context.Context, string) ([]net.IP, time.Duration, error) {
+	f.calls++
+	return f.ips, f.ttl, f.err
+}
+
+func TestResolverHonorsTTL(t *testing.T) {
+	fake := &fakeLookup{ips: []net.IP{net.ParseIP("10.0.0.1")}, ttl: 30 * time.Second}
+	resolver := newResolver(Config{MinTTL: time.Second, MaxTTL: time.Minute, StaleTTL: time.Minute}, fake.lookup)
+	now := time.Now()
+	resolver.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if _, err := resolver.LookupIP(context.Background(), "bothan.example"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if fake.calls != 1 {
+		t.Fatalf("expected 1 lookup within the TTL, got %d", fake.calls)
+	}
+
+	now = now.Add(31 * time.Second)
+	if _, err := resolver.LookupIP(context.Background(), "bothan.example"); err != nil {
+		t.Fatal(err)
+	}
+	if fake.calls != 2 {
+		t.Fatalf("expected a new lookup after the TTL, got %d lookups", fake.calls)
+	}
+}
+
+func TestResolverServesStaleRecords(t *testing.T) {
+	fake := &fakeLookup{ips: []net.IP{net.ParseIP("10.0.0.1")}, ttl: 10 * time.Second}
+	resolver := newResolver(Config{StaleTTL: time.Minute}, fake.lookup)
+	now := time.Now()
+	resolver.now = func() time.Time { return now }
+
+	if _, err := resolver.LookupIP(context.Background(), "bothan.example"); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.err = errors.New("dns outage")
+	now = now.Add(30 * time.Second)
+	ips, err := resolver.LookupIP(context.Background(), "bothan.example")
+	if err != nil {
+		t.Fatalf("expected the stale record, got %v", err)
+	}
+	if !ips[0].Equal(net.ParseIP("10.0.0.1")) {
+		t.Errorf("unexpected address %v", ips[0])
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := resolver.LookupIP(context.Background(), "bothan.example"); err == nil {
+		t.Error("expected an error once the stale period is over")
+	}
+}
+
+func TestQueryReportsTTL(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	go serveDNS(conn, [4]byte{10, 0, 0, 1}, 42, false)
+
+	ips, ttl, err := query(context.Background(), []string{conn.LocalAddr().String()}, "bothan.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ips) != 1 || !ips[0].Equal(net.ParseIP("10.0.0.1")) {
+		t.Errorf("unexpected addresses %v", ips)
+	}
+	if ttl != 42*time.Second {
+		t.Errorf("expected TTL 42s, got %v", ttl)
+	}
+}
+
+func TestQueryRejectsTruncatedAnswers(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	go serveDNS(conn, [4]byte{10, 0, 0, 1}, 42, true)
+
+	if _, _, err := query(context.Background(), []string{conn.LocalAddr().String()}, "bothan.example"); !errors.Is(err, errTruncated) {
+		t.Errorf("expected errTruncated, got %v", err)
+	}
+}
+
+func TestInHosts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts")
+	hosts := "127.0.0.1 localhost\n# 10.0.0.2 commented.example\n10.0.0.1 bothan.internal proxy # the proxy\n"
+	if err := os.WriteFile(path, []byte(hosts), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for host, expected := range map[string]bool{
+		"bothan.internal":   true,
+		"PROXY.":            true,
+		"localhost":         true,
+		"commented.example": false,
+		"the":               false,
+		"10.0.0.1":          false,
+		"bothan.example":    false,
+	} {
+		if got := inHosts(path, host); got != expected {
+			t.Errorf("%s: expected %v, got %v", host, expected, got)
+		}
+	}
+}
+
+// serveDNS answers A queries with the given address and AAAA queries with no records, with
+// the truncated flag set if requested.
+func serveDNS(conn net.PacketConn, a [4]byte, ttl uint32, truncated bool) {
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		var req dnsmessage.Message
+		if err := req.Unpack(buf[:n]); err != nil {
+			continue
+		}
+
+		resp := dnsmessage.Message{
+			Header:    dnsmessage.Header{ID: req.ID, Response: true, Truncated: truncated},
+			Questions: req.Questions,
+		}
+		if q := req.Questions[0]; q.Type == dnsmessage.TypeA {
+			resp.Answers = []dnsmessage.Resource{{
+				Header: dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: q.Class, TTL: ttl},
+				Body:   &dnsmessage.AResource{A: a},
+			}}
+		}
+
+		packet, err := resp.Pack()
+		if err != nil {
+			continue
+		}
+		_, _ = conn.WriteTo(packet, addr)
+	}
+}
+
+func TestPartialDeadline(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		left      time.Duration
+		remaining int
+		expected  time.Duration
+	}{
+		{"last address", 10 * time.Second, 1, 10 * time.Second},
+		{"equal shares", 10 * time.Second, 2, 5 * time.Second},
+		{"minimum share", 10 * time.Second, 10, minDialTimeout},
+		{"less than the minimum left", time.Second, 3, time.Second},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := partialDeadline(now, now.Add(test.left), test.remaining)
+			if want := now.Add(test.expected); !got.Equal(want) {
+				t.Errorf("expected a timeout of %v, got %v", test.expected, got.Sub(now))
+			}
+		})
+	}
+}
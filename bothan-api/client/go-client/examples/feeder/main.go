@@ -0,0 +1,77 @@
+// Command feeder polls the prices of the given signals and reports a new feeds price whenever
+// a price deviates from the last reported one by more than the threshold, the way a BandChain
+// feeder decides when to submit.
+//
+//	go run ./examples/feeder -addr localhost:50051 -threshold 50 crypto_price.btcusd
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	client "github.com/bandprotocol/bothan/bothan-api/client/go-client"
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/feeds"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:50051", "address of the Bothan gRPC server")
+	interval := flag.Duration("interval", 10*time.Second, "interval between queries")
+	threshold := flag.Uint64("threshold", 50, "deviation threshold in basis points")
+	flag.Parse()
+
+	signalIDs := flag.Args()
+	if len(signalIDs) == 0 {
+		signalIDs = []string{"crypto_price.btcusd"}
+	}
+
+	c, err := client.NewGRPC(*addr, *interval)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	reported := make(map[string]uint64)
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	for {
+		prices, err := c.GetPriceMap(ctx, signalIDs)
+		if err != nil {
+			log.Println("error querying prices:", err)
+		}
+
+		for signalID, result := range prices {
+			if result.Err != nil {
+				log.Printf("%s: %v", signalID, result.Err)
+				continue
+			}
+
+			price, err := feeds.ToFeedsPrice(result.Price)
+			if err != nil {
+				log.Printf("%s: %v", signalID, err)
+				continue
+			}
+
+			previous, ok := reported[signalID]
+			if ok && !feeds.ExceedsDeviation(previous, price, *threshold) {
+				continue
+			}
+
+			reported[signalID] = price
+			fmt.Printf("%s: report %d (%s)\n", signalID, price, result.Price)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// Command prices queries the prices of the given signals from a Bothan server.
+//
+//	go run ./examples/prices -addr localhost:50051 crypto_price.btcusd crypto_price.ethusd
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	client "github.com/bandprotocol/bothan/bothan-api/client/go-client"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:50051", "address of the Bothan gRPC server")
+	timeout := flag.Duration("timeout", 5*time.Second, "timeout of the query")
+	flag.Parse()
+
+	signalIDs := flag.Args()
+	if len(signalIDs) == 0 {
+		signalIDs = []string{"crypto_price.btcusd", "crypto_price.ethusd"}
+	}
+
+	c, err := client.NewGRPC(*addr, *timeout)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	prices, err := c.GetPriceMap(context.Background(), signalIDs)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, signalID := range signalIDs {
+		result := prices[signalID]
+		if result.Err != nil {
+			fmt.Printf("%s: %v\n", signalID, result.Err)
+			continue
+		}
+		fmt.Printf("%s: %s\n", signalID, result.Price)
+	}
+}
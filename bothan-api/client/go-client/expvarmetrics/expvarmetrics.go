@@ -0,0 +1,170 @@
+// Package expvarmetrics publishes basic metrics of a Bothan client through expvar, for
+// applications without a metrics system. It is kept out of the client package because
+// importing expvar registers /debug/vars on http.DefaultServeMux.
+//
+//	c := expvarmetrics.NewClient(restClient, "")
+package expvarmetrics
+
+import (
+	"context"
+	"expvar"
+	"time"
+
+	client "github.com/bandprotocol/bothan/bothan-api/client/go-client"
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// DefaultName is the expvar name the metrics are published under by default.
+const DefaultName = "bothan_client"
+
+var (
+	_ client.Client      = &Client{}
+	_ client.PriceMapper = &Client{}
+)
+
+// Client decorates a client.Client with basic metrics published through expvar. The metrics
+// appear under /debug/vars if the application serves expvar.Handler, which importing expvar
+// does on http.DefaultServeMux.
+type Client struct {
+	client client.Client
+
+	queries     *expvar.Int
+	errors      *expvar.Int
+	signals     *expvar.Int
+	unavailable *expvar.Int
+	latency     *expvar.Float
+	lastLatency *expvar.Float
+	lastSuccess *expvar.String
+
+	serverQueue          *expvar.Float
+	serverProcessing     *expvar.Float
+	lastServerQueue      *expvar.Float
+	lastServerProcessing *expvar.Float
+	lastNetwork          *expvar.Float
+}
+
+// NewClient wraps the given client and publishes its metrics under the given expvar name,
+// DefaultName if empty. Clients created with the same name share their metrics.
+func NewClient(c client.Client, name string) *Client {
+	metrics := publishedMap(name)
+
+	return &Client{
+		client:      c,
+		queries:     expvarInt(metrics, "queries"),
+		errors:      expvarInt(metrics, "errors"),
+		signals:     expvarInt(metrics, "signals_requested"),
+		unavailable: expvarInt(metrics, "signals_unavailable"),
+		latency:     expvarFloat(metrics, "latency_seconds_total"),
+		lastLatency: expvarFloat(metrics, "last_latency_seconds"),
+		lastSuccess: expvarString(metrics, "last_success"),
+
+		serverQueue:          expvarFloat(metrics, "server_queue_seconds_total"),
+		serverProcessing:     expvarFloat(metrics, "server_processing_seconds_total"),
+		lastServerQueue:      expvarFloat(metrics, "last_server_queue_seconds"),
+		lastServerProcessing: expvarFloat(metrics, "last_server_processing_seconds"),
+		lastNetwork:          expvarFloat(metrics, "last_network_seconds"),
+	}
+}
+
+func (c *Client) QueryPrices(signalIds []string) ([]*proto.PriceData, error) {
+	start := time.Now()
+	prices, err := c.client.QueryPrices(signalIds)
+	c.record(start, len(signalIds), err)
+	if err == nil {
+		for _, price := range prices {
+			if price.PriceStatus != proto.PriceStatus_PRICE_STATUS_AVAILABLE {
+				c.unavailable.Add(1)
+			}
+		}
+	}
+
+	return prices, err
+}
+
+// GetPriceMap also records the time the server reports to have spent on the query, and the
+// remaining latency as network time, if the server reports it.
+func (c *Client) GetPriceMap(ctx context.Context, signalIds []string) (map[string]client.PriceResult, error) {
+	var timing client.ServerTiming
+	start := time.Now()
+	results, err := client.GetPriceMap(client.WithServerTiming(ctx, &timing), c.client, signalIds)
+	c.record(start, len(signalIds), err)
+	if err == nil && timing.Reported {
+		c.recordServerTiming(time.Since(start), timing)
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			c.unavailable.Add(1)
+		}
+	}
+
+	return results, err
+}
+
+func (c *Client) record(start time.Time, signals int, err error) {
+	latency := time.Since(start).Seconds()
+	c.queries.Add(1)
+	c.signals.Add(int64(signals))
+	c.latency.Add(latency)
+	c.lastLatency.Set(latency)
+	if err != nil {
+		c.errors.Add(1)
+		return
+	}
+	c.lastSuccess.Set(time.Now().UTC().Format(time.RFC3339))
+}
+
+func (c *Client) recordServerTiming(latency time.Duration, timing client.ServerTiming) {
+	c.serverQueue.Add(timing.Queue.Seconds())
+	c.serverProcessing.Add(timing.Processing.Seconds())
+	c.lastServerQueue.Set(timing.Queue.Seconds())
+	c.lastServerProcessing.Set(timing.Processing.Seconds())
+	c.lastNetwork.Set(max(latency-timing.Total(), 0).Seconds())
+}
+
+// PublishPrefetcher publishes the hits, misses and refresh errors of the prefetcher under the
+// given expvar name, DefaultName if empty, next to the metrics of the clients of that name.
+func PublishPrefetcher(p *client.Prefetcher, name string) {
+	metrics := publishedMap(name)
+	metrics.Set("prefetch_hits", expvar.Func(func() any { return p.Stats().Hits }))
+	metrics.Set("prefetch_misses", expvar.Func(func() any { return p.Stats().Misses }))
+	metrics.Set("prefetch_errors", expvar.Func(func() any { return p.Stats().Errors }))
+}
+
+// publishedMap returns the expvar map of the given name, DefaultName if empty, publishing it
+// if needed.
+func publishedMap(name string) *expvar.Map {
+	if name == "" {
+		name = DefaultName
+	}
+	if metrics, ok := expvar.Get(name).(*expvar.Map); ok {
+		return metrics
+	}
+	return expvar.NewMap(name)
+}
+
+func expvarInt(m *expvar.Map, key string) *expvar.Int {
+	if v, ok := m.Get(key).(*expvar.Int); ok {
+		return v
+	}
+	v := new(expvar.Int)
+	m.Set(key, v)
+	return v
+}
+
+func expvarFloat(m *expvar.Map, key string) *expvar.Float {
+	if v, ok := m.Get(key).(*expvar.Float); ok {
+		return v
+	}
+	v := new(expvar.Float)
+	m.Set(key, v)
+	return v
+}
+
+func expvarString(m *expvar.Map, key string) *expvar.String {
+	if v, ok := m.Get(key).(*expvar.String); ok {
+		return v
+	}
+	v := new(expvar.String)
+	m.Set(key, v)
+	return v
+}
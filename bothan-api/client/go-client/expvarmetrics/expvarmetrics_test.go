@@ -0,0 +1,96 @@
+package expvarmetrics
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"testing"
+	"time"
+
+	client "github.com/bandprotocol/bothan/bothan-api/client/go-client"
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+	clientv2 "github.com/bandprotocol/bothan/bothan-api/client/go-client/v2"
+)
+
+type stubClient struct {
+	prices []*proto.PriceData
+	timing *proto.ServerTiming
+	err    error
+}
+
+func (c *stubClient) QueryPrices([]string) ([]*proto.PriceData, error) {
+	return c.prices, c.err
+}
+
+func (c *stubClient) GetPriceMap(ctx context.Context, signalIDs []string) (map[string]client.PriceResult, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	clientv2.ReportServerTiming(ctx, c.timing)
+	return client.NewPriceMap(signalIDs, c.prices, time.Now()), nil
+}
+
+func TestClient(t *testing.T) {
+	stub := &stubClient{prices: []*proto.PriceData{
+		{SignalId: "crypto_price.btcusd", Price: "60000", PriceStatus: proto.PriceStatus_PRICE_STATUS_AVAILABLE},
+		{SignalId: "crypto_price.ethusd", PriceStatus: proto.PriceStatus_PRICE_STATUS_UNAVAILABLE},
+	}, timing: &proto.ServerTiming{QueueTimeUs: 500000, ProcessingTimeUs: 250000}}
+	c := NewClient(stub, "bothan_client_test")
+
+	signalIDs := []string{"crypto_price.btcusd", "crypto_price.ethusd"}
+	if _, err := c.QueryPrices(signalIDs); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetPriceMap(context.Background(), signalIDs); err != nil {
+		t.Fatal(err)
+	}
+	stub.err = errors.New("unavailable")
+	if _, err := c.QueryPrices(signalIDs); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	metrics := expvar.Get("bothan_client_test").(*expvar.Map)
+	expected := map[string]string{
+		"queries":             "3",
+		"errors":              "1",
+		"signals_requested":   "6",
+		"signals_unavailable": "2",
+
+		"server_queue_seconds_total":      "0.5",
+		"server_processing_seconds_total": "0.25",
+		"last_server_queue_seconds":       "0.5",
+		"last_server_processing_seconds":  "0.25",
+		"last_network_seconds":            "0",
+	}
+	for key, value := range expected {
+		if got := metrics.Get(key).String(); got != value {
+			t.Errorf("%s: expected %s, got %s", key, value, got)
+		}
+	}
+
+	// Clients with the same name share their metrics instead of panicking on a duplicate.
+	NewClient(stub, "bothan_client_test")
+	if got := metrics.Get("queries").String(); got != "3" {
+		t.Errorf("expected shared metrics to be kept, got %s queries", got)
+	}
+}
+
+func TestPublishPrefetcher(t *testing.T) {
+	stub := &stubClient{prices: []*proto.PriceData{
+		{SignalId: "crypto_price.btcusd", Price: "60000", PriceStatus: proto.PriceStatus_PRICE_STATUS_AVAILABLE},
+	}}
+	p := client.NewPrefetcher(stub, []string{"crypto_price.btcusd"}, time.Hour)
+	PublishPrefetcher(p, "bothan_client_prefetch_test")
+
+	if _, err := p.GetPriceMap(context.Background(), []string{"crypto_price.btcusd"}); err != nil {
+		t.Fatal(err)
+	}
+
+	metrics := expvar.Get("bothan_client_prefetch_test").(*expvar.Map)
+	if got := metrics.Get("prefetch_misses").String(); got != "1" {
+		t.Errorf("expected 1 miss, got %s", got)
+	}
+	if got := metrics.Get("prefetch_hits").String(); got != "0" {
+		t.Errorf("expected no hit, got %s", got)
+	}
+}
@@ -0,0 +1,172 @@
+// Package feeds converts Bothan prices into the representation used by the BandChain feeds
+// module.
+package feeds
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+const (
+	// PriceDecimals is the number of decimals kept by the feeds module.
+	PriceDecimals = 9
+	// PriceMultiplier is the factor between a decimal price and its feeds representation.
+	PriceMultiplier uint64 = 1_000_000_000
+	// BasisPoints is the number of basis points in 100%.
+	BasisPoints uint64 = 10_000
+	// MaxScale is the largest scale, positive or negative, accepted by ToFeedsPriceFixed.
+	MaxScale = 77
+)
+
+var (
+	ErrInvalidPrice  = errors.New("invalid price")
+	ErrPriceOverflow = errors.New("price overflows uint64")
+	ErrPrecisionLoss = errors.New("price loses precision")
+)
+
+// ToFeedsPrice converts a decimal price as returned by Bothan, e.g. "67012.123456789123",
+// into the price submitted to the feeds module, which is the price multiplied by 10^9.
+// Decimals beyond the ninth are truncated, matching the integer conversion done by feeders.
+func ToFeedsPrice(price string) (uint64, error) {
+	integer, fraction, err := splitDecimal(price)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(fraction) > PriceDecimals {
+		fraction = fraction[:PriceDecimals]
+	}
+	fraction += strings.Repeat("0", PriceDecimals-len(fraction))
+
+	value, ok := new(big.Int).SetString(integer+fraction, 10)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidPrice, price)
+	}
+	if !value.IsUint64() {
+		return 0, fmt.Errorf("%w: %q", ErrPriceOverflow, price)
+	}
+
+	return value.Uint64(), nil
+}
+
+// ToFeedsPriceChecked is like ToFeedsPrice but fails with ErrPrecisionLoss instead of
+// silently truncating, i.e. if the price has non-zero digits beyond the ninth decimal. Every
+// other price converts exactly unless it overflows, which fails with ErrPriceOverflow.
+func ToFeedsPriceChecked(price string) (uint64, error) {
+	_, fraction, err := splitDecimal(price)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(fraction) > PriceDecimals && strings.Trim(fraction[PriceDecimals:], "0") != "" {
+		return 0, fmt.Errorf("%w: %q has more than %d decimals", ErrPrecisionLoss, price, PriceDecimals)
+	}
+
+	return ToFeedsPrice(price)
+}
+
+// ToFeedsPriceFixed converts a fixed-point price, mantissa × 10^-scale, into the price
+// submitted to the feeds module. Like ToFeedsPrice, digits beyond the ninth decimal are
+// truncated. Scales beyond MaxScale in either direction fail with ErrInvalidPrice.
+func ToFeedsPriceFixed(mantissa *big.Int, scale int32) (uint64, error) {
+	if mantissa == nil || mantissa.Sign() < 0 {
+		return 0, fmt.Errorf("%w: mantissa %v", ErrInvalidPrice, mantissa)
+	}
+	if scale < -MaxScale || scale > MaxScale {
+		return 0, fmt.Errorf("%w: scale %d", ErrInvalidPrice, scale)
+	}
+
+	value := new(big.Int).Set(mantissa)
+	exp := new(big.Int).Abs(big.NewInt(int64(PriceDecimals) - int64(scale)))
+	factor := new(big.Int).Exp(big.NewInt(10), exp, nil)
+	if scale < PriceDecimals {
+		value.Mul(value, factor)
+	} else {
+		value.Quo(value, factor)
+	}
+	if !value.IsUint64() {
+		return 0, fmt.Errorf("%w: mantissa %s with scale %d", ErrPriceOverflow, mantissa, scale)
+	}
+
+	return value.Uint64(), nil
+}
+
+// MulPrice multiplies a feeds price by a factor, failing with ErrPriceOverflow instead of
+// wrapping around.
+func MulPrice(price, factor uint64) (uint64, error) {
+	hi, lo := bits.Mul64(price, factor)
+	if hi != 0 {
+		return 0, fmt.Errorf("%w: %d * %d", ErrPriceOverflow, price, factor)
+	}
+
+	return lo, nil
+}
+
+// FromFeedsPrice converts a feeds price back into its decimal representation without
+// trailing zeros, e.g. 67012123456789 becomes "67012.123456789".
+func FromFeedsPrice(price uint64) string {
+	integer := price / PriceMultiplier
+	fraction := price % PriceMultiplier
+	if fraction == 0 {
+		return fmt.Sprintf("%d", integer)
+	}
+
+	return strings.TrimRight(fmt.Sprintf("%d.%09d", integer, fraction), "0")
+}
+
+// DeviationBasisPoints returns the deviation of current from previous in basis points,
+// rounded down. A zero previous price is treated as an infinite deviation.
+func DeviationBasisPoints(previous, current uint64) uint64 {
+	if previous == 0 {
+		if current == 0 {
+			return 0
+		}
+		return ^uint64(0)
+	}
+
+	diff := current - previous
+	if current < previous {
+		diff = previous - current
+	}
+
+	deviation := new(big.Int).SetUint64(diff)
+	deviation.Mul(deviation, new(big.Int).SetUint64(BasisPoints))
+	deviation.Quo(deviation, new(big.Int).SetUint64(previous))
+	if !deviation.IsUint64() {
+		return ^uint64(0)
+	}
+
+	return deviation.Uint64()
+}
+
+// ExceedsDeviation reports whether current deviates from previous by at least threshold basis
+// points, which is when the feeds module expects a new price to be submitted.
+func ExceedsDeviation(previous, current, threshold uint64) bool {
+	return DeviationBasisPoints(previous, current) >= threshold
+}
+
+// splitDecimal splits a non-negative decimal string into its integer and fraction digits.
+func splitDecimal(price string) (string, string, error) {
+	integer, fraction, _ := strings.Cut(price, ".")
+	if integer == "" && fraction == "" {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidPrice, price)
+	}
+	if !isDigits(integer) || !isDigits(fraction) {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidPrice, price)
+	}
+
+	return integer, fraction, nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,204 @@
+package feeds
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+// loadVectors reads the test vectors of testdata. The vectors were computed by hand from the
+// rules documented in this package, a 10^9 multiplier truncated towards zero and deviations
+// in basis points truncated towards zero, and were not taken from the BandChain feeds
+// module. They pin the behaviour of this package but would not catch the chain changing its
+// rules.
+func loadVectors(t *testing.T, path string, v interface{}) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestToFeedsPriceGolden(t *testing.T) {
+	var vectors []struct {
+		Price      string `json:"price"`
+		FeedsPrice uint64 `json:"feeds_price"`
+	}
+	loadVectors(t, "testdata/prices.json", &vectors)
+
+	for _, v := range vectors {
+		got, err := ToFeedsPrice(v.Price)
+		if err != nil {
+			t.Errorf("ToFeedsPrice(%q): unexpected error %v", v.Price, err)
+			continue
+		}
+		if got != v.FeedsPrice {
+			t.Errorf("ToFeedsPrice(%q) = %d, expected %d", v.Price, got, v.FeedsPrice)
+		}
+
+		// Converting back and forth must be lossless once truncated to the feeds precision.
+		if again, _ := ToFeedsPrice(FromFeedsPrice(got)); again != got {
+			t.Errorf("round trip of %d gave %d", got, again)
+		}
+	}
+}
+
+func TestToFeedsPriceErrors(t *testing.T) {
+	invalid := []string{"", ".", "-1", "1e9", "1,5", "NaN", "inf", " 1", "1.2.3"}
+	for _, price := range invalid {
+		if _, err := ToFeedsPrice(price); !errors.Is(err, ErrInvalidPrice) {
+			t.Errorf("ToFeedsPrice(%q): expected ErrInvalidPrice, got %v", price, err)
+		}
+	}
+
+	if _, err := ToFeedsPrice("18446744073.709551616"); !errors.Is(err, ErrPriceOverflow) {
+		t.Errorf("expected ErrPriceOverflow, got %v", err)
+	}
+}
+
+func TestToFeedsPriceFixed(t *testing.T) {
+	var vectors []struct {
+		Price      string `json:"price"`
+		FeedsPrice uint64 `json:"feeds_price"`
+	}
+	loadVectors(t, "testdata/prices.json", &vectors)
+
+	// The fixed-point form of every golden price converts to the same feeds price.
+	for _, v := range vectors {
+		integer, fraction, _ := strings.Cut(v.Price, ".")
+		mantissa, _ := new(big.Int).SetString(integer+fraction, 10)
+		got, err := ToFeedsPriceFixed(mantissa, int32(len(fraction)))
+		if err != nil {
+			t.Errorf("ToFeedsPriceFixed(%q): unexpected error %v", v.Price, err)
+			continue
+		}
+		if got != v.FeedsPrice {
+			t.Errorf("ToFeedsPriceFixed(%q) = %d, expected %d", v.Price, got, v.FeedsPrice)
+		}
+	}
+
+	if got, _ := ToFeedsPriceFixed(big.NewInt(5), -2); got != 500*PriceMultiplier {
+		t.Errorf("expected a negative scale to multiply the mantissa, got %d", got)
+	}
+	if _, err := ToFeedsPriceFixed(big.NewInt(-1), 0); !errors.Is(err, ErrInvalidPrice) {
+		t.Errorf("expected ErrInvalidPrice, got %v", err)
+	}
+	if _, err := ToFeedsPriceFixed(big.NewInt(18446744074), 0); !errors.Is(err, ErrPriceOverflow) {
+		t.Errorf("expected ErrPriceOverflow, got %v", err)
+	}
+
+	for _, scale := range []int32{MaxScale + 1, -MaxScale - 1, math.MaxInt32, math.MinInt32} {
+		if _, err := ToFeedsPriceFixed(big.NewInt(1), scale); !errors.Is(err, ErrInvalidPrice) {
+			t.Errorf("expected ErrInvalidPrice for scale %d, got %v", scale, err)
+		}
+	}
+	if got, err := ToFeedsPriceFixed(big.NewInt(1), MaxScale); err != nil || got != 0 {
+		t.Errorf("expected the largest scale to truncate to 0, got %d, %v", got, err)
+	}
+}
+
+func TestFromFeedsPrice(t *testing.T) {
+	cases := map[uint64]string{
+		0:              "0",
+		1:              "0.000000001",
+		1000000000:     "1",
+		3421500000000:  "3421.5",
+		67012123456789: "67012.123456789",
+	}
+	for price, expected := range cases {
+		if got := FromFeedsPrice(price); got != expected {
+			t.Errorf("FromFeedsPrice(%d) = %q, expected %q", price, got, expected)
+		}
+	}
+}
+
+func TestDeviationBasisPointsGolden(t *testing.T) {
+	var vectors []struct {
+		Previous    uint64 `json:"previous"`
+		Current     uint64 `json:"current"`
+		BasisPoints uint64 `json:"basis_points"`
+	}
+	loadVectors(t, "testdata/deviations.json", &vectors)
+
+	for _, v := range vectors {
+		if got := DeviationBasisPoints(v.Previous, v.Current); got != v.BasisPoints {
+			t.Errorf("DeviationBasisPoints(%d, %d) = %d, expected %d", v.Previous, v.Current, got, v.BasisPoints)
+		}
+		if !ExceedsDeviation(v.Previous, v.Current, v.BasisPoints) {
+			t.Errorf("ExceedsDeviation(%d, %d, %d) = false", v.Previous, v.Current, v.BasisPoints)
+		}
+	}
+
+	if !ExceedsDeviation(0, 1, 10_000) {
+		t.Error("a zero previous price must always exceed the deviation")
+	}
+}
+
+func TestToFeedsPriceChecked(t *testing.T) {
+	valid := map[string]uint64{
+		"67012.123456789":         67012123456789,
+		"67012.123456789000":      67012123456789,
+		"0.000000001":             1,
+		"1200":                    1200_000_000_000,
+		"999999999.999999999":     999999999999999999,
+		"0000001.500000000000000": 1500000000,
+		"1234567890.123456789":    1234567890123456789,
+		"18446744073.709551615":   18446744073709551615,
+	}
+	for price, expected := range valid {
+		got, err := ToFeedsPriceChecked(price)
+		if err != nil {
+			t.Errorf("ToFeedsPriceChecked(%q): unexpected error %v", price, err)
+			continue
+		}
+		if got != expected {
+			t.Errorf("ToFeedsPriceChecked(%q) = %d, expected %d", price, got, expected)
+		}
+	}
+
+	lossy := []string{"0.0000000001", "67012.1234567891", "18446744073.7095516151"}
+	for _, price := range lossy {
+		if _, err := ToFeedsPriceChecked(price); !errors.Is(err, ErrPrecisionLoss) {
+			t.Errorf("ToFeedsPriceChecked(%q): expected ErrPrecisionLoss, got %v", price, err)
+		}
+	}
+
+	if _, err := ToFeedsPriceChecked("18446744073.709551616"); !errors.Is(err, ErrPriceOverflow) {
+		t.Errorf("expected ErrPriceOverflow, got %v", err)
+	}
+	if _, err := ToFeedsPriceChecked("-1"); !errors.Is(err, ErrInvalidPrice) {
+		t.Errorf("expected ErrInvalidPrice, got %v", err)
+	}
+}
+
+func TestFeedsPriceRoundTripProperty(t *testing.T) {
+	roundTrip := func(price uint64) bool {
+		got, err := ToFeedsPriceChecked(FromFeedsPrice(price))
+		return err == nil && got == price
+	}
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMulPriceProperty(t *testing.T) {
+	mul := func(price, factor uint64) bool {
+		expected := new(big.Int).Mul(new(big.Int).SetUint64(price), new(big.Int).SetUint64(factor))
+		got, err := MulPrice(price, factor)
+		if !expected.IsUint64() {
+			return errors.Is(err, ErrPriceOverflow)
+		}
+		return err == nil && got == expected.Uint64()
+	}
+	if err := quick.Check(mul, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -3,17 +3,27 @@ module github.com/bandprotocol/bothan/bothan-api/client/go-client
 go 1.22.0
 
 require (
-	github.com/grpc-ecosystem/grpc-gateway/v2 v2.19.1
+	github.com/bandprotocol/bothan/bothan-api/client/go-client/query v0.1.0
+	github.com/bandprotocol/bothan/bothan-api/client/go-client/v2 v2.0.0
+	github.com/json-iterator/go v1.1.12
 	github.com/levigross/grequests v0.0.0-20231203190023-9c307ef1f48d
-	google.golang.org/genproto/googleapis/api v0.0.0-20240506185236-b8a5c65736ae
+	golang.org/x/net v0.21.0
+	golang.org/x/time v0.5.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240429193739-8cf5692501f6
 	google.golang.org/grpc v1.63.2
 	google.golang.org/protobuf v1.34.1
 )
 
 require (
 	github.com/google/go-querystring v1.1.0 // indirect
-	golang.org/x/net v0.21.0 // indirect
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.19.1 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180228061459-e0a39a4cb421 // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
 	golang.org/x/sys v0.17.0 // indirect
 	golang.org/x/text v0.14.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20240429193739-8cf5692501f6 // indirect
+	google.golang.org/genproto/googleapis/api v0.0.0-20240506185236-b8a5c65736ae // indirect
 )
+
+replace github.com/bandprotocol/bothan/bothan-api/client/go-client/query => ./query
+
+replace github.com/bandprotocol/bothan/bothan-api/client/go-client/v2 => ./v2
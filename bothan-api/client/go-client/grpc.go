@@ -2,39 +2,160 @@ package client
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/connectivity"
 
 	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+	clientv2 "github.com/bandprotocol/bothan/bothan-api/client/go-client/v2"
 )
 
-var _ Client = &GRPC{}
+var (
+	_ Client          = &GRPC{}
+	_ PriceMapper     = &GRPC{}
+	_ SignalActivator = &GRPC{}
+)
 
+// GRPC is a thin wrapper around the client of version 2, which new code should use directly.
 type GRPC struct {
-	connection *grpc.ClientConn
-	timeout    time.Duration
+	client *clientv2.Client
 }
 
-func NewGRPC(url string, timeout time.Duration) (*GRPC, error) {
-	connection, err := grpc.Dial(url, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// NewGRPC creates a new gRPC client connected to the given url. Additional dial options are
+// applied after the default ones and can override them.
+func NewGRPC(url string, timeout time.Duration, opts ...grpc.DialOption) (*GRPC, error) {
+	c, err := clientv2.New(url, clientv2.WithTimeout(timeout), clientv2.WithDialOptions(opts...))
 	if err != nil {
 		return nil, err
 	}
-	return &GRPC{connection, timeout}, nil
+	return &GRPC{c}, nil
+}
+
+// NewGRPCFromConn creates a new gRPC client on an existing connection, e.g. one shared with
+// other gRPC clients of the application.
+func NewGRPCFromConn(conn grpc.ClientConnInterface, timeout time.Duration) *GRPC {
+	return &GRPC{clientv2.NewFromConn(conn, clientv2.WithTimeout(timeout))}
+}
+
+// Raw returns the generated gRPC client of the query service, for methods the client does not
+// wrap yet.
+func (c *GRPC) Raw() proto.QueryClient {
+	return c.client.Raw()
+}
+
+// Close closes the connection of the client, unless it was created with NewGRPCFromConn.
+func (c *GRPC) Close() error {
+	return c.client.Close()
+}
+
+// State returns the state of the connection of the client.
+func (c *GRPC) State() connectivity.State {
+	return c.client.State()
+}
+
+// WaitForReady connects the client if its connection is idle and blocks until the connection
+// is ready, the context is done or the connection is closed, see clientv2.Client.WaitForReady.
+func (c *GRPC) WaitForReady(ctx context.Context) error {
+	return c.client.WaitForReady(ctx)
 }
 
+// DebugReport returns a JSON snapshot of the client for support requests, see
+// clientv2.Client.DebugReport.
+func (c *GRPC) DebugReport() ([]byte, error) {
+	return c.client.DebugReport()
+}
+
+// QueryPrices returns the prices of the response of the server unchanged, like version 1 did.
 func (c *GRPC) QueryPrices(signalIds []string) ([]*proto.PriceData, error) {
-	// Create a client instance using the connection.
-	client := proto.NewQueryClient(c.connection)
-	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
-	defer cancel()
+	resp, err := c.client.PricesResponse(context.Background(), signalIds)
+	if err != nil {
+		return nil, unwrapCallError(err)
+	}
+
+	return resp.Prices, nil
+}
 
-	response, err := client.Prices(ctx, &proto.QueryPricesRequest{SignalIds: signalIds})
+// SetActiveSignalIds activates the signals with a price query, see SignalActivator.
+func (c *GRPC) SetActiveSignalIds(signalIds []string) error {
+	resp, err := c.client.PricesResponse(context.Background(), signalIds)
+	if err != nil {
+		return unwrapCallError(err)
+	}
+
+	return checkActivated(resp.Prices)
+}
+
+func (c *GRPC) GetPriceMap(ctx context.Context, signalIds []string) (map[string]PriceResult, error) {
+	prices, err := c.getPrices(ctx, signalIds)
 	if err != nil {
 		return nil, err
 	}
 
-	return response.Prices, nil
+	results := make(map[string]PriceResult, len(prices))
+	for _, price := range prices {
+		results[price.SignalID] = PriceResult{
+			Price:     price.Value,
+			Status:    price.Status,
+			Timestamp: price.Time,
+			Err:       errors.Unwrap(price.Err),
+		}
+	}
+
+	return results, nil
+}
+
+// ListSignals returns every signal known to the registry of the server, querying it page by
+// page.
+func (c *GRPC) ListSignals(ctx context.Context) ([]*proto.SignalInfo, error) {
+	signals, err := c.client.Signals(ctx)
+	return signals, unwrapCallError(err)
+}
+
+// Registry returns the registry the server loaded, see clientv2.Client.Registry.
+func (c *GRPC) Registry(ctx context.Context) (*proto.QueryRegistryResponse, error) {
+	resp, err := c.client.Registry(ctx)
+	return resp, unwrapCallError(err)
+}
+
+// StatsSummary returns the summary of the signals of the server, see
+// clientv2.Client.StatsSummary.
+func (c *GRPC) StatsSummary(ctx context.Context) (*proto.QueryStatsSummaryResponse, error) {
+	resp, err := c.client.StatsSummary(ctx)
+	return resp, unwrapCallError(err)
+}
+
+// ActiveSignalIDs returns the signal IDs the server keeps up to date, see
+// clientv2.Client.ActiveSignalIDs.
+func (c *GRPC) ActiveSignalIDs(ctx context.Context) ([]string, error) {
+	signalIDs, err := c.client.ActiveSignalIDs(ctx)
+	return signalIDs, unwrapCallError(err)
+}
+
+// Healthy checks that the server is live with the standard gRPC health checking protocol, see
+// clientv2.Client.Healthy.
+func (c *GRPC) Healthy(ctx context.Context) error {
+	return unwrapCallError(c.client.Healthy(ctx))
+}
+
+// ServerVersion returns the version the server reports, or an empty version for servers that
+// predate the reporting, see clientv2.Client.ServerVersion.
+func (c *GRPC) ServerVersion(ctx context.Context) (string, error) {
+	version, err := c.client.ServerVersion(ctx)
+	return version, unwrapCallError(err)
+}
+
+func (c *GRPC) getPrices(ctx context.Context, signalIds []string) ([]clientv2.Price, error) {
+	prices, err := c.client.Prices(ctx, signalIds)
+	return prices, unwrapCallError(err)
+}
+
+// unwrapCallError returns the gRPC error of a failed call, which is what version 1 returned.
+func unwrapCallError(err error) error {
+	var callErr *clientv2.CallError
+	if errors.As(err, &callErr) {
+		return callErr.Err
+	}
+	return err
 }
@@ -0,0 +1,13 @@
+package client
+
+import (
+	"google.golang.org/grpc"
+
+	clientv2 "github.com/bandprotocol/bothan/bothan-api/client/go-client/v2"
+)
+
+// WithHeaders returns a dial option for NewGRPC that sends the given metadata with every
+// call, see the option of the same name in v2. The REST client takes WithRestHeaders.
+func WithHeaders(headers map[string]string) grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(clientv2.HeadersInterceptor(headers))
+}
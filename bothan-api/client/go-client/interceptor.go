@@ -0,0 +1,24 @@
+package client
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+
+	clientv2 "github.com/bandprotocol/bothan/bothan-api/client/go-client/v2"
+)
+
+// WithUnaryInterceptor returns a dial option for NewGRPC that chains the given interceptors,
+// e.g. for logging, auth or metrics, into the connection of the client, in the order given.
+func WithUnaryInterceptor(interceptors ...grpc.UnaryClientInterceptor) grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(interceptors...)
+}
+
+// WithClockSkewWarning returns a dial option for NewGRPC that reports a WarningClockSkew to h
+// whenever the time reported by the server differs from the local clock by more than the
+// threshold, see clientv2.WithClockSkewCheck.
+func WithClockSkewWarning(threshold time.Duration, h WarningHandler) grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(clientv2.ClockSkewInterceptor(threshold, func(skew time.Duration) {
+		h.warn(Warning{Kind: WarningClockSkew, Skew: skew})
+	}))
+}
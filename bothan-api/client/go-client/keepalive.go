@@ -0,0 +1,19 @@
+package client
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// WithKeepalive returns a dial option for NewGRPC that pings the server after the given
+// interval without activity and closes the connection if a ping is not acknowledged within
+// the timeout, see the option of the same name in v2.
+func WithKeepalive(interval, timeout time.Duration, permitWithoutStream bool) grpc.DialOption {
+	return grpc.WithKeepaliveParams(keepalive.ClientParameters{
+		Time:                interval,
+		Timeout:             timeout,
+		PermitWithoutStream: permitWithoutStream,
+	})
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+
+	clientv2 "github.com/bandprotocol/bothan/bothan-api/client/go-client/v2"
+)
+
+// Logger receives the log records of the calls of a client, see the type of the same name in
+// v2. *slog.Logger implements it.
+type Logger = clientv2.Logger
+
+// WithLogger returns a dial option for NewGRPC that logs every call, see the option of the
+// same name in v2. The REST client takes WithRestLogger.
+func WithLogger(l Logger) grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(clientv2.LoggerInterceptor(l))
+}
+
+// WithRestLogger logs the start and the end of every request of the client at debug level, and
+// failed attempts, which are retried on the other proxies, and failed requests at warn level,
+// with the path, the queried signal IDs and the duration. Health probes rejected for the
+// credentials of the client are logged at error level.
+func WithRestLogger(l Logger) RestOption {
+	return func(c *RestClient) {
+		c.logger = l
+	}
+}
+
+// restLog logs the requests of a RestClient, and nothing if its logger is nil.
+type restLog struct {
+	logger Logger
+	attrs  []slog.Attr
+	start  time.Time
+}
+
+func (c *RestClient) startLog(ctx context.Context, attrs ...slog.Attr) *restLog {
+	l := &restLog{logger: c.logger, attrs: attrs, start: time.Now()}
+	l.log(ctx, slog.LevelDebug, "bothan request started")
+	return l
+}
+
+func (l *restLog) retry(ctx context.Context, url string, err error) {
+	l.log(ctx, slog.LevelWarn, "bothan request attempt failed", slog.String("url", url), slog.String("error", err.Error()))
+}
+
+func (l *restLog) finish(ctx context.Context, err error) {
+	duration := slog.Duration("duration", time.Since(l.start))
+	if err != nil {
+		l.log(ctx, slog.LevelWarn, "bothan request failed", duration, slog.String("error", err.Error()))
+		return
+	}
+	l.log(ctx, slog.LevelDebug, "bothan request finished", duration)
+}
+
+func (l *restLog) log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if l.logger == nil {
+		return
+	}
+	l.logger.LogAttrs(ctx, level, msg, append(l.attrs[:len(l.attrs):len(l.attrs)], attrs...)...)
+}
@@ -0,0 +1,146 @@
+// Package logging provides gRPC interceptors that log whole requests and responses of a
+// sample of the calls and of every failed call, to debug intermittent issues such as bad
+// price reports without logging every call.
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultMaxSize = 4096
+	redacted       = "[REDACTED]"
+	gatewayPrefix  = "grpcgateway-"
+)
+
+// defaultRedactedKeys are the metadata keys whose values are never logged.
+var defaultRedactedKeys = []string{"authorization", "x-api-key", "cookie"}
+
+// Config defines which calls are logged and how.
+type Config struct {
+	// SampleRate is the probability of a successful call being logged, between 0 and 1.
+	// Failed calls are always logged, so 0 logs failed calls only.
+	SampleRate float64
+	// MaxSize is the maximum number of bytes logged per request or response, 4096 if zero.
+	MaxSize int
+	// RedactedKeys are metadata keys whose values are replaced in the log, in addition to
+	// authorization, x-api-key and cookie.
+	RedactedKeys []string
+	// Logger receives the log records, slog.Default() if nil.
+	Logger *slog.Logger
+}
+
+type logger struct {
+	config   Config
+	redacted map[string]struct{}
+}
+
+func newLogger(config Config) *logger {
+	if config.MaxSize <= 0 {
+		config.MaxSize = defaultMaxSize
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+
+	keys := make(map[string]struct{})
+	for _, key := range append(defaultRedactedKeys, config.RedactedKeys...) {
+		keys[strings.ToLower(key)] = struct{}{}
+	}
+
+	return &logger{config: config, redacted: keys}
+}
+
+// UnaryClientInterceptor returns an interceptor that logs the calls of a client, see Config.
+func UnaryClientInterceptor(config Config) grpc.UnaryClientInterceptor {
+	l := newLogger(config)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		md, _ := metadata.FromOutgoingContext(ctx)
+		l.log(ctx, "grpc client call", method, md, req, reply, err, time.Since(start))
+		return err
+	}
+}
+
+// UnaryServerInterceptor returns an interceptor that logs the calls handled by a server, see
+// Config.
+func UnaryServerInterceptor(config Config) grpc.UnaryServerInterceptor {
+	l := newLogger(config)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		l.log(ctx, "grpc server call", info.FullMethod, md, req, resp, err, time.Since(start))
+		return resp, err
+	}
+}
+
+func (l *logger) log(ctx context.Context, msg, method string, md metadata.MD, req, resp any, err error, duration time.Duration) {
+	if err == nil && !(l.config.SampleRate > 0 && rand.Float64() < l.config.SampleRate) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.Duration("duration", duration),
+		slog.String("code", status.Code(err).String()),
+		slog.Any("metadata", l.redact(md)),
+		slog.String("request", l.marshal(req)),
+	}
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.String("error", err.Error()))
+	} else {
+		attrs = append(attrs, slog.String("response", l.marshal(resp)))
+	}
+
+	l.config.Logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// redact returns a copy of the metadata with the values of sensitive keys replaced.
+func (l *logger) redact(md metadata.MD) map[string][]string {
+	result := make(map[string][]string, len(md))
+	for key, values := range md {
+		// The gRPC gateway forwards HTTP headers with a prefix, e.g. grpcgateway-authorization.
+		name := strings.TrimPrefix(strings.ToLower(key), gatewayPrefix)
+		if _, ok := l.redacted[name]; ok {
+			result[key] = []string{redacted}
+			continue
+		}
+		result[key] = values
+	}
+
+	return result
+}
+
+// marshal renders a message as JSON, truncated to the configured size.
+func (l *logger) marshal(v any) string {
+	msg, ok := v.(proto.Message)
+	if !ok || msg == nil {
+		return ""
+	}
+
+	b, err := protojson.Marshal(msg)
+	if err != nil {
+		return err.Error()
+	}
+	if len(b) > l.config.MaxSize {
+		return string(b[:l.config.MaxSize]) + "...(truncated)"
+	}
+
+	return string(b)
+}
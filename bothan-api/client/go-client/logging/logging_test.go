@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	var buf bytes.Buffer
+	interceptor := UnaryServerInterceptor(Config{
+		MaxSize: 32,
+		Logger:  slog.New(slog.NewJSONHandler(&buf, nil)),
+	})
+	info := &grpc.UnaryServerInfo{FullMethod: "/query.Query/Prices"}
+	req := &query.QueryPricesRequest{SignalIds: []string{"crypto_price.btcusd", "crypto_price.ethusd"}}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret", "x-request-id", "1"))
+
+	// Successful calls are not logged with a zero sample rate.
+	ok := func(context.Context, any) (any, error) { return &query.QueryPricesResponse{}, nil }
+	if _, err := interceptor(ctx, req, info, ok); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected no log, got %s", buf.String())
+	}
+
+	failed := func(context.Context, any) (any, error) { return nil, errors.New("boom") }
+	if _, err := interceptor(ctx, req, info, failed); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	var record struct {
+		Method   string              `json:"method"`
+		Request  string              `json:"request"`
+		Metadata map[string][]string `json:"metadata"`
+		Error    string              `json:"error"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("invalid log %q: %v", buf.String(), err)
+	}
+	if record.Method != info.FullMethod || record.Error != "boom" {
+		t.Errorf("unexpected record %+v", record)
+	}
+	if !strings.HasSuffix(record.Request, "...(truncated)") {
+		t.Errorf("expected a truncated request, got %q", record.Request)
+	}
+	if got := record.Metadata["authorization"]; len(got) != 1 || got[0] != redacted {
+		t.Errorf("expected the authorization to be redacted, got %v", got)
+	}
+	if got := record.Metadata["x-request-id"]; len(got) != 1 || got[0] != "1" {
+		t.Errorf("expected the request id to be kept, got %v", got)
+	}
+}
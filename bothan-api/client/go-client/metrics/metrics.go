@@ -0,0 +1,117 @@
+// Package metrics records Prometheus metrics of the calls of a Bothan client: the number of
+// calls and failed calls and the latency of the calls, per method.
+//
+//	c, err := metrics.NewClient(grpcClient, prometheus.DefaultRegisterer)
+//
+// It is a separate module so that the client does not depend on Prometheus.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	client "github.com/bandprotocol/bothan/bothan-api/client/go-client"
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+var (
+	_ client.Client       = &Client{}
+	_ client.PriceMapper  = &Client{}
+	_ client.SignalLister = &Client{}
+)
+
+// Client decorates a client.Client with Prometheus metrics, labeled by method, e.g.
+// client.MethodQueryPrices:
+//
+//   - bothan_client_requests_total counts the calls.
+//   - bothan_client_errors_total counts the failed calls.
+//   - bothan_client_request_duration_seconds is a histogram of the latency of the calls.
+type Client struct {
+	client   client.Client
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+}
+
+// NewClient wraps the given client and registers its metrics on the given registerer.
+// Clients registered on the same registerer share their metrics.
+func NewClient(c client.Client, registerer prometheus.Registerer) (*Client, error) {
+	requests, err := register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "bothan_client_requests_total",
+		Help: "Number of calls of the Bothan client.",
+	}, []string{"method"}))
+	if err != nil {
+		return nil, err
+	}
+
+	errs, err := register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "bothan_client_errors_total",
+		Help: "Number of failed calls of the Bothan client.",
+	}, []string{"method"}))
+	if err != nil {
+		return nil, err
+	}
+
+	latency, err := register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "bothan_client_request_duration_seconds",
+		Help:    "Latency of the calls of the Bothan client.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{client: c, requests: requests, errors: errs, latency: latency}, nil
+}
+
+// register registers the given collector, or returns the collector already registered in its
+// place.
+func register[C prometheus.Collector](registerer prometheus.Registerer, collector C) (C, error) {
+	err := registerer.Register(collector)
+	var registered prometheus.AlreadyRegisteredError
+	if errors.As(err, &registered) {
+		if existing, ok := registered.ExistingCollector.(C); ok {
+			return existing, nil
+		}
+	}
+	return collector, err
+}
+
+func (c *Client) QueryPrices(signalIds []string) ([]*proto.PriceData, error) {
+	start := time.Now()
+	prices, err := c.client.QueryPrices(signalIds)
+	c.record(client.MethodQueryPrices, start, err)
+	return prices, err
+}
+
+func (c *Client) GetPriceMap(ctx context.Context, signalIds []string) (map[string]client.PriceResult, error) {
+	start := time.Now()
+	prices, err := client.GetPriceMap(ctx, c.client, signalIds)
+	c.record(client.MethodGetPriceMap, start, err)
+	return prices, err
+}
+
+// ListSignals lists the signals with the wrapped client, which must implement
+// client.SignalLister.
+func (c *Client) ListSignals(ctx context.Context) ([]*proto.SignalInfo, error) {
+	lister, ok := c.client.(client.SignalLister)
+	if !ok {
+		return nil, errors.New("client cannot list signals")
+	}
+
+	start := time.Now()
+	signals, err := lister.ListSignals(ctx)
+	c.record(client.MethodListSignals, start, err)
+	return signals, err
+}
+
+func (c *Client) record(method string, start time.Time, err error) {
+	c.requests.WithLabelValues(method).Inc()
+	c.latency.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		c.errors.WithLabelValues(method).Inc()
+	}
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	client "github.com/bandprotocol/bothan/bothan-api/client/go-client"
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+type stubClient struct {
+	err error
+}
+
+func (c stubClient) QueryPrices([]string) ([]*proto.PriceData, error) {
+	return nil, c.err
+}
+
+func (c stubClient) GetPriceMap(context.Context, []string) (map[string]client.PriceResult, error) {
+	return nil, c.err
+}
+
+func TestClient(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	ok, err := NewClient(stubClient{}, registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	failing, err := NewClient(stubClient{err: errors.New("down")}, registry)
+	if err != nil {
+		t.Fatalf("expected clients on the same registerer to share their metrics: %v", err)
+	}
+
+	_, _ = ok.QueryPrices(nil)
+	_, _ = ok.GetPriceMap(context.Background(), nil)
+	_, _ = failing.GetPriceMap(context.Background(), nil)
+	if _, err := ok.ListSignals(context.Background()); err == nil {
+		t.Error("expected ListSignals to fail for a client that cannot list signals")
+	}
+
+	if n := testutil.ToFloat64(ok.requests.WithLabelValues(client.MethodGetPriceMap)); n != 2 {
+		t.Errorf("expected 2 GetPriceMap calls, got %v", n)
+	}
+	if n := testutil.ToFloat64(ok.errors.WithLabelValues(client.MethodGetPriceMap)); n != 1 {
+		t.Errorf("expected 1 failed GetPriceMap call, got %v", n)
+	}
+	if n := testutil.ToFloat64(ok.requests.WithLabelValues(client.MethodQueryPrices)); n != 1 {
+		t.Errorf("expected 1 QueryPrices call, got %v", n)
+	}
+	if n := testutil.CollectAndCount(registry, "bothan_client_request_duration_seconds"); n != 2 {
+		t.Errorf("expected the latency of 2 methods, got %d", n)
+	}
+}
@@ -0,0 +1,187 @@
+// Package pipeline derives feeds from the prices of one or more Bothan servers on the client,
+// e.g. the median of several servers averaged over time, without changing the servers.
+//
+// A Pipeline runs the observations of every signal through a chain of stages, which filter
+// them, check them or reduce them to a single price.
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+var (
+	// ErrNoObservations is reported for signals whose observations were all filtered out.
+	ErrNoObservations = errors.New("no observations left")
+	// ErrTooFewSources is reported by MinSources for signals observed by too few sources.
+	ErrTooFewSources = errors.New("too few sources")
+	// ErrNotReduced is reported for signals with more than one observation after the last
+	// stage, which means the pipeline lacks a stage like Median.
+	ErrNotReduced = errors.New("observations were not reduced to a single price")
+)
+
+// Observation is the price of a signal reported by a source at a point in time.
+type Observation struct {
+	SignalID string
+	// Source identifies where the price comes from, e.g. the address of a Bothan server.
+	Source string
+	Price  float64
+	Time   time.Time
+}
+
+// FromPrices converts the available prices of a response of the given source into
+// observations. Prices that are not available or not numbers are skipped.
+func FromPrices(source string, prices []*proto.PriceData, t time.Time) []Observation {
+	observations := make([]Observation, 0, len(prices))
+	for _, price := range prices {
+		if price.PriceStatus != proto.PriceStatus_PRICE_STATUS_AVAILABLE {
+			continue
+		}
+		value, err := strconv.ParseFloat(price.Price, 64)
+		if err != nil {
+			continue
+		}
+		observations = append(observations, Observation{
+			SignalID: price.SignalId,
+			Source:   source,
+			Price:    value,
+			Time:     t,
+		})
+	}
+
+	return observations
+}
+
+// Stage transforms the observations of a single signal.
+type Stage interface {
+	Apply(observations []Observation) ([]Observation, error)
+}
+
+// StageFunc adapts a function to a Stage.
+type StageFunc func(observations []Observation) ([]Observation, error)
+
+func (f StageFunc) Apply(observations []Observation) ([]Observation, error) {
+	return f(observations)
+}
+
+// Result is the derived price of a signal.
+type Result struct {
+	Price float64
+	// Time is the time of the derived price, see the stages for how it is chosen.
+	Time time.Time
+	// Err is set if no price could be derived for the signal.
+	Err error
+}
+
+// Pipeline is a chain of stages.
+type Pipeline struct {
+	stages []Stage
+}
+
+// New creates a pipeline that applies the given stages in order.
+func New(stages ...Stage) *Pipeline {
+	return &Pipeline{stages: stages}
+}
+
+// Run applies the stages to the observations of every signal separately, which must leave a
+// single observation per signal, and returns the results keyed by signal ID.
+func (p *Pipeline) Run(observations []Observation) map[string]Result {
+	bySignal := make(map[string][]Observation)
+	for _, o := range observations {
+		bySignal[o.SignalID] = append(bySignal[o.SignalID], o)
+	}
+
+	results := make(map[string]Result, len(bySignal))
+	for signalID, group := range bySignal {
+		results[signalID] = p.run(group)
+	}
+
+	return results
+}
+
+func (p *Pipeline) run(observations []Observation) Result {
+	var err error
+	for _, stage := range p.stages {
+		if observations, err = stage.Apply(observations); err != nil {
+			return Result{Err: err}
+		}
+		if len(observations) == 0 {
+			return Result{Err: ErrNoObservations}
+		}
+	}
+
+	if len(observations) != 1 {
+		return Result{Err: ErrNotReduced}
+	}
+
+	return Result{Price: observations[0].Price, Time: observations[0].Time}
+}
+
+// Filter keeps the observations for which keep returns true.
+func Filter(keep func(Observation) bool) Stage {
+	return StageFunc(func(observations []Observation) ([]Observation, error) {
+		kept := make([]Observation, 0, len(observations))
+		for _, o := range observations {
+			if keep(o) {
+				kept = append(kept, o)
+			}
+		}
+		return kept, nil
+	})
+}
+
+// MaxAge drops the observations older than maxAge at the time returned by now.
+func MaxAge(maxAge time.Duration, now func() time.Time) Stage {
+	return StageFunc(func(observations []Observation) ([]Observation, error) {
+		cutoff := now().Add(-maxAge)
+		return Filter(func(o Observation) bool { return !o.Time.Before(cutoff) }).Apply(observations)
+	})
+}
+
+// MinSources fails with ErrTooFewSources unless the observations come from at least n
+// distinct sources.
+func MinSources(n int) Stage {
+	return StageFunc(func(observations []Observation) ([]Observation, error) {
+		sources := make(map[string]struct{}, len(observations))
+		for _, o := range observations {
+			sources[o.Source] = struct{}{}
+		}
+		if len(sources) < n {
+			return nil, fmt.Errorf("%w: %d of %d", ErrTooFewSources, len(sources), n)
+		}
+		return observations, nil
+	})
+}
+
+// Median reduces the observations to their median price, the mean of the two middle prices
+// for an even number of observations, at the time of the latest observation.
+func Median() Stage {
+	return StageFunc(func(observations []Observation) ([]Observation, error) {
+		prices := make([]float64, len(observations))
+		latest := observations[0]
+		for i, o := range observations {
+			prices[i] = o.Price
+			if o.Time.After(latest.Time) {
+				latest = o
+			}
+		}
+		sort.Float64s(prices)
+
+		median := prices[len(prices)/2]
+		if len(prices)%2 == 0 {
+			median = (prices[len(prices)/2-1] + median) / 2
+		}
+
+		return []Observation{{
+			SignalID: latest.SignalID,
+			Source:   "median",
+			Price:    median,
+			Time:     latest.Time,
+		}}, nil
+	})
+}
@@ -0,0 +1,91 @@
+package pipeline
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+var epoch = time.Unix(1700000000, 0)
+
+func at(seconds int) time.Time {
+	return epoch.Add(time.Duration(seconds) * time.Second)
+}
+
+func TestFromPrices(t *testing.T) {
+	observations := FromPrices("a", []*proto.PriceData{
+		{SignalId: "btc", Price: "60000.5", PriceStatus: proto.PriceStatus_PRICE_STATUS_AVAILABLE},
+		{SignalId: "eth", PriceStatus: proto.PriceStatus_PRICE_STATUS_UNAVAILABLE},
+		{SignalId: "bad", Price: "n/a", PriceStatus: proto.PriceStatus_PRICE_STATUS_AVAILABLE},
+	}, epoch)
+
+	if len(observations) != 1 || observations[0] != (Observation{SignalID: "btc", Source: "a", Price: 60000.5, Time: epoch}) {
+		t.Errorf("unexpected observations %+v", observations)
+	}
+}
+
+func TestPipelineMedian(t *testing.T) {
+	p := New(
+		MaxAge(time.Minute, func() time.Time { return at(60) }),
+		MinSources(2),
+		Median(),
+	)
+
+	results := p.Run([]Observation{
+		{SignalID: "btc", Source: "a", Price: 100, Time: at(10)},
+		{SignalID: "btc", Source: "b", Price: 103, Time: at(20)},
+		{SignalID: "btc", Source: "c", Price: 101, Time: at(15)},
+		{SignalID: "eth", Source: "a", Price: 10, Time: at(30)},
+		{SignalID: "eth", Source: "b", Price: 11, Time: at(-10)},
+		{SignalID: "sol", Source: "a", Price: 1, Time: at(-10)},
+	})
+
+	if btc := results["btc"]; btc.Err != nil || btc.Price != 101 || !btc.Time.Equal(at(20)) {
+		t.Errorf("unexpected btc result %+v", btc)
+	}
+	if eth := results["eth"]; !errors.Is(eth.Err, ErrTooFewSources) {
+		t.Errorf("expected too few sources for the stale eth source, got %+v", eth)
+	}
+	if sol := results["sol"]; !errors.Is(sol.Err, ErrNoObservations) {
+		t.Errorf("expected no observations for stale sol, got %+v", sol)
+	}
+}
+
+func TestPipelineRequiresReduction(t *testing.T) {
+	results := New().Run([]Observation{
+		{SignalID: "btc", Source: "a", Price: 100},
+		{SignalID: "btc", Source: "b", Price: 101},
+	})
+	if !errors.Is(results["btc"].Err, ErrNotReduced) {
+		t.Errorf("expected ErrNotReduced, got %+v", results["btc"])
+	}
+}
+
+func TestPipelineTWAP(t *testing.T) {
+	p := New(Median(), TWAP(NewHistory(time.Minute)))
+	run := func(price float64, seconds int) float64 {
+		result := p.Run([]Observation{{SignalID: "btc", Source: "a", Price: price, Time: at(seconds)}})["btc"]
+		if result.Err != nil {
+			t.Fatal(result.Err)
+		}
+		return result.Price
+	}
+
+	if price := run(100, 0); price != 100 {
+		t.Errorf("expected the first price, got %v", price)
+	}
+	// 100 held for 30s, then 200 for 30s.
+	if price := run(200, 30); price != 100 {
+		t.Errorf("expected 100 as 200 has not held yet, got %v", price)
+	}
+	if price := run(200, 60); math.Abs(price-150) > 1e-9 {
+		t.Errorf("expected 150, got %v", price)
+	}
+	// The window of [30, 90] only sees 200.
+	if price := run(300, 90); math.Abs(price-200) > 1e-9 {
+		t.Errorf("expected 200, got %v", price)
+	}
+}
@@ -0,0 +1,104 @@
+package pipeline
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// History keeps the prices a pipeline derived for each signal over a time window, for stages
+// that average over time.
+type History struct {
+	window time.Duration
+
+	mu      sync.Mutex
+	samples map[string][]Observation
+}
+
+// NewHistory creates a history that keeps the prices of the given window.
+func NewHistory(window time.Duration) *History {
+	return &History{window: window, samples: make(map[string][]Observation)}
+}
+
+// Add records an observation and drops the ones that no longer affect the window. The last
+// observation before the window is kept, as its price holds at the start of the window.
+func (h *History) Add(o Observation) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	samples := h.samples[o.SignalID]
+	i := sort.Search(len(samples), func(i int) bool { return samples[i].Time.After(o.Time) })
+	samples = append(samples, Observation{})
+	copy(samples[i+1:], samples[i:])
+	samples[i] = o
+
+	start := samples[len(samples)-1].Time.Add(-h.window)
+	first := sort.Search(len(samples), func(i int) bool { return samples[i].Time.After(start) })
+	if first > 0 {
+		samples = samples[first-1:]
+	}
+	h.samples[o.SignalID] = samples
+}
+
+// TWAP returns the time-weighted average price of the signal over the window ending at the
+// given time, where every price holds until the next one. It reports false if the signal has no
+// price before that time.
+func (h *History) TWAP(signalID string, end time.Time) (float64, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	samples := h.samples[signalID]
+	start := end.Add(-h.window)
+
+	var weighted float64
+	var total time.Duration
+	for i, s := range samples {
+		if s.Time.After(end) {
+			break
+		}
+		from := s.Time
+		if from.Before(start) {
+			from = start
+		}
+		to := end
+		if i+1 < len(samples) && samples[i+1].Time.Before(end) {
+			to = samples[i+1].Time
+		}
+		if !to.After(from) {
+			continue
+		}
+		weighted += s.Price * float64(to.Sub(from))
+		total += to.Sub(from)
+	}
+
+	if total == 0 {
+		// A single price at the end of the window is its own average.
+		for i := len(samples) - 1; i >= 0; i-- {
+			if !samples[i].Time.After(end) {
+				return samples[i].Price, true
+			}
+		}
+		return 0, false
+	}
+
+	return weighted / float64(total), true
+}
+
+// TWAP records the single observation left by the previous stages in the history and replaces
+// its price by the time-weighted average price of the window ending at its time. It must follow
+// a stage like Median.
+func TWAP(history *History) Stage {
+	return StageFunc(func(observations []Observation) ([]Observation, error) {
+		if len(observations) != 1 {
+			return nil, ErrNotReduced
+		}
+
+		o := observations[0]
+		history.Add(o)
+		if price, ok := history.TWAP(o.SignalID, o.Time); ok {
+			o.Price = price
+		}
+
+		return []Observation{o}, nil
+	})
+}
@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+var (
+	_ Client      = &Prefetcher{}
+	_ PriceMapper = &Prefetcher{}
+)
+
+// Prefetcher decorates a Client with a cache of a fixed set of signals that is refreshed in
+// the background, so that queries of those signals are answered without a round trip. Queries
+// that include other signals, or that arrive while the cached prices are older than the max
+// age, are passed to the wrapped client. The hits and misses of the cache are counted, see
+// Stats.
+type Prefetcher struct {
+	client    Client
+	signalIds []string
+	interval  time.Duration
+	maxAge    time.Duration
+
+	hits   atomic.Int64
+	misses atomic.Int64
+	errors atomic.Int64
+
+	warnings WarningHandler
+
+	mu        sync.RWMutex
+	prices    map[string]*proto.PriceData
+	fetchedAt time.Time
+}
+
+// NewPrefetcher wraps the given client and keeps the prices of the given signals warm,
+// refetching them every interval once Run is called. Cached prices are served for up to twice
+// the interval, so a single failed refresh does not cause misses.
+func NewPrefetcher(c Client, signalIds []string, interval time.Duration) *Prefetcher {
+	return &Prefetcher{
+		client:    c,
+		signalIds: signalIds,
+		interval:  interval,
+		maxAge:    2 * interval,
+	}
+}
+
+// PrefetchStats counts the queries a Prefetcher answered from its cache and the refreshes that
+// failed since it was created.
+type PrefetchStats struct {
+	Hits   int64
+	Misses int64
+	Errors int64
+}
+
+// Stats returns the counts of the prefetcher, e.g. to publish them with the expvarmetrics
+// package.
+func (p *Prefetcher) Stats() PrefetchStats {
+	return PrefetchStats{Hits: p.hits.Load(), Misses: p.misses.Load(), Errors: p.errors.Load()}
+}
+
+// SetWarningHandler sets the handler called with a WarningStaleCache whenever a query is
+// answered from prices older than the refresh interval, which means the last refresh failed.
+// It must be called before Run.
+func (p *Prefetcher) SetWarningHandler(h WarningHandler) {
+	p.warnings = h
+}
+
+// Run fetches the prices of the configured signals every interval until the context is
+// cancelled. The first fetch happens immediately.
+func (p *Prefetcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.refresh()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Prefetcher) refresh() {
+	prices, err := p.client.QueryPrices(p.signalIds)
+	if err != nil {
+		p.errors.Add(1)
+		return
+	}
+
+	cache := make(map[string]*proto.PriceData, len(prices))
+	for _, price := range prices {
+		cache[price.SignalId] = price
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prices = cache
+	p.fetchedAt = time.Now()
+}
+
+// cached returns the cached prices of the given signals and the time they were fetched at,
+// or false if any of them is not cached or the cache is too old.
+func (p *Prefetcher) cached(signalIds []string) ([]*proto.PriceData, time.Time, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.prices == nil || time.Since(p.fetchedAt) > p.maxAge {
+		return nil, time.Time{}, false
+	}
+
+	prices := make([]*proto.PriceData, 0, len(signalIds))
+	for _, id := range signalIds {
+		price, ok := p.prices[id]
+		if !ok {
+			return nil, time.Time{}, false
+		}
+		prices = append(prices, price)
+	}
+
+	return prices, p.fetchedAt, true
+}
+
+// hit counts a query answered from the cache, warning if the cache missed a refresh.
+func (p *Prefetcher) hit(fetchedAt time.Time) {
+	p.hits.Add(1)
+	if age := time.Since(fetchedAt); age > p.interval {
+		p.warnings.warn(Warning{Kind: WarningStaleCache, Age: age})
+	}
+}
+
+func (p *Prefetcher) QueryPrices(signalIds []string) ([]*proto.PriceData, error) {
+	if prices, fetchedAt, ok := p.cached(signalIds); ok {
+		p.hit(fetchedAt)
+		return prices, nil
+	}
+
+	p.misses.Add(1)
+	return p.client.QueryPrices(signalIds)
+}
+
+func (p *Prefetcher) GetPriceMap(ctx context.Context, signalIds []string) (map[string]PriceResult, error) {
+	if prices, fetchedAt, ok := p.cached(signalIds); ok {
+		p.hit(fetchedAt)
+		return NewPriceMap(signalIds, prices, fetchedAt), nil
+	}
+
+	p.misses.Add(1)
+	return GetPriceMap(ctx, p.client, signalIds)
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+	clientv2 "github.com/bandprotocol/bothan/bothan-api/client/go-client/v2"
+)
+
+type stubClient struct {
+	prices []*proto.PriceData
+	timing *proto.ServerTiming
+	err    error
+}
+
+func (c *stubClient) QueryPrices([]string) ([]*proto.PriceData, error) {
+	return c.prices, c.err
+}
+
+func (c *stubClient) GetPriceMap(ctx context.Context, signalIDs []string) (map[string]PriceResult, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	clientv2.ReportServerTiming(ctx, c.timing)
+	return NewPriceMap(signalIDs, c.prices, time.Now()), nil
+}
+
+func TestPrefetcher(t *testing.T) {
+	stub := &stubClient{prices: []*proto.PriceData{
+		{SignalId: "crypto_price.btcusd", Price: "60000", PriceStatus: proto.PriceStatus_PRICE_STATUS_AVAILABLE},
+	}}
+	p := NewPrefetcher(stub, []string{"crypto_price.btcusd"}, time.Hour)
+
+	// Nothing is cached before the first refresh.
+	if _, err := p.GetPriceMap(context.Background(), []string{"crypto_price.btcusd"}); err != nil {
+		t.Fatal(err)
+	}
+	if p.Stats().Misses != 1 {
+		t.Errorf("expected 1 miss, got %d", p.Stats().Misses)
+	}
+
+	p.refresh()
+	stub.prices = nil
+
+	results, err := p.GetPriceMap(context.Background(), []string{"crypto_price.btcusd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results["crypto_price.btcusd"].Price != "60000" {
+		t.Errorf("expected the cached price, got %+v", results["crypto_price.btcusd"])
+	}
+	if p.Stats().Hits != 1 {
+		t.Errorf("expected 1 hit, got %d", p.Stats().Hits)
+	}
+
+	// Signals outside the prefetched set go to the wrapped client.
+	if _, err := p.QueryPrices([]string{"crypto_price.btcusd", "crypto_price.ethusd"}); err != nil {
+		t.Fatal(err)
+	}
+	if p.Stats().Misses != 2 {
+		t.Errorf("expected 2 misses, got %d", p.Stats().Misses)
+	}
+}
+
+func TestPrefetcherStaleCacheWarning(t *testing.T) {
+	stub := &stubClient{prices: []*proto.PriceData{
+		{SignalId: "crypto_price.btcusd", Price: "60000", PriceStatus: proto.PriceStatus_PRICE_STATUS_AVAILABLE},
+	}}
+	p := NewPrefetcher(stub, []string{"crypto_price.btcusd"}, time.Hour)
+
+	var warnings []Warning
+	p.SetWarningHandler(func(w Warning) { warnings = append(warnings, w) })
+
+	p.refresh()
+	if _, err := p.QueryPrices([]string{"crypto_price.btcusd"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warning for a fresh cache, got %v", warnings)
+	}
+
+	// A missed refresh leaves prices older than the interval but within the max age.
+	p.fetchedAt = time.Now().Add(-90 * time.Minute)
+	if _, err := p.QueryPrices([]string{"crypto_price.btcusd"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 1 || warnings[0].Kind != WarningStaleCache || warnings[0].Age < 90*time.Minute {
+		t.Errorf("expected a stale cache warning, got %v", warnings)
+	}
+}
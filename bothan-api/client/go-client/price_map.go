@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+	clientv2 "github.com/bandprotocol/bothan/bothan-api/client/go-client/v2"
+)
+
+// The errors are shared with version 2, so errors.Is works across both versions.
+var (
+	// ErrSignalUnsupported is reported for signals that are not in the registry of the server.
+	ErrSignalUnsupported = clientv2.ErrSignalUnsupported
+	// ErrPriceUnavailable is reported for supported signals whose price is not available yet.
+	ErrPriceUnavailable = clientv2.ErrPriceUnavailable
+	// ErrPriceMissing is reported for signals that are missing from the response.
+	ErrPriceMissing = clientv2.ErrPriceMissing
+	// ErrEmptySignalID is reported for queries that contain an empty signal ID.
+	ErrEmptySignalID = clientv2.ErrEmptySignalID
+	// ErrConnectionClosed is returned by WaitForReady once the connection of the client is
+	// closed.
+	ErrConnectionClosed = clientv2.ErrConnectionClosed
+)
+
+// NormalizeSignalIDs removes duplicate signal IDs and rejects empty ones, see the function of
+// the same name in version 2.
+func NormalizeSignalIDs(signalIDs []string) ([]string, error) {
+	return clientv2.NormalizeSignalIDs(signalIDs)
+}
+
+// PriceResult is the result of a single signal of a price query.
+type PriceResult struct {
+	// Price is the decimal price of the signal, empty unless Status is available.
+	Price  string
+	Status proto.PriceStatus
+	// Timestamp is the time of the oldest source data the price was computed from, as
+	// reported by the server. It is the time the response was received if the server did not
+	// report one, e.g. for prices that are not available.
+	Timestamp time.Time
+	// Err is set if the price of the signal cannot be used.
+	Err error
+}
+
+// NewPriceMap maps the prices of a response to the signals that were queried. Every queried
+// signal has an entry, which carries ErrPriceMissing if the response did not include it. The
+// given timestamp is the time the response was received.
+func NewPriceMap(signalIDs []string, prices []*proto.PriceData, timestamp time.Time) map[string]PriceResult {
+	results := make(map[string]PriceResult, len(signalIDs))
+	for _, signalID := range signalIDs {
+		results[signalID] = PriceResult{Timestamp: timestamp, Err: ErrPriceMissing}
+	}
+
+	for _, price := range prices {
+		if _, ok := results[price.SignalId]; !ok {
+			continue
+		}
+
+		result := PriceResult{Price: price.Price, Status: price.PriceStatus, Timestamp: timestamp}
+		if price.Timestamp != 0 {
+			result.Timestamp = time.Unix(int64(price.Timestamp), 0)
+		}
+		switch price.PriceStatus {
+		case proto.PriceStatus_PRICE_STATUS_AVAILABLE:
+		case proto.PriceStatus_PRICE_STATUS_UNSUPPORTED:
+			result.Err = ErrSignalUnsupported
+		default:
+			result.Err = ErrPriceUnavailable
+		}
+		results[price.SignalId] = result
+	}
+
+	return results
+}
+
+// GetPriceMap queries the prices of the given signals with c and returns them keyed by signal
+// ID. Clients that implement PriceMapper are queried with their GetPriceMap. Other clients are
+// queried with QueryPrices, which cannot be cancelled, so the context is only checked before
+// the query, and references to signal groups are not expanded.
+func GetPriceMap(ctx context.Context, c Client, signalIDs []string) (map[string]PriceResult, error) {
+	if mapper, ok := c.(PriceMapper); ok {
+		return mapper.GetPriceMap(ctx, signalIDs)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	prices, err := c.QueryPrices(signalIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPriceMap(signalIDs, prices, time.Now()), nil
+}
+
+// ExpandGroups replaces the references to signal groups in the queried signal IDs by the
+// signal IDs the server expanded them to, so that the price map has an entry per signal rather
+// than per group.
+func ExpandGroups(signalIDs []string, expansions []*proto.GroupExpansion) []string {
+	if len(expansions) == 0 {
+		return signalIDs
+	}
+
+	groups := make(map[string][]string, len(expansions))
+	for _, expansion := range expansions {
+		groups[expansion.Group] = expansion.SignalIds
+	}
+
+	expanded := make([]string, 0, len(signalIDs))
+	for _, signalID := range signalIDs {
+		if members, ok := groups[signalID]; ok {
+			expanded = append(expanded, members...)
+		} else {
+			expanded = append(expanded, signalID)
+		}
+	}
+
+	return expanded
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestNewPriceMap(t *testing.T) {
+	now := time.Now()
+	updated := time.Unix(1700000000, 0)
+	prices := []*proto.PriceData{
+		{SignalId: "crypto_price.btcusd", Price: "60000", PriceStatus: proto.PriceStatus_PRICE_STATUS_AVAILABLE, Timestamp: uint64(updated.Unix())},
+		{SignalId: "crypto_price.ethusd", PriceStatus: proto.PriceStatus_PRICE_STATUS_UNAVAILABLE},
+		{SignalId: "crypto_price.foo", PriceStatus: proto.PriceStatus_PRICE_STATUS_UNSUPPORTED},
+		{SignalId: "crypto_price.unrequested", Price: "1", PriceStatus: proto.PriceStatus_PRICE_STATUS_AVAILABLE},
+	}
+	signalIDs := []string{"crypto_price.btcusd", "crypto_price.ethusd", "crypto_price.foo", "crypto_price.bar"}
+
+	results := NewPriceMap(signalIDs, prices, now)
+
+	if len(results) != len(signalIDs) {
+		t.Fatalf("expected %d results, got %d", len(signalIDs), len(results))
+	}
+	expected := map[string]error{
+		"crypto_price.btcusd": nil,
+		"crypto_price.ethusd": ErrPriceUnavailable,
+		"crypto_price.foo":    ErrSignalUnsupported,
+		"crypto_price.bar":    ErrPriceMissing,
+	}
+	for signalID, err := range expected {
+		result := results[signalID]
+		if !errors.Is(result.Err, err) {
+			t.Errorf("%s: expected error %v, got %v", signalID, err, result.Err)
+		}
+		// The time the server reports, else the time the response was received.
+		timestamp := now
+		if signalID == "crypto_price.btcusd" {
+			timestamp = updated
+		}
+		if !result.Timestamp.Equal(timestamp) {
+			t.Errorf("%s: expected timestamp %v, got %v", signalID, timestamp, result.Timestamp)
+		}
+	}
+	if price := results["crypto_price.btcusd"].Price; price != "60000" {
+		t.Errorf("expected price 60000, got %s", price)
+	}
+}
+
+func TestExpandGroups(t *testing.T) {
+	expansions := []*proto.GroupExpansion{
+		{Group: "@stablecoins", SignalIds: []string{"crypto_price.usdtusd", "crypto_price.usdcusd"}},
+	}
+
+	expanded := ExpandGroups([]string{"crypto_price.btcusd", "@stablecoins"}, expansions)
+	expected := []string{"crypto_price.btcusd", "crypto_price.usdtusd", "crypto_price.usdcusd"}
+	if !reflect.DeepEqual(expanded, expected) {
+		t.Errorf("expected %v, got %v", expected, expanded)
+	}
+}
+
+// pricesOnly implements only the Client interface, like clients written before PriceMapper.
+type pricesOnly struct{}
+
+func (pricesOnly) QueryPrices(signalIDs []string) ([]*proto.PriceData, error) {
+	var prices []*proto.PriceData
+	for _, id := range signalIDs {
+		prices = append(prices, &proto.PriceData{SignalId: id, Price: "1", PriceStatus: proto.PriceStatus_PRICE_STATUS_AVAILABLE})
+	}
+	return prices, nil
+}
+
+func TestGetPriceMapFallback(t *testing.T) {
+	results, err := GetPriceMap(context.Background(), pricesOnly{}, []string{"crypto_price.btcusd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result := results["crypto_price.btcusd"]; len(results) != 1 || result.Price != "1" || result.Err != nil {
+		t.Errorf("unexpected results %v", results)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := GetPriceMap(ctx, pricesOnly{}, []string{"crypto_price.btcusd"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the cancelled context to be reported, got %v", err)
+	}
+}
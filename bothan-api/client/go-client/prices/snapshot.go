@@ -0,0 +1,130 @@
+// Package prices compares sets of prices, e.g. those of two Bothan servers or of a server at
+// two points in time.
+package prices
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// PriceSnapshot holds the available prices of a set of signals at a point in time.
+type PriceSnapshot struct {
+	Time time.Time `json:"time"`
+	// Prices maps signal IDs to their decimal prices.
+	Prices map[string]string `json:"prices"`
+}
+
+// NewSnapshot creates a snapshot of the available prices among the given ones.
+func NewSnapshot(prices []*proto.PriceData, t time.Time) PriceSnapshot {
+	snapshot := PriceSnapshot{Time: t, Prices: make(map[string]string, len(prices))}
+	for _, price := range prices {
+		if price.PriceStatus == proto.PriceStatus_PRICE_STATUS_AVAILABLE {
+			snapshot.Prices[price.SignalId] = price.Price
+		}
+	}
+
+	return snapshot
+}
+
+// LoadSnapshot reads a snapshot saved as JSON.
+func LoadSnapshot(path string) (PriceSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return PriceSnapshot{}, err
+	}
+
+	var snapshot PriceSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return PriceSnapshot{}, err
+	}
+
+	return snapshot, nil
+}
+
+// Save writes the snapshot as JSON.
+func (s PriceSnapshot) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Change is a signal whose price differs between two snapshots.
+type Change struct {
+	SignalID string `json:"signal_id"`
+	Old      string `json:"old"`
+	New      string `json:"new"`
+	// PercentDelta is the change from Old to New in percent, e.g. -2.5 for a 2.5% drop.
+	PercentDelta float64 `json:"percent_delta"`
+}
+
+// SnapshotDiff lists the differences between two snapshots, each ordered by signal ID.
+type SnapshotDiff struct {
+	// Added are the signals only priced in the second snapshot.
+	Added []string `json:"added"`
+	// Removed are the signals only priced in the first snapshot.
+	Removed []string `json:"removed"`
+	Changed []Change `json:"changed"`
+}
+
+// Empty reports whether the snapshots have the same prices.
+func (d SnapshotDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff compares snapshot b against snapshot a. Prices are compared numerically, so "1.50"
+// and "1.5" are equal.
+func Diff(a, b PriceSnapshot) SnapshotDiff {
+	var diff SnapshotDiff
+	for signalID, old := range a.Prices {
+		current, ok := b.Prices[signalID]
+		if !ok {
+			diff.Removed = append(diff.Removed, signalID)
+			continue
+		}
+
+		if change, changed := compare(signalID, old, current); changed {
+			diff.Changed = append(diff.Changed, change)
+		}
+	}
+	for signalID := range b.Prices {
+		if _, ok := a.Prices[signalID]; !ok {
+			diff.Added = append(diff.Added, signalID)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return diff.Changed[i].SignalID < diff.Changed[j].SignalID
+	})
+
+	return diff
+}
+
+func compare(signalID, old, current string) (Change, bool) {
+	change := Change{SignalID: signalID, Old: old, New: current}
+
+	oldValue, oldErr := strconv.ParseFloat(old, 64)
+	newValue, newErr := strconv.ParseFloat(current, 64)
+	if oldErr != nil || newErr != nil {
+		// Unparsable prices can only be compared as strings.
+		return change, old != current
+	}
+	if oldValue == newValue {
+		return change, false
+	}
+
+	if oldValue != 0 {
+		change.PercentDelta = (newValue - oldValue) / oldValue * 100
+	}
+
+	return change, true
+}
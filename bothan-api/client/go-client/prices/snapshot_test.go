@@ -0,0 +1,65 @@
+package prices
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestDiff(t *testing.T) {
+	a := PriceSnapshot{Prices: map[string]string{
+		"crypto_price.btcusd":  "60000",
+		"crypto_price.ethusd":  "3000",
+		"crypto_price.usdtusd": "1.00",
+		"crypto_price.bnbusd":  "500",
+	}}
+	b := PriceSnapshot{Prices: map[string]string{
+		"crypto_price.btcusd":  "63000",
+		"crypto_price.ethusd":  "2970",
+		"crypto_price.usdtusd": "1",
+		"crypto_price.solusd":  "150",
+	}}
+
+	diff := Diff(a, b)
+
+	expected := SnapshotDiff{
+		Added:   []string{"crypto_price.solusd"},
+		Removed: []string{"crypto_price.bnbusd"},
+		Changed: []Change{
+			{SignalID: "crypto_price.btcusd", Old: "60000", New: "63000", PercentDelta: 5},
+			{SignalID: "crypto_price.ethusd", Old: "3000", New: "2970", PercentDelta: -1},
+		},
+	}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("expected %+v, got %+v", expected, diff)
+	}
+	if !Diff(a, a).Empty() {
+		t.Error("expected no difference between identical snapshots")
+	}
+}
+
+func TestSnapshotSaveAndLoad(t *testing.T) {
+	snapshot := NewSnapshot([]*proto.PriceData{
+		{SignalId: "crypto_price.btcusd", Price: "60000", PriceStatus: proto.PriceStatus_PRICE_STATUS_AVAILABLE},
+		{SignalId: "crypto_price.ethusd", PriceStatus: proto.PriceStatus_PRICE_STATUS_UNAVAILABLE},
+	}, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := snapshot.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadSnapshot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(loaded, snapshot) {
+		t.Errorf("expected %+v, got %+v", snapshot, loaded)
+	}
+	if len(loaded.Prices) != 1 {
+		t.Errorf("expected only available prices, got %v", loaded.Prices)
+	}
+}
@@ -0,0 +1,17 @@
+module github.com/bandprotocol/bothan/bothan-api/client/go-client/query
+
+go 1.22.0
+
+require (
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.19.1
+	google.golang.org/genproto/googleapis/api v0.0.0-20240506185236-b8a5c65736ae
+	google.golang.org/grpc v1.63.2
+	google.golang.org/protobuf v1.34.1
+)
+
+require (
+	golang.org/x/net v0.21.0 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240429193739-8cf5692501f6 // indirect
+)
@@ -19,7 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Query_Prices_FullMethodName = "/query.Query/Prices"
+	Query_Prices_FullMethodName        = "/query.Query/Prices"
+	Query_Signals_FullMethodName       = "/query.Query/Signals"
+	Query_SourceStats_FullMethodName   = "/query.Query/SourceStats"
+	Query_Registry_FullMethodName      = "/query.Query/Registry"
+	Query_StatsSummary_FullMethodName  = "/query.Query/StatsSummary"
+	Query_ActiveSignals_FullMethodName = "/query.Query/ActiveSignals"
 )
 
 // QueryClient is the client API for Query service.
@@ -28,6 +33,24 @@ const (
 type QueryClient interface {
 	// RPC method that returns all prices of requested signal ids.
 	Prices(ctx context.Context, in *QueryPricesRequest, opts ...grpc.CallOption) (*QueryPricesResponse, error)
+	// RPC method that lists the signal ids of the registry, ordered by signal id.
+	Signals(ctx context.Context, in *QuerySignalsRequest, opts ...grpc.CallOption) (*QuerySignalsResponse, error)
+	// RPC method that returns the latency and success rate of every source, as
+	// observed by the server when querying prices.
+	SourceStats(ctx context.Context, in *QuerySourceStatsRequest, opts ...grpc.CallOption) (*QuerySourceStatsResponse, error)
+	// RPC method that returns the registry the server loaded, so that tools can
+	// verify which registry a server uses.
+	Registry(ctx context.Context, in *QueryRegistryRequest, opts ...grpc.CallOption) (*QueryRegistryResponse, error)
+	// RPC method that summarizes the prices of all signals of the registry, so that
+	// status pages need a single call instead of paging through the signals. The
+	// prices are those of the last price query of each signal, so no source is
+	// queried; signals that were never queried are unavailable.
+	StatsSummary(ctx context.Context, in *QueryStatsSummaryRequest, opts ...grpc.CallOption) (*QueryStatsSummaryResponse, error)
+	// RPC method that lists the signal ids the server keeps up to date: the signals
+	// of the registry that were queried since the server started, and so whose
+	// sources are subscribed, and that a source supports. Unlike Signals, it tells
+	// a signal that was never queried apart from one whose price is unavailable.
+	ActiveSignals(ctx context.Context, in *QueryActiveSignalsRequest, opts ...grpc.CallOption) (*QueryActiveSignalsResponse, error)
 }
 
 type queryClient struct {
@@ -47,12 +70,75 @@ func (c *queryClient) Prices(ctx context.Context, in *QueryPricesRequest, opts .
 	return out, nil
 }
 
+func (c *queryClient) Signals(ctx context.Context, in *QuerySignalsRequest, opts ...grpc.CallOption) (*QuerySignalsResponse, error) {
+	out := new(QuerySignalsResponse)
+	err := c.cc.Invoke(ctx, Query_Signals_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) SourceStats(ctx context.Context, in *QuerySourceStatsRequest, opts ...grpc.CallOption) (*QuerySourceStatsResponse, error) {
+	out := new(QuerySourceStatsResponse)
+	err := c.cc.Invoke(ctx, Query_SourceStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) Registry(ctx context.Context, in *QueryRegistryRequest, opts ...grpc.CallOption) (*QueryRegistryResponse, error) {
+	out := new(QueryRegistryResponse)
+	err := c.cc.Invoke(ctx, Query_Registry_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) StatsSummary(ctx context.Context, in *QueryStatsSummaryRequest, opts ...grpc.CallOption) (*QueryStatsSummaryResponse, error) {
+	out := new(QueryStatsSummaryResponse)
+	err := c.cc.Invoke(ctx, Query_StatsSummary_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) ActiveSignals(ctx context.Context, in *QueryActiveSignalsRequest, opts ...grpc.CallOption) (*QueryActiveSignalsResponse, error) {
+	out := new(QueryActiveSignalsResponse)
+	err := c.cc.Invoke(ctx, Query_ActiveSignals_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QueryServer is the server API for Query service.
 // All implementations must embed UnimplementedQueryServer
 // for forward compatibility
 type QueryServer interface {
 	// RPC method that returns all prices of requested signal ids.
 	Prices(context.Context, *QueryPricesRequest) (*QueryPricesResponse, error)
+	// RPC method that lists the signal ids of the registry, ordered by signal id.
+	Signals(context.Context, *QuerySignalsRequest) (*QuerySignalsResponse, error)
+	// RPC method that returns the latency and success rate of every source, as
+	// observed by the server when querying prices.
+	SourceStats(context.Context, *QuerySourceStatsRequest) (*QuerySourceStatsResponse, error)
+	// RPC method that returns the registry the server loaded, so that tools can
+	// verify which registry a server uses.
+	Registry(context.Context, *QueryRegistryRequest) (*QueryRegistryResponse, error)
+	// RPC method that summarizes the prices of all signals of the registry, so that
+	// status pages need a single call instead of paging through the signals. The
+	// prices are those of the last price query of each signal, so no source is
+	// queried; signals that were never queried are unavailable.
+	StatsSummary(context.Context, *QueryStatsSummaryRequest) (*QueryStatsSummaryResponse, error)
+	// RPC method that lists the signal ids the server keeps up to date: the signals
+	// of the registry that were queried since the server started, and so whose
+	// sources are subscribed, and that a source supports. Unlike Signals, it tells
+	// a signal that was never queried apart from one whose price is unavailable.
+	ActiveSignals(context.Context, *QueryActiveSignalsRequest) (*QueryActiveSignalsResponse, error)
 	mustEmbedUnimplementedQueryServer()
 }
 
@@ -63,6 +149,21 @@ type UnimplementedQueryServer struct {
 func (UnimplementedQueryServer) Prices(context.Context, *QueryPricesRequest) (*QueryPricesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Prices not implemented")
 }
+func (UnimplementedQueryServer) Signals(context.Context, *QuerySignalsRequest) (*QuerySignalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Signals not implemented")
+}
+func (UnimplementedQueryServer) SourceStats(context.Context, *QuerySourceStatsRequest) (*QuerySourceStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SourceStats not implemented")
+}
+func (UnimplementedQueryServer) Registry(context.Context, *QueryRegistryRequest) (*QueryRegistryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Registry not implemented")
+}
+func (UnimplementedQueryServer) StatsSummary(context.Context, *QueryStatsSummaryRequest) (*QueryStatsSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StatsSummary not implemented")
+}
+func (UnimplementedQueryServer) ActiveSignals(context.Context, *QueryActiveSignalsRequest) (*QueryActiveSignalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ActiveSignals not implemented")
+}
 func (UnimplementedQueryServer) mustEmbedUnimplementedQueryServer() {}
 
 // UnsafeQueryServer may be embedded to opt out of forward compatibility for this service.
@@ -94,6 +195,96 @@ func _Query_Prices_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Query_Signals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuerySignalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Signals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Query_Signals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Signals(ctx, req.(*QuerySignalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_SourceStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuerySourceStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).SourceStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Query_SourceStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).SourceStats(ctx, req.(*QuerySourceStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Registry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRegistryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Registry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Query_Registry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Registry(ctx, req.(*QueryRegistryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_StatsSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryStatsSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).StatsSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Query_StatsSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).StatsSummary(ctx, req.(*QueryStatsSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_ActiveSignals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryActiveSignalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).ActiveSignals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Query_ActiveSignals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).ActiveSignals(ctx, req.(*QueryActiveSignalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Query_ServiceDesc is the grpc.ServiceDesc for Query service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -105,6 +296,26 @@ var Query_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Prices",
 			Handler:    _Query_Prices_Handler,
 		},
+		{
+			MethodName: "Signals",
+			Handler:    _Query_Signals_Handler,
+		},
+		{
+			MethodName: "SourceStats",
+			Handler:    _Query_SourceStats_Handler,
+		},
+		{
+			MethodName: "Registry",
+			Handler:    _Query_Registry_Handler,
+		},
+		{
+			MethodName: "StatsSummary",
+			Handler:    _Query_StatsSummary_Handler,
+		},
+		{
+			MethodName: "ActiveSignals",
+			Handler:    _Query_ActiveSignals_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "query/query.proto",
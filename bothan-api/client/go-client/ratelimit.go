@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"golang.org/x/time/rate"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// Method names of the RateLimitConfig.
+const (
+	MethodQueryPrices = "QueryPrices"
+	MethodGetPriceMap = "GetPriceMap"
+	MethodListSignals = "ListSignals"
+)
+
+var (
+	_ Client       = &RateLimitedClient{}
+	_ PriceMapper  = &RateLimitedClient{}
+	_ SignalLister = &RateLimitedClient{}
+)
+
+// RateLimit is the token bucket of a method.
+type RateLimit struct {
+	// Rate is the sustained number of calls per second. Zero means unlimited.
+	Rate float64
+	// Burst is the number of calls that may be made at once. It defaults to the rate, rounded
+	// up, if zero.
+	Burst int
+}
+
+// RateLimitConfig defines the rates at which a RateLimitedClient calls the server.
+type RateLimitConfig struct {
+	// Default applies to the methods without an entry in Methods.
+	Default RateLimit
+	// Methods overrides the rate of single methods, keyed by method name, e.g.
+	// MethodQueryPrices.
+	Methods map[string]RateLimit
+}
+
+// RateLimitedClient decorates a Client with client-side token buckets, so that bursts of
+// calls are spread out to stay within the quota of the server instead of being rejected by
+// it. Calls wait for a token until their context is done.
+type RateLimitedClient struct {
+	client   Client
+	limiters map[string]*rate.Limiter
+}
+
+// NewRateLimitedClient wraps the given client with the given rates.
+func NewRateLimitedClient(c Client, config RateLimitConfig) *RateLimitedClient {
+	limiters := make(map[string]*rate.Limiter)
+	for _, method := range []string{MethodQueryPrices, MethodGetPriceMap, MethodListSignals} {
+		limit, ok := config.Methods[method]
+		if !ok {
+			limit = config.Default
+		}
+		limiters[method] = newLimiter(limit)
+	}
+
+	return &RateLimitedClient{client: c, limiters: limiters}
+}
+
+func newLimiter(limit RateLimit) *rate.Limiter {
+	if limit.Rate <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+
+	burst := limit.Burst
+	if burst <= 0 {
+		burst = int(math.Ceil(limit.Rate))
+	}
+
+	return rate.NewLimiter(rate.Limit(limit.Rate), burst)
+}
+
+func (c *RateLimitedClient) QueryPrices(signalIds []string) ([]*proto.PriceData, error) {
+	if err := c.limiters[MethodQueryPrices].Wait(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return c.client.QueryPrices(signalIds)
+}
+
+func (c *RateLimitedClient) GetPriceMap(ctx context.Context, signalIds []string) (map[string]PriceResult, error) {
+	if err := c.limiters[MethodGetPriceMap].Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return GetPriceMap(ctx, c.client, signalIds)
+}
+
+// ListSignals lists the signals with the wrapped client, which must implement SignalLister.
+func (c *RateLimitedClient) ListSignals(ctx context.Context) ([]*proto.SignalInfo, error) {
+	lister, ok := c.client.(SignalLister)
+	if !ok {
+		return nil, errors.New("client cannot list signals")
+	}
+	if err := c.limiters[MethodListSignals].Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return lister.ListSignals(ctx)
+}
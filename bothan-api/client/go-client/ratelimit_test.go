@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimitedClient(t *testing.T) {
+	c := NewRateLimitedClient(&stubClient{}, RateLimitConfig{
+		Methods: map[string]RateLimit{MethodGetPriceMap: {Rate: 1, Burst: 2}},
+	})
+
+	// The burst passes at once, the next call has to wait for a token.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetPriceMap(ctx, nil); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+	if _, err := c.GetPriceMap(ctx, nil); err == nil {
+		t.Error("expected the call beyond the burst to wait longer than the deadline")
+	}
+
+	// Methods without a rate are not limited.
+	for i := 0; i < 10; i++ {
+		if _, err := c.QueryPrices(nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -1,50 +1,547 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
 	"net/url"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/levigross/grequests"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
 
+	"github.com/bandprotocol/bothan/bothan-api/client/go-client/dnscache"
 	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+	clientv2 "github.com/bandprotocol/bothan/bothan-api/client/go-client/v2"
 )
 
-var _ Client = &RestClient{}
+var (
+	_ Client          = &RestClient{}
+	_ PriceMapper     = &RestClient{}
+	_ SignalActivator = &RestClient{}
+)
+
+// unmarshalOptions decode the JSON of the gateway, which uses the camel case field names of
+// protojson, and ignore fields added by newer servers.
+var unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
 
 type RestClient struct {
-	url     string
-	timeout time.Duration
+	urls       []string
+	httpClient *http.Client
+	consumer   string
+	headers    map[string]string
+	warnings   WarningHandler
+	logger     Logger
+
+	// wrapTransport are the wrappers of WithRestTransport, applied after the other options.
+	wrapTransport []func(http.RoundTripper) http.RoundTripper
+
+	// latencyMargin is the margin of WithRestLatencyRouting, negative if it is not used.
+	latencyMargin float64
+	// verifyChecksum is set by WithRestChecksumVerification.
+	verifyChecksum bool
+
+	mu      sync.Mutex
+	healthy []bool
+	// latencies are the smoothed probe latencies of the base urls, zero until a probe
+	// succeeds. They are only measured with WithRestLatencyRouting.
+	latencies []time.Duration
+	// preferred is the index of the base url requests go to first while it is healthy.
+	preferred int
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewRest creates a new REST client for the proxy at the given url. Host names are resolved
+// by the system resolver, see NewRestWithResolver for a DNS cache.
+func NewRest(url string, timeout time.Duration, opts ...RestOption) *RestClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	return newRest([]string{url}, &http.Client{Transport: transport, Timeout: timeout}, opts)
+}
+
+// NewRestWithResolver creates a new REST client that resolves host names with the given
+// resolver, which avoids a DNS lookup per request and keeps the client working through brief
+// DNS outages. See the dnscache package for how it differs from the system resolver.
+func NewRestWithResolver(url string, timeout time.Duration, resolver *dnscache.Resolver, opts ...RestOption) *RestClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = resolver.DialContext
+
+	return newRest([]string{url}, &http.Client{Transport: transport, Timeout: timeout}, opts)
+}
+
+// NewRestWithFailover creates a new REST client for several proxies serving the same data, in
+// order of priority. Requests go to the first healthy proxy and are retried on the next one if
+// it cannot be reached or fails with a server error. Every healthInterval the unhealthy proxies
+// are probed, so that requests return to a higher priority proxy once it recovers; a zero
+// interval disables probing, and is rejected with WithRestLatencyRouting, which relies on
+// the probes. Close must be called to stop the probing.
+func NewRestWithFailover(urls []string, timeout, healthInterval time.Duration, opts ...RestOption) (*RestClient, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no url given")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	c := newRest(urls, &http.Client{Transport: transport, Timeout: timeout}, opts)
+	if c.latencyMargin >= 0 && healthInterval <= 0 {
+		return nil, errors.New("latency routing requires a health interval")
+	}
+	if healthInterval > 0 {
+		go c.checkHealth(healthInterval)
+	}
+
+	return c, nil
+}
+
+func newRest(urls []string, httpClient *http.Client, opts []RestOption) *RestClient {
+	healthy := make([]bool, len(urls))
+	for i := range healthy {
+		healthy[i] = true
+	}
+
+	c := &RestClient{
+		urls:          urls,
+		httpClient:    httpClient,
+		latencyMargin: -1,
+		healthy:       healthy,
+		latencies:     make([]time.Duration, len(urls)),
+		stop:          make(chan struct{}),
+	}
+	if httpClient.CheckRedirect == nil {
+		httpClient.CheckRedirect = checkRedirect
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	for _, wrap := range c.wrapTransport {
+		c.httpClient.Transport = wrap(c.httpClient.Transport)
+	}
+	c.wrapTransport = nil
+	return c
+}
+
+// checkRedirect follows up to grequests.RedirectLimit redirects. grequests sets a policy like
+// it on clients without one at their first request, which races with the requests made
+// concurrently, e.g. by the health probes.
+func checkRedirect(_ *http.Request, via []*http.Request) error {
+	if len(via) >= grequests.RedirectLimit {
+		return grequests.ErrRedirectLimitExceeded
+	}
+	return nil
+}
+
+// SetConsumer tags every request of the client with the given consumer name, see
+// ConsumerHeader. It must be called before the client is used.
+func (c *RestClient) SetConsumer(name string) {
+	c.consumer = name
 }
 
-func NewRest(url string, timeout time.Duration) *RestClient {
-	return &RestClient{url, timeout}
+// SetWarningHandler sets the handler called with a WarningFallbackEndpoint whenever a request
+// is served by a proxy other than the preferred one, which is the first one given unless
+// WithRestLatencyRouting is used.
+func (c *RestClient) SetWarningHandler(h WarningHandler) {
+	c.warnings = h
+}
+
+// Close stops the health checks of the client.
+func (c *RestClient) Close() error {
+	c.stopOnce.Do(func() { close(c.stop) })
+	return nil
 }
 
 func (c *RestClient) QueryPrices(signalIds []string) ([]*proto.PriceData, error) {
-	parsedUrl, err := url.Parse(c.url + "/prices")
+	resp, err := c.queryPrices(context.Background(), signalIds)
 	if err != nil {
 		return nil, err
 	}
-	parsedUrl.Path = path.Join(parsedUrl.Path, strings.Join(signalIds, ","))
 
-	resp, err := grequests.Get(
-		parsedUrl.String(),
-		&grequests.RequestOptions{
-			RequestTimeout: c.timeout,
-		},
-	)
+	return resp.Prices, nil
+}
+
+// SetActiveSignalIds activates the signals with a price query, see SignalActivator.
+func (c *RestClient) SetActiveSignalIds(signalIds []string) error {
+	resp, err := c.queryPrices(context.Background(), signalIds)
+	if err != nil {
+		return err
+	}
+
+	return checkActivated(resp.Prices)
+}
+
+func (c *RestClient) GetPriceMap(ctx context.Context, signalIds []string) (map[string]PriceResult, error) {
+	resp, err := c.queryPrices(ctx, signalIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPriceMap(ExpandGroups(signalIds, resp.Expansions), resp.Prices, time.Now()), nil
+}
+
+func (c *RestClient) queryPrices(ctx context.Context, signalIds []string) (*proto.QueryPricesResponse, error) {
+	signalIds, err := NormalizeSignalIDs(signalIds)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.get(ctx, []slog.Attr{slog.String("path", "/prices"), slog.Any("signal_ids", signalIds)}, func(baseUrl string) (string, error) {
+		parsedUrl, err := url.Parse(baseUrl + "/prices")
+		if err != nil {
+			return "", err
+		}
+		parsedUrl.Path = path.Join(parsedUrl.Path, strings.Join(signalIds, ","))
+		return parsedUrl.String(), nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var priceResp proto.QueryPricesResponse
-	err = json.Unmarshal(resp.Bytes(), &priceResp)
+	err = decodePrices(body, &priceResp)
+	if err != nil {
+		return nil, err
+	}
+	clientv2.ReportServerTiming(ctx, priceResp.ServerTiming)
+
+	return &priceResp, nil
+}
+
+// ListSignals returns every signal known to the registry of the server, querying it page by
+// page.
+func (c *RestClient) ListSignals(ctx context.Context) ([]*proto.SignalInfo, error) {
+	var signals []*proto.SignalInfo
+	pageToken := ""
+	for {
+		body, err := c.get(ctx, []slog.Attr{slog.String("path", "/signals")}, func(baseUrl string) (string, error) {
+			parsedUrl, err := url.Parse(baseUrl + "/signals")
+			if err != nil {
+				return "", err
+			}
+			if pageToken != "" {
+				parsedUrl.RawQuery = url.Values{"page_token": {pageToken}}.Encode()
+			}
+			return parsedUrl.String(), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var response proto.QuerySignalsResponse
+		if err := unmarshalOptions.Unmarshal(body, &response); err != nil {
+			return nil, err
+		}
+
+		signals = append(signals, response.Signals...)
+		if response.NextPageToken == "" {
+			return signals, nil
+		}
+		pageToken = response.NextPageToken
+	}
+}
+
+// PriceChange is the change of the price of a signal over a window, computed by a
+// bothan-api-proxy from the prices it served, see RestClient.PriceChanges.
+type PriceChange struct {
+	SignalID string `json:"signal_id"`
+	// Price is the last available price of the signal, observed at Time. It is empty if the
+	// proxy has not served an available price of the signal.
+	Price string     `json:"price"`
+	Time  *time.Time `json:"time"`
+	// BasePrice is the last available price observed at least the window ago, at BaseTime.
+	// It is empty if the history of the proxy does not reach back that far.
+	BasePrice string     `json:"base_price"`
+	BaseTime  *time.Time `json:"base_time"`
+	// PercentChange is the change from the base price to the price in percent, or nil if
+	// either is missing or the base price is zero.
+	PercentChange *float64 `json:"percent_change"`
+}
+
+// PriceChanges returns the change of the prices of the signals over the window, in the
+// order of the signal IDs, from GET /prices/{signal_ids}/change of a bothan-api-proxy with
+// its price history enabled. The changes are computed from the prices the proxy served, not
+// by the server.
+func (c *RestClient) PriceChanges(ctx context.Context, signalIds []string, window time.Duration) ([]PriceChange, error) {
+	signalIds, err := NormalizeSignalIDs(signalIds)
 	if err != nil {
 		return nil, err
 	}
 
-	return priceResp.Prices, nil
+	attrs := []slog.Attr{slog.String("path", "/prices/change"), slog.Any("signal_ids", signalIds)}
+	body, err := c.get(ctx, attrs, func(baseUrl string) (string, error) {
+		parsedUrl, err := url.Parse(baseUrl + "/prices")
+		if err != nil {
+			return "", err
+		}
+		parsedUrl.Path = path.Join(parsedUrl.Path, strings.Join(signalIds, ","), "change")
+		parsedUrl.RawQuery = url.Values{"window": {window.String()}}.Encode()
+		return parsedUrl.String(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Changes []PriceChange `json:"changes"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Changes, nil
+}
+
+// get sends a GET request to the url built for each base url, healthy ones first, until one
+// of them does not fail with a connection or server error, or with a checksum mismatch if it
+// is verified, and returns the response body. The attributes describe the request in the log
+// of the client.
+func (c *RestClient) get(ctx context.Context, attrs []slog.Attr, buildUrl func(baseUrl string) (string, error)) (body []byte, err error) {
+	log := c.startLog(ctx, attrs...)
+	defer func() { log.finish(ctx, err) }()
+
+	headers := c.requestHeaders()
+
+	var lastErr error
+	candidates, preferred := c.candidates()
+	for _, i := range candidates {
+		reqUrl, err := buildUrl(c.urls[i])
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := grequests.Get(
+			reqUrl,
+			&grequests.RequestOptions{
+				HTTPClient: c.httpClient,
+				Context:    ctx,
+				Headers:    headers,
+			},
+		)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			c.setHealthy(i, false)
+			lastErr = err
+			log.retry(ctx, c.urls[i], err)
+			continue
+		}
+		if resp.StatusCode >= http.StatusInternalServerError {
+			_ = resp.Close()
+			c.setHealthy(i, false)
+			lastErr = fmt.Errorf("%s: unexpected status %s", c.urls[i], resp.RawResponse.Status)
+			log.retry(ctx, c.urls[i], lastErr)
+			continue
+		}
+		if c.verifyChecksum {
+			if err := verifyContentDigest(resp.Header.Get(ContentDigestHeader), resp.Bytes()); err != nil {
+				c.setHealthy(i, false)
+				lastErr = fmt.Errorf("%s: %w", c.urls[i], err)
+				log.retry(ctx, c.urls[i], lastErr)
+				continue
+			}
+		}
+
+		c.setHealthy(i, true)
+		if i != preferred {
+			c.warnings.warn(Warning{Kind: WarningFallbackEndpoint, Endpoint: c.urls[i]})
+		}
+		if !resp.Ok {
+			return nil, responseError(c.urls[i], resp)
+		}
+
+		return resp.Bytes(), nil
+	}
+
+	return nil, lastErr
+}
+
+// requestHeaders returns the headers sent with every request: those of WithRestHeaders and
+// the consumer name.
+func (c *RestClient) requestHeaders() map[string]string {
+	headers := make(map[string]string, len(c.headers)+1)
+	for key, value := range c.headers {
+		headers[key] = value
+	}
+	if c.consumer != "" {
+		headers[ConsumerHeader] = c.consumer
+	}
+	return headers
+}
+
+// responseError returns the error of a response with a client error status. The gateway
+// writes errors as a google.rpc.Status, which is returned as a gRPC status error, so that
+// status.Code and status.FromError tell e.g. NotFound from InvalidArgument. Other bodies only
+// give the HTTP status.
+func responseError(baseUrl string, resp *grequests.Response) error {
+	body := resp.Bytes()
+
+	var st spb.Status
+	if err := unmarshalOptions.Unmarshal(body, &st); err != nil {
+		// Details of types unknown to the client fail to decode, the code and message do not.
+		var fallback struct {
+			Code    int32  `json:"code"`
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(body, &fallback)
+		st = spb.Status{Code: fallback.Code, Message: fallback.Message}
+	}
+	if st.Code == int32(codes.OK) {
+		return fmt.Errorf("%s: unexpected status %s", baseUrl, resp.RawResponse.Status)
+	}
+
+	return fmt.Errorf("%s: unexpected status %s: %w", baseUrl, resp.RawResponse.Status, status.ErrorProto(&st))
+}
+
+// candidates returns the indexes of the base urls to try, healthy ones first, each group in
+// order of priority except that the preferred base url comes first if it is healthy, and the
+// index of the preferred base url.
+func (c *RestClient) candidates() ([]int, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	healthy := make([]int, 0, len(c.urls))
+	if c.healthy[c.preferred] {
+		healthy = append(healthy, c.preferred)
+	}
+	var unhealthy []int
+	for i, ok := range c.healthy {
+		switch {
+		case ok && i != c.preferred:
+			healthy = append(healthy, i)
+		case !ok:
+			unhealthy = append(unhealthy, i)
+		}
+	}
+
+	return append(healthy, unhealthy...), c.preferred
+}
+
+func (c *RestClient) isHealthy(i int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.healthy[i]
+}
+
+func (c *RestClient) setHealthy(i int, healthy bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.healthy[i] = healthy
+}
+
+// checkHealth probes the unhealthy base urls every interval until the client is closed. With
+// WithRestLatencyRouting, all base urls are probed and the preferred one is then reselected.
+func (c *RestClient) checkHealth(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+		}
+
+		for i, baseUrl := range c.urls {
+			switch {
+			case c.latencyMargin >= 0:
+				start := time.Now()
+				healthy := c.probe(baseUrl)
+				c.recordProbe(i, healthy, time.Since(start))
+			case !c.isHealthy(i):
+				c.setHealthy(i, c.probe(baseUrl))
+			}
+		}
+		if c.latencyMargin >= 0 {
+			c.selectPreferred()
+		}
+	}
+}
+
+// probe reports whether the proxy at the base url serves requests. The server has no
+// dedicated health endpoint, so the stats summary is used, which only reads the prices the
+// server has cached and so neither queries nor subscribes its sources. Servers that predate
+// the stats summary answer with 501 Not Implemented, which still shows the proxy and its
+// upstream are reachable. The probe carries the headers of requests, as a proxy with API keys
+// rejects requests without one before reaching its upstream; a rejection means the client is
+// misconfigured, so the proxy is reported unhealthy and the rejection is logged as an error.
+func (c *RestClient) probe(baseUrl string) bool {
+	resp, err := grequests.Get(
+		baseUrl+"/stats/summary",
+		&grequests.RequestOptions{HTTPClient: c.httpClient, Headers: c.requestHeaders()},
+	)
+	if err != nil {
+		return false
+	}
+	defer resp.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		if c.logger != nil {
+			c.logger.LogAttrs(context.Background(), slog.LevelError, "bothan health probe rejected, check the credentials of the client",
+				slog.String("url", baseUrl), slog.String("status", resp.RawResponse.Status))
+		}
+		return false
+	case resp.StatusCode == http.StatusNotImplemented:
+		return true
+	default:
+		return resp.StatusCode < http.StatusInternalServerError
+	}
+}
+
+// latencySmoothing is the weight of the latest probe in the smoothed latency of a base url.
+const latencySmoothing = 0.3
+
+// recordProbe records the outcome of a probe of the base url at index i, whose latency only
+// counts if it succeeded.
+func (c *RestClient) recordProbe(i int, healthy bool, latency time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.healthy[i] = healthy
+	switch {
+	case !healthy:
+	case c.latencies[i] == 0:
+		c.latencies[i] = latency
+	default:
+		c.latencies[i] = time.Duration(latencySmoothing*float64(latency) + (1-latencySmoothing)*float64(c.latencies[i]))
+	}
+}
+
+// selectPreferred prefers the fastest healthy base url if the preferred one is unhealthy or
+// slower than it by more than the latency margin.
+func (c *RestClient) selectPreferred() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fastest := -1
+	for i, latency := range c.latencies {
+		if c.healthy[i] && latency > 0 && (fastest < 0 || latency < c.latencies[fastest]) {
+			fastest = i
+		}
+	}
+	current := c.preferred
+	if fastest < 0 || fastest == current {
+		return
+	}
+	if c.healthy[current] && c.latencies[current] > 0 &&
+		float64(c.latencies[current]) <= float64(c.latencies[fastest])*(1+c.latencyMargin) {
+		return
+	}
+
+	c.preferred = fastest
+	if c.logger != nil {
+		c.logger.LogAttrs(context.Background(), slog.LevelInfo, "bothan preferred proxy changed",
+			slog.String("from", c.urls[current]), slog.String("to", c.urls[fastest]),
+			slog.Duration("latency", c.latencies[fastest]))
+	}
 }
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"crypto/tls"
+	"net/http"
+)
+
+// RestOption configures a RestClient. The options are applied by NewRest,
+// NewRestWithResolver and NewRestWithFailover, so that new settings do not need new
+// constructors.
+type RestOption func(*RestClient)
+
+// WithRestConsumer tags every request of the client with the given consumer name, see
+// ConsumerHeader.
+func WithRestConsumer(name string) RestOption {
+	return func(c *RestClient) {
+		c.SetConsumer(name)
+	}
+}
+
+// WithRestHeaders sends the given headers with every request of the client, e.g. for a load
+// balancer in front of the proxy that routes on headers.
+func WithRestHeaders(headers map[string]string) RestOption {
+	return func(c *RestClient) {
+		if c.headers == nil {
+			c.headers = make(map[string]string, len(headers))
+		}
+		for key, value := range headers {
+			c.headers[key] = value
+		}
+	}
+}
+
+// WithRestTransport wraps the HTTP transport of the client, e.g. to trace or record its
+// requests. It is applied after the other options, whatever the order they are given in, so
+// that WithRestTLS still configures the underlying transport. SetTLSConfig has no effect on
+// a wrapped transport.
+func WithRestTransport(wrap func(http.RoundTripper) http.RoundTripper) RestOption {
+	return func(c *RestClient) {
+		c.wrapTransport = append(c.wrapTransport, wrap)
+	}
+}
+
+// WithRestTLS makes the client use the given TLS configuration for https urls, see
+// SetTLSConfig.
+func WithRestTLS(config *tls.Config) RestOption {
+	return func(c *RestClient) {
+		c.SetTLSConfig(config)
+	}
+}
+
+// WithRestWarningHandler sets the handler of the warnings of the client, see
+// SetWarningHandler.
+func WithRestWarningHandler(h WarningHandler) RestOption {
+	return func(c *RestClient) {
+		c.SetWarningHandler(h)
+	}
+}
+
+// WithRestLatencyRouting makes a client of several proxies, see NewRestWithFailover, send
+// requests to the healthy proxy with the lowest latency instead of the one of highest
+// priority, e.g. the closest of geo-redundant deployments. Every proxy is probed at the health
+// interval, which must not be zero, and its latency is smoothed over the probes. The client
+// sticks to its proxy until another one is faster by more than the given margin, e.g. 0.2 for
+// 20%, so that proxies of similar latency do not alternate.
+func WithRestLatencyRouting(margin float64) RestOption {
+	return func(c *RestClient) {
+		c.latencyMargin = max(margin, 0)
+	}
+}
+
+// WithRestChecksumVerification makes the client check every response body against the
+// SHA-256 hash the proxy sends in the Content-Digest header, so that bodies corrupted by
+// caches or middleboxes on the way are not decoded. A corrupted response is retried on the
+// next proxy like a server error, and ErrChecksumMismatch is returned if all are corrupted.
+// Responses without the header, e.g. of proxies that predate it, are accepted.
+func WithRestChecksumVerification() RestOption {
+	return func(c *RestClient) {
+		c.verifyChecksum = true
+	}
+}
@@ -0,0 +1,608 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestRestFailoverAndRecovery(t *testing.T) {
+	var primaryDown atomic.Bool
+	primaryDown.Store(true)
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if primaryDown.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"prices":[{"signal_id":"primary"}]}`))
+	}))
+	defer primary.Close()
+
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"prices":[{"signal_id":"fallback"}]}`))
+	}))
+	defer fallback.Close()
+
+	c, err := NewRestWithFailover([]string{primary.URL, fallback.URL}, time.Second, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	var warnings []Warning
+	c.SetWarningHandler(func(w Warning) { warnings = append(warnings, w) })
+
+	prices, err := c.QueryPrices([]string{"crypto_price.btcusd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 1 || prices[0].SignalId != "fallback" {
+		t.Fatalf("expected the fallback to serve the request, got %v", prices)
+	}
+	if len(warnings) != 1 || warnings[0].Kind != WarningFallbackEndpoint || warnings[0].Endpoint != fallback.URL {
+		t.Errorf("expected a fallback endpoint warning, got %v", warnings)
+	}
+	if c.isHealthy(0) {
+		t.Fatal("expected the primary to be marked unhealthy")
+	}
+
+	primaryDown.Store(false)
+	deadline := time.Now().Add(time.Second)
+	for !c.isHealthy(0) {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the primary to recover")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	prices, err = c.QueryPrices([]string{"crypto_price.btcusd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 1 || prices[0].SignalId != "primary" {
+		t.Fatalf("expected the primary to serve the request, got %v", prices)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("expected no warning once the primary recovered, got %v", warnings)
+	}
+}
+
+func TestRestFailoverAllDown(t *testing.T) {
+	c, err := NewRestWithFailover([]string{"http://127.0.0.1:1", "http://127.0.0.1:2"}, time.Second, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if _, err := c.QueryPrices([]string{"crypto_price.btcusd"}); err == nil {
+		t.Fatal("expected an error when no proxy is reachable")
+	}
+}
+
+func TestRestLatencyRouting(t *testing.T) {
+	newProxy := func(name string, delay *atomic.Int64) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(time.Duration(delay.Load()))
+			_, _ = w.Write([]byte(`{"prices":[{"signal_id":"` + name + `"}]}`))
+		}))
+	}
+	var farDelay, nearDelay atomic.Int64
+	farDelay.Store(int64(30 * time.Millisecond))
+	far := newProxy("far", &farDelay)
+	defer far.Close()
+	near := newProxy("near", &nearDelay)
+	defer near.Close()
+
+	c, err := NewRestWithFailover([]string{far.URL, near.URL}, time.Second, 10*time.Millisecond, WithRestLatencyRouting(0.2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	var warnings atomic.Int64
+	c.SetWarningHandler(func(Warning) { warnings.Add(1) })
+
+	waitForPreferred := func(expected string) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			prices, err := c.QueryPrices([]string{"crypto_price.btcusd"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if prices[0].SignalId == expected {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected the %s proxy to be preferred", expected)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	waitForPreferred("near")
+	if warnings.Load() != 0 {
+		t.Errorf("expected no warning for the preferred proxy, got %d", warnings.Load())
+	}
+
+	farDelay.Store(0)
+	nearDelay.Store(int64(30 * time.Millisecond))
+	waitForPreferred("far")
+}
+
+func TestRestLatencyRoutingRequiresHealthInterval(t *testing.T) {
+	if _, err := NewRestWithFailover([]string{"http://a", "http://b"}, time.Second, 0, WithRestLatencyRouting(0.2)); err == nil {
+		t.Fatal("expected an error for latency routing without a health interval")
+	}
+}
+
+func TestRestLatencyHysteresis(t *testing.T) {
+	c := newRest([]string{"http://a", "http://b"}, &http.Client{}, []RestOption{WithRestLatencyRouting(0.2)})
+	c.recordProbe(0, true, 100*time.Millisecond)
+	c.recordProbe(1, true, 90*time.Millisecond)
+
+	c.selectPreferred()
+	if _, preferred := c.candidates(); preferred != 0 {
+		t.Fatal("expected the client to stick to a proxy within the margin")
+	}
+
+	c.latencies[1] = 70 * time.Millisecond
+	c.selectPreferred()
+	if _, preferred := c.candidates(); preferred != 1 {
+		t.Fatal("expected the client to switch to a proxy faster by more than the margin")
+	}
+
+	c.latencies[0] = 10 * time.Millisecond
+	c.recordProbe(1, false, 0)
+	c.selectPreferred()
+	candidates, preferred := c.candidates()
+	if preferred != 0 || candidates[0] != 0 {
+		t.Fatalf("expected the client to leave an unhealthy proxy, got %v", candidates)
+	}
+}
+
+func TestRestConsumerHeader(t *testing.T) {
+	var consumer string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		consumer = r.Header.Get(ConsumerHeader)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := NewRest(server.URL, time.Second)
+	c.SetConsumer("band-chain")
+	if _, err := c.QueryPrices([]string{"crypto_price.btcusd"}); err != nil {
+		t.Fatal(err)
+	}
+	if consumer != "band-chain" {
+		t.Errorf("expected consumer band-chain, got %q", consumer)
+	}
+}
+
+func TestRestOptions(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := NewRest(server.URL, time.Second, WithRestConsumer("band-chain"), WithRestHeaders(map[string]string{"X-Route": "eu"}))
+	if _, err := c.QueryPrices([]string{"crypto_price.btcusd"}); err != nil {
+		t.Fatal(err)
+	}
+	if header.Get(ConsumerHeader) != "band-chain" || header.Get("X-Route") != "eu" {
+		t.Errorf("expected the consumer and the extra header, got %v", header)
+	}
+}
+
+func TestRestLogger(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer fallback.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c, err := NewRestWithFailover([]string{primary.URL, fallback.URL}, time.Second, 0, WithRestLogger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if _, err := c.QueryPrices([]string{"crypto_price.btcusd"}); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expected := []string{
+		`level=DEBUG msg="bothan request started" path=/prices signal_ids=[crypto_price.btcusd]`,
+		`level=WARN msg="bothan request attempt failed" path=/prices signal_ids=[crypto_price.btcusd] url=` + primary.URL,
+		`level=DEBUG msg="bothan request finished" path=/prices signal_ids=[crypto_price.btcusd] duration=`,
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d records, got %q", len(expected), lines)
+	}
+	for i, line := range lines {
+		if !strings.Contains(line, expected[i]) {
+			t.Errorf("expected %q to contain %q", line, expected[i])
+		}
+	}
+}
+
+func TestRestNormalizesSignalIDs(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := NewRest(server.URL, time.Second)
+	if _, err := c.QueryPrices([]string{"btc", "eth", "btc"}); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/prices/btc,eth" {
+		t.Errorf("expected duplicates to be removed, got %q", path)
+	}
+
+	path = ""
+	if _, err := c.QueryPrices([]string{"btc", ""}); !errors.Is(err, ErrEmptySignalID) {
+		t.Errorf("expected ErrEmptySignalID, got %v", err)
+	}
+	if path != "" {
+		t.Errorf("expected no request, got %q", path)
+	}
+}
+
+func TestRestServerTiming(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"prices":[],"serverTiming":{"queueTimeUs":"10","processingTimeUs":"20"}}`))
+	}))
+	defer server.Close()
+
+	var timing ServerTiming
+	c := NewRest(server.URL, time.Second)
+	if _, err := c.GetPriceMap(WithServerTiming(context.Background(), &timing), []string{"btc"}); err != nil {
+		t.Fatal(err)
+	}
+	if !timing.Reported || timing.Queue != 10*time.Microsecond || timing.Processing != 20*time.Microsecond {
+		t.Errorf("unexpected timing %+v", timing)
+	}
+}
+
+func TestRestPriceChanges(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/prices/btc,eth/change" || r.URL.Query().Get("window") != "1h0m0s" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"window":"1h0m0s","changes":[` +
+			`{"signal_id":"btc","price":"44000","time":"2024-01-01T01:00:00Z","base_price":"40000","base_time":"2024-01-01T00:00:00Z","percent_change":10},` +
+			`{"signal_id":"eth","price":"3000","time":"2024-01-01T01:00:00Z"}]}`))
+	}))
+	defer server.Close()
+
+	changes, err := NewRest(server.URL, time.Second).PriceChanges(context.Background(), []string{"btc", "eth", "btc"}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %+v", changes)
+	}
+	if btc := changes[0]; btc.BasePrice != "40000" || btc.PercentChange == nil || *btc.PercentChange != 10 || !btc.BaseTime.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected change of btc %+v", btc)
+	}
+	if eth := changes[1]; eth.Price != "3000" || eth.BaseTime != nil || eth.PercentChange != nil {
+		t.Errorf("expected no change of eth, got %+v", eth)
+	}
+}
+
+func TestRestSetActiveSignalIds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/prices/btc,made_up" {
+			_, _ = w.Write([]byte(`{"prices":[{"signalId":"btc","priceStatus":"PRICE_STATUS_UNAVAILABLE"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"prices":[` +
+			`{"signalId":"btc","priceStatus":"PRICE_STATUS_UNAVAILABLE"},` +
+			`{"signalId":"made_up","priceStatus":"PRICE_STATUS_UNSUPPORTED"}]}`))
+	}))
+	defer server.Close()
+
+	c := NewRest(server.URL, time.Second)
+	if err := c.SetActiveSignalIds([]string{"btc"}); err != nil {
+		t.Errorf("expected signals whose price is not available yet to be activated, got %v", err)
+	}
+	err := c.SetActiveSignalIds([]string{"btc", "made_up"})
+	if !errors.Is(err, ErrSignalUnsupported) || !strings.Contains(err.Error(), "made_up") {
+		t.Errorf("expected the unsupported signal to be reported, got %v", err)
+	}
+}
+
+func TestRestDecodesGatewayJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"camel case and enum name", `{"prices":[{"signalId":"btc","price":"1","priceStatus":"PRICE_STATUS_AVAILABLE"}]}`},
+		{"snake case and enum name", `{"prices":[{"signal_id":"btc","price":"1","price_status":"PRICE_STATUS_AVAILABLE"}]}`},
+		{"enum number", `{"prices":[{"signalId":"btc","price":"1","priceStatus":3}]}`},
+		{"unknown fields", `{"prices":[{"signalId":"btc","price":"1","priceStatus":3,"updatedAt":"now"}],"server":"v2"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			prices, err := NewRest(server.URL, time.Second).QueryPrices([]string{"btc"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(prices) != 1 || prices[0].SignalId != "btc" || prices[0].Price != "1" ||
+				prices[0].PriceStatus != proto.PriceStatus_PRICE_STATUS_AVAILABLE {
+				t.Errorf("unexpected prices %v", prices)
+			}
+		})
+	}
+
+	// Statuses added by newer servers decode as unspecified, which is never available.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"prices":[{"signalId":"btc","priceStatus":"PRICE_STATUS_STALE"}]}`))
+	}))
+	defer server.Close()
+	results, err := NewRest(server.URL, time.Second).GetPriceMap(context.Background(), []string{"btc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results["btc"].Status != proto.PriceStatus_PRICE_STATUS_UNSPECIFIED || results["btc"].Err != ErrPriceUnavailable {
+		t.Errorf("expected an unavailable price, got %+v", results["btc"])
+	}
+}
+
+func TestRestClientCertificate(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"prices":[{"signalId":"btc"}]}`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	// The certificate of the test server doubles as the client certificate.
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	cert := server.TLS.Certificates[0]
+
+	c := NewRest(server.URL, time.Second)
+	c.SetTLSConfig(ClientTLSConfig(cert, roots))
+	prices, err := c.QueryPrices([]string{"btc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 1 || prices[0].SignalId != "btc" {
+		t.Errorf("unexpected prices %v", prices)
+	}
+
+	anonymous := NewRest(server.URL, time.Second)
+	anonymous.SetTLSConfig(&tls.Config{RootCAs: roots})
+	if _, err := anonymous.QueryPrices([]string{"btc"}); err == nil {
+		t.Error("expected a request without a client certificate to be rejected")
+	}
+}
+
+func TestRestChecksumVerification(t *testing.T) {
+	const body = `{"prices":[{"signal_id":"btc"}]}`
+	sum := sha256.Sum256([]byte(body))
+	digest := "sha-256=:" + base64.StdEncoding.EncodeToString(sum[:]) + ":"
+
+	corrupted := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(ContentDigestHeader, digest)
+		_, _ = w.Write([]byte(`{"prices":[{"signal_id":"eth"}]}`))
+	}))
+	defer corrupted.Close()
+
+	intact := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(ContentDigestHeader, digest)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer intact.Close()
+
+	c, err := NewRestWithFailover([]string{corrupted.URL, intact.URL}, time.Second, 0, WithRestChecksumVerification())
+	if err != nil {
+		t.Fatal(err)
+	}
+	prices, err := c.QueryPrices([]string{"btc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 1 || prices[0].SignalId != "btc" {
+		t.Fatalf("expected the intact response, got %v", prices)
+	}
+	if c.isHealthy(0) {
+		t.Error("expected the proxy with the corrupted response to be marked unhealthy")
+	}
+
+	c = NewRest(corrupted.URL, time.Second, WithRestChecksumVerification())
+	if _, err := c.QueryPrices([]string{"btc"}); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("expected ErrChecksumMismatch, got %v", err)
+	}
+
+	// The checksum is only verified if asked to.
+	c = NewRest(corrupted.URL, time.Second)
+	if _, err := c.QueryPrices([]string{"btc"}); err != nil {
+		t.Errorf("unexpected error without verification: %v", err)
+	}
+}
+
+func TestVerifyContentDigest(t *testing.T) {
+	body := []byte("hello")
+	sum := sha256.Sum256(body)
+	encoded := base64.StdEncoding.EncodeToString(sum[:])
+
+	tests := []struct {
+		name   string
+		header string
+		ok     bool
+	}{
+		{"missing", "", true},
+		{"match", "sha-256=:" + encoded + ":", true},
+		{"among other algorithms", "sha-512=:AAAA:, sha-256=:" + encoded + ":", true},
+		{"other algorithms only", "sha-512=:AAAA:", true},
+		{"mismatch", "sha-256=:" + base64.StdEncoding.EncodeToString(make([]byte, sha256.Size)) + ":", false},
+		{"not a byte sequence", "sha-256=" + encoded, false},
+		{"invalid base64", "sha-256=:!!:", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyContentDigest(tt.header, body); (err == nil) != tt.ok {
+				t.Errorf("unexpected result %v", err)
+			}
+		})
+	}
+}
+
+func TestRestProbe(t *testing.T) {
+	tests := []struct {
+		status  int
+		healthy bool
+	}{
+		{http.StatusOK, true},
+		{http.StatusNotFound, true},
+		// A server without the stats summary RPC.
+		{http.StatusNotImplemented, true},
+		{http.StatusServiceUnavailable, false},
+		// A proxy rejecting the credentials of the client never reaches its upstream.
+		{http.StatusUnauthorized, false},
+		{http.StatusForbidden, false},
+	}
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+		}))
+		c := newRest([]string{server.URL}, &http.Client{}, nil)
+		if healthy := c.probe(server.URL); healthy != tt.healthy {
+			t.Errorf("%d: expected healthy %v, got %v", tt.status, tt.healthy, healthy)
+		}
+		server.Close()
+	}
+}
+
+func TestRestProbeHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "key" || r.Header.Get(ConsumerHeader) != "band-chain" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	c := newRest([]string{server.URL}, &http.Client{}, []RestOption{WithRestConsumer("band-chain"), WithRestLogger(logger)})
+	if c.probe(server.URL) {
+		t.Error("expected a probe without the key to fail")
+	}
+	if !strings.Contains(logs.String(), "level=ERROR") {
+		t.Errorf("expected the rejected probe to be logged as an error, got %q", logs.String())
+	}
+
+	c = newRest([]string{server.URL}, &http.Client{}, []RestOption{
+		WithRestConsumer("band-chain"),
+		WithRestHeaders(map[string]string{"X-Api-Key": "key"}),
+	})
+	if !c.probe(server.URL) {
+		t.Error("expected the probe to send the headers of the client")
+	}
+}
+
+func TestRestGatewayError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":5,"message":"unknown signal ids: foo","details":[{"@type":"type.googleapis.com/unknown.Detail"}]}`))
+	}))
+	defer server.Close()
+
+	c := NewRest(server.URL, time.Second)
+	_, err := c.QueryPrices([]string{"foo"})
+	if status.Code(err) != codes.NotFound || !strings.Contains(err.Error(), "unknown signal ids: foo") {
+		t.Errorf("expected the status of the gateway, got %v", err)
+	}
+}
+
+// closeCounter counts the response bodies closed by the client.
+type closeCounter struct {
+	transport http.RoundTripper
+	opened    atomic.Int32
+	closed    atomic.Int32
+}
+
+func (c *closeCounter) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	c.opened.Add(1)
+	resp.Body = &countedBody{ReadCloser: resp.Body, closed: &c.closed}
+	return resp, nil
+}
+
+type countedBody struct {
+	io.ReadCloser
+	closed *atomic.Int32
+	once   sync.Once
+}
+
+func (b *countedBody) Close() error {
+	b.once.Do(func() { b.closed.Add(1) })
+	return b.ReadCloser.Close()
+}
+
+func TestRestClosesResponses(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("down"))
+	}))
+	defer down.Close()
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":3,"message":"cost exceeded"}`))
+	}))
+	defer rejecting.Close()
+
+	counter := &closeCounter{transport: http.DefaultTransport}
+	c := newRest([]string{down.URL, rejecting.URL}, &http.Client{Transport: counter}, nil)
+	if _, err := c.QueryPrices([]string{"crypto_price.btcusd"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	if opened, closed := counter.opened.Load(), counter.closed.Load(); opened != 2 || closed != 2 {
+		t.Errorf("expected both responses to be closed, %d of %d were", closed, opened)
+	}
+}
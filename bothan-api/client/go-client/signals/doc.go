@@ -0,0 +1,5 @@
+// Package signals provides constants for the signal IDs of the crypto price registry shipped
+// with the Bothan server.
+package signals
+
+//go:generate go run ../cmd/signalgen -registry ../../../server/registry/crypto_price.json -o signals.go
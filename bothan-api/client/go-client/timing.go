@@ -0,0 +1,17 @@
+package client
+
+import (
+	"context"
+
+	clientv2 "github.com/bandprotocol/bothan/bothan-api/client/go-client/v2"
+)
+
+// ServerTiming is the time the server spent on a price query, see the type of the same name
+// in version 2.
+type ServerTiming = clientv2.ServerTiming
+
+// WithServerTiming returns a context that makes the GetPriceMap calls of the clients of this
+// package store the timing reported by the server in t.
+func WithServerTiming(ctx context.Context, t *ServerTiming) context.Context {
+	return clientv2.WithServerTiming(ctx, t)
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	clientv2 "github.com/bandprotocol/bothan/bothan-api/client/go-client/v2"
+)
+
+// ClientTLSConfig returns the TLS configuration of a client that presents the given
+// certificate to servers requiring mutual TLS, see the function of the same name in v2.
+func ClientTLSConfig(cert tls.Certificate, rootCAs *x509.CertPool) *tls.Config {
+	return clientv2.ClientTLSConfig(cert, rootCAs)
+}
+
+// LoadClientTLSConfig is like ClientTLSConfig, with the certificate, its key and the root CAs
+// read from PEM files. An empty caFile verifies the server against the system roots.
+func LoadClientTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	return clientv2.LoadClientTLSConfig(certFile, keyFile, caFile)
+}
+
+// WithTLS returns a dial option for NewGRPC that connects over TLS with the given
+// configuration, e.g. one of ClientTLSConfig.
+func WithTLS(config *tls.Config) grpc.DialOption {
+	return grpc.WithTransportCredentials(credentials.NewTLS(config))
+}
+
+// SetTLSConfig makes the client use the given TLS configuration for https urls, e.g. one of
+// ClientTLSConfig to present a client certificate. It must be called before the client is
+// used.
+func (c *RestClient) SetTLSConfig(config *tls.Config) {
+	if transport, ok := c.httpClient.Transport.(*http.Transport); ok {
+		transport.TLSClientConfig = config
+	}
+}
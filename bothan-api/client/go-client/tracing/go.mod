@@ -0,0 +1,39 @@
+module github.com/bandprotocol/bothan/bothan-api/client/go-client/tracing
+
+go 1.22.0
+
+replace github.com/bandprotocol/bothan/bothan-api/client/go-client => ../
+
+replace github.com/bandprotocol/bothan/bothan-api/client/go-client/v2 => ../v2
+
+replace github.com/bandprotocol/bothan/bothan-api/client/go-client/query => ../query
+
+require (
+	github.com/bandprotocol/bothan/bothan-api/client/go-client v0.1.0
+	github.com/bandprotocol/bothan/bothan-api/client/go-client/query v0.1.0
+	github.com/bandprotocol/bothan/bothan-api/client/go-client/v2 v2.0.0
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/sdk v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
+	google.golang.org/grpc v1.63.2
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/go-querystring v1.1.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.19.1 // indirect
+	github.com/json-iterator/go v1.1.12 // indirect
+	github.com/levigross/grequests v0.0.0-20231203190023-9c307ef1f48d // indirect
+	github.com/modern-go/concurrent v0.0.0-20180228061459-e0a39a4cb421 // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
+	go.opentelemetry.io/otel/metric v1.28.0 // indirect
+	golang.org/x/net v0.21.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	golang.org/x/time v0.5.0 // indirect
+	google.golang.org/genproto/googleapis/api v0.0.0-20240506185236-b8a5c65736ae // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240429193739-8cf5692501f6 // indirect
+	google.golang.org/protobuf v1.34.1 // indirect
+)
@@ -0,0 +1,178 @@
+// Package tracing adds OpenTelemetry spans to the calls of the Bothan clients, so that the
+// latency of price queries shows up in the distributed traces of an application. Every span
+// carries the method, the number of queried signals and the status code of the call, and the
+// trace context is propagated to the server.
+//
+// The gRPC clients take the interceptor of UnaryClientInterceptor:
+//
+//	c, err := client.New(target, client.WithUnaryInterceptor(tracing.UnaryClientInterceptor()))
+//
+// and the REST client takes the transport of Transport:
+//
+//	c := client.NewRest(url, timeout, client.WithRestTransport(tracing.Transport))
+//
+// It is a separate module so that the client does not depend on OpenTelemetry.
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// instrumentationName is the name of the tracer of the spans.
+const instrumentationName = "github.com/bandprotocol/bothan/bothan-api/client/go-client/tracing"
+
+// SignalCountKey is the attribute of the number of signals queried by a call.
+const SignalCountKey = attribute.Key("bothan.signal_count")
+
+// Option configures the tracing of a client.
+type Option func(*options)
+
+type options struct {
+	provider    trace.TracerProvider
+	propagators propagation.TextMapPropagator
+}
+
+// WithTracerProvider sets the provider of the tracer of the spans. The global provider is used
+// by default.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(o *options) {
+		o.provider = provider
+	}
+}
+
+// WithPropagators sets the propagators of the trace context. The global propagators are used
+// by default.
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return func(o *options) {
+		o.propagators = propagators
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{provider: otel.GetTracerProvider(), propagators: otel.GetTextMapPropagator()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// UnaryClientInterceptor returns an interceptor that records a span for every call of a gRPC
+// client and propagates its trace context in the metadata of the call.
+func UnaryClientInterceptor(opts ...Option) grpc.UnaryClientInterceptor {
+	o := newOptions(opts)
+	tracer := o.provider.Tracer(instrumentationName)
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		ctx, span := tracer.Start(ctx, strings.TrimPrefix(method, "/"), trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attribute.String("rpc.system", "grpc"), attribute.String("rpc.method", method)))
+		defer span.End()
+		if r, ok := req.(*proto.QueryPricesRequest); ok {
+			span.SetAttributes(SignalCountKey.Int(len(r.SignalIds)))
+		}
+
+		md, _ := metadata.FromOutgoingContext(ctx)
+		md = md.Copy()
+		o.propagators.Inject(ctx, metadataCarrier(md))
+		ctx = metadata.NewOutgoingContext(ctx, md)
+
+		err := invoker(ctx, method, req, reply, cc, callOpts...)
+		code := status.Code(err)
+		span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(code)))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelcodes.Error, code.String())
+		}
+		return err
+	}
+}
+
+// metadataCarrier adapts gRPC metadata to a propagation.TextMapCarrier.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Transport wraps the transport of a REST client to record a span for every request and
+// propagate its trace context in the headers of the request, with the default options. Use
+// NewTransport for other options.
+func Transport(next http.RoundTripper) http.RoundTripper {
+	return NewTransport(next)
+}
+
+// NewTransport is like Transport with the given options.
+func NewTransport(next http.RoundTripper, opts ...Option) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	o := newOptions(opts)
+	return &transport{next: next, tracer: o.provider.Tracer(instrumentationName), propagators: o.propagators}
+}
+
+type transport struct {
+	next        http.RoundTripper
+	tracer      trace.Tracer
+	propagators propagation.TextMapPropagator
+}
+
+func (t *transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx, span := t.tracer.Start(r.Context(), r.Method+" "+route(r.URL.Path), trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("http.request.method", r.Method), attribute.String("url.path", r.URL.Path)))
+	defer span.End()
+	if ids, ok := strings.CutPrefix(r.URL.Path, "/prices/"); ok {
+		span.SetAttributes(SignalCountKey.Int(strings.Count(ids, ",") + 1))
+	}
+
+	r = r.Clone(ctx)
+	t.propagators.Inject(ctx, propagation.HeaderCarrier(r.Header))
+
+	resp, err := t.next.RoundTrip(r)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(otelcodes.Error, resp.Status)
+	}
+	return resp, nil
+}
+
+// route returns the route of a path of the proxy for span names, which leaves out the signal
+// IDs to keep the number of span names small.
+func route(path string) string {
+	if strings.HasPrefix(path, "/prices/") {
+		return "/prices/{signal_ids}"
+	}
+	return path
+}
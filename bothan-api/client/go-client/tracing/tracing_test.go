@@ -0,0 +1,119 @@
+package tracing
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+
+	client "github.com/bandprotocol/bothan/bothan-api/client/go-client"
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+	clientv2 "github.com/bandprotocol/bothan/bothan-api/client/go-client/v2"
+)
+
+type queryServer struct {
+	proto.UnimplementedQueryServer
+	traceparent chan string
+}
+
+func (s *queryServer) Prices(ctx context.Context, req *proto.QueryPricesRequest) (*proto.QueryPricesResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.traceparent <- firstOf(md.Get("traceparent"))
+	return &proto.QueryPricesResponse{}, nil
+}
+
+func firstOf(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func newRecorder() (*tracetest.SpanRecorder, Option, Option) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	return recorder, WithTracerProvider(provider), WithPropagators(propagation.TraceContext{})
+}
+
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	listener := bufconn.Listen(1 << 20)
+	server := &queryServer{traceparent: make(chan string, 1)}
+	grpcServer := grpc.NewServer()
+	proto.RegisterQueryServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	recorder, provider, propagators := newRecorder()
+	dialer := grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	})
+	c, err := clientv2.New("passthrough:///bufconn", clientv2.WithDialOptions(dialer),
+		clientv2.WithUnaryInterceptor(UnaryClientInterceptor(provider, propagators)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if _, err := c.Prices(context.Background(), []string{"btc", "eth"}); err != nil {
+		t.Fatal(err)
+	}
+	if traceparent := <-server.traceparent; traceparent == "" {
+		t.Error("expected the trace context to be propagated")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Name() != "query.Query/Prices" {
+		t.Fatalf("expected a span of the call, got %v", spans)
+	}
+	attrs := attributes(spans[0])
+	if attrs[SignalCountKey].AsInt64() != 2 || attrs["rpc.grpc.status_code"].AsInt64() != 0 {
+		t.Errorf("unexpected attributes %v", attrs)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	recorder, provider, propagators := newRecorder()
+	wrap := func(next http.RoundTripper) http.RoundTripper {
+		return NewTransport(next, provider, propagators)
+	}
+	c := client.NewRest(server.URL, 0, client.WithRestTransport(wrap))
+	if _, err := c.QueryPrices([]string{"btc", "eth", "sol"}); err != nil {
+		t.Fatal(err)
+	}
+	if traceparent == "" {
+		t.Error("expected the trace context to be propagated")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Name() != "GET /prices/{signal_ids}" {
+		t.Fatalf("expected a span of the request, got %v", spans)
+	}
+	attrs := attributes(spans[0])
+	if attrs[SignalCountKey].AsInt64() != 3 || attrs["http.response.status_code"].AsInt64() != http.StatusOK {
+		t.Errorf("unexpected attributes %v", attrs)
+	}
+}
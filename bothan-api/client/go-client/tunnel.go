@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc"
+)
+
+// TunnelOption configures the WebSocket connection of a tunnel.
+type TunnelOption func(*websocket.Config)
+
+// WithTunnelHeaders sends the given headers with the WebSocket handshake of the tunnel, e.g. the
+// API key or the "Authorization: Bearer <token>" the proxy requires to open it.
+func WithTunnelHeaders(headers map[string]string) TunnelOption {
+	return func(config *websocket.Config) {
+		for key, value := range headers {
+			config.Header.Set(key, value)
+		}
+	}
+}
+
+// WithWebSocketTunnel returns a dial option that carries the gRPC connection through the
+// WebSocket tunnel of a bothan-api-proxy, e.g. "wss://bothan.example.com/tunnel", for
+// environments where only HTTP(S) egress is allowed. The url given to NewGRPC is then only
+// used as the authority of the calls.
+func WithWebSocketTunnel(tunnelURL string, opts ...TunnelOption) grpc.DialOption {
+	return grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return DialWebSocketTunnel(ctx, tunnelURL, opts...)
+	})
+}
+
+// DialWebSocketTunnel opens a WebSocket connection to the tunnel at tunnelURL and returns it
+// as a net.Conn carrying raw bytes to the upstream gRPC server. The origin of the handshake is
+// the tunnel url, which the proxy accepts as its own origin.
+func DialWebSocketTunnel(ctx context.Context, tunnelURL string, opts ...TunnelOption) (net.Conn, error) {
+	config, err := websocket.NewConfig(tunnelURL, tunnelURL)
+	if err != nil {
+		return nil, err
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	location := config.Location
+	var conn net.Conn
+	switch location.Scheme {
+	case "ws":
+		dialer := &net.Dialer{}
+		conn, err = dialer.DialContext(ctx, "tcp", hostPort(location.Host, "80"))
+	case "wss":
+		dialer := &tls.Dialer{Config: &tls.Config{ServerName: location.Hostname()}}
+		conn, err = dialer.DialContext(ctx, "tcp", hostPort(location.Host, "443"))
+	default:
+		return nil, fmt.Errorf("unsupported tunnel scheme %q", location.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Bound the handshake by the context deadline, the deadline is cleared once connected.
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	ws, err := websocket.NewClient(config, conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+	ws.PayloadType = websocket.BinaryFrame
+
+	return ws, nil
+}
+
+func hostPort(host, defaultPort string) string {
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		return net.JoinHostPort(host, defaultPort)
+	}
+
+	return host
+}
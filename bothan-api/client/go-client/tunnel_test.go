@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+func TestDialWebSocketTunnel(t *testing.T) {
+	headers := make(chan string, 1)
+	server := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		headers <- ws.Request().Header.Get("Authorization")
+		ws.PayloadType = websocket.BinaryFrame
+		_, _ = io.Copy(ws, ws)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/tunnel"
+	conn, err := DialWebSocketTunnel(ctx, url, WithTunnelHeaders(map[string]string{"Authorization": "Bearer secret"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if auth := <-headers; auth != "Bearer secret" {
+		t.Errorf("expected the headers to be sent with the handshake, got authorization %q", auth)
+	}
+
+	payload := []byte("PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n")
+	if _, err := conn.Write(payload); err != nil {
+		t.Fatal(err)
+	}
+	echoed := make([]byte, len(payload))
+	if _, err := io.ReadFull(conn, echoed); err != nil {
+		t.Fatal(err)
+	}
+	if string(echoed) != string(payload) {
+		t.Errorf("expected %q, got %q", payload, echoed)
+	}
+
+	if _, err := DialWebSocketTunnel(ctx, "http://localhost/tunnel"); err == nil {
+		t.Error("expected an error for an unsupported scheme")
+	}
+}
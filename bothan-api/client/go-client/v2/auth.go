@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// TokenSource returns the bearer token to authenticate a call with, e.g. an access token that
+// is cached and refreshed before it expires. It is called for every call, and an error fails
+// the call without sending it.
+type TokenSource func(ctx context.Context) (string, error)
+
+// StaticToken returns a TokenSource that always returns the given token.
+func StaticToken(token string) TokenSource {
+	return func(context.Context) (string, error) {
+		return token, nil
+	}
+}
+
+// TokenCredentials returns per-call credentials that send the tokens of the source as
+// "authorization: Bearer <token>" metadata, which an authenticating gateway in front of the
+// server or a bothan-api-proxy reads. The credentials refuse to send tokens over connections
+// without transport security, see WithTLS.
+func TokenCredentials(source TokenSource) credentials.PerRPCCredentials {
+	return tokenCredentials{source: source}
+}
+
+type tokenCredentials struct {
+	source TokenSource
+}
+
+func (c tokenCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	token, err := c.source(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
+
+func (tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// WithToken authenticates every call of the client with the given bearer token.
+func WithToken(token string) Option {
+	return WithTokenSource(StaticToken(token))
+}
+
+// WithTokenSource authenticates every call of the client with a bearer token of the given
+// source. Unlike credentials given with WithDialOptions, it also applies to clients on shared
+// connections, see NewFromConn and Pool.
+func WithTokenSource(source TokenSource) Option {
+	return func(o *options) {
+		o.callOptions = append(o.callOptions, grpc.PerRPCCredentials(TokenCredentials(source)))
+		o.authenticated = true
+	}
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// serveTLS serves the fake server over TLS and returns its address and the pool verifying it.
+func serveTLS(t *testing.T, server *fakeQueryServer) (string, *x509.CertPool) {
+	t.Helper()
+
+	certPEM, keyPEM := issue(t, nil, true)
+	cert := keyPair(t, certPEM, keyPEM)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert.Leaf)
+
+	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{cert}})))
+	proto.RegisterQueryServer(grpcServer, server)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	return listener.Addr().String(), pool
+}
+
+func TestWithToken(t *testing.T) {
+	server := &fakeQueryServer{auth: make(chan string, 1)}
+	addr, pool := serveTLS(t, server)
+
+	c, err := New(addr, WithTLS(&tls.Config{RootCAs: pool}), WithToken("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if _, err := c.Prices(context.Background(), []string{"btc"}); err != nil {
+		t.Fatal(err)
+	}
+	if auth := <-server.auth; auth != "Bearer secret" {
+		t.Errorf("expected the bearer token, got %q", auth)
+	}
+}
+
+func TestWithTokenSource(t *testing.T) {
+	server := &fakeQueryServer{auth: make(chan string, 1)}
+	addr, pool := serveTLS(t, server)
+
+	errExpired := errors.New("token expired")
+	token, tokenErr := "first", error(nil)
+	source := func(context.Context) (string, error) { return token, tokenErr }
+
+	// Tokens are attached per call, so they also reach the server on shared connections.
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: pool})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	c := NewFromConn(conn, WithTokenSource(source))
+
+	for _, expected := range []string{"first", "second"} {
+		token = expected
+		if _, err := c.Prices(context.Background(), []string{"btc"}); err != nil {
+			t.Fatal(err)
+		}
+		if auth := <-server.auth; auth != "Bearer "+expected {
+			t.Errorf("expected the token %q, got %q", expected, auth)
+		}
+	}
+
+	tokenErr = errExpired
+	if _, err := c.Prices(context.Background(), []string{"btc"}); err == nil {
+		t.Error("expected the call to fail without a token")
+	}
+}
+
+func TestWithTokenRequiresTLS(t *testing.T) {
+	server := &fakeQueryServer{auth: make(chan string, 1)}
+	c := newTestClient(t, server, WithToken("secret"))
+
+	if _, err := c.Prices(context.Background(), []string{"btc"}); err == nil {
+		t.Error("expected the token to be refused on an insecure connection")
+	}
+	if server.calls != 0 {
+		t.Errorf("expected no call to reach the server, got %d", server.calls)
+	}
+}
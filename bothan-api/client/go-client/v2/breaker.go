@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrCircuitOpen is the error of calls rejected by an open CircuitBreaker. Its gRPC code is
+// Unavailable.
+var ErrCircuitOpen = status.Error(codes.Unavailable, "circuit breaker is open")
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets all calls through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects all calls until the cool-down has passed.
+	CircuitOpen
+	// CircuitHalfOpen lets a single trial call through, which closes the circuit if it
+	// succeeds and opens it again if it fails.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker makes calls to a dead server fail fast with ErrCircuitOpen instead of piling
+// up timeouts. It opens after a number of consecutive failed calls, and lets a trial call
+// through once the cool-down has passed. Failures are calls that fail with Unavailable,
+// DeadlineExceeded, ResourceExhausted, Internal or Unknown; other errors show that the server
+// is up. A CircuitBreaker is safe for concurrent use and can be shared by the clients of the
+// same server.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// NewCircuitBreaker creates a circuit breaker that opens after threshold consecutive failures,
+// at least one, for the given cool-down.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{threshold: max(threshold, 1), cooldown: cooldown}
+}
+
+// Allow returns ErrCircuitOpen if a call must not be made, and otherwise nil, after which the
+// result of the call must be passed to Record. A nil CircuitBreaker allows every call.
+func (b *CircuitBreaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state(time.Now()) {
+	case CircuitOpen:
+		return ErrCircuitOpen
+	case CircuitHalfOpen:
+		b.probing = true
+	}
+
+	return nil
+}
+
+// Record records the result of a call that was allowed. Calls cancelled by their caller tell
+// nothing about the server and are ignored.
+func (b *CircuitBreaker) Record(err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	switch {
+	case errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled:
+	case isFailure(err):
+		b.failures++
+		if b.failures >= b.threshold {
+			b.openUntil = time.Now().Add(b.cooldown)
+		}
+	default:
+		b.failures = 0
+	}
+}
+
+// State returns the current state of the circuit breaker.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state(time.Now())
+}
+
+// state returns the state at the given time. A half-open circuit with a trial call in flight
+// is reported as open.
+func (b *CircuitBreaker) state(now time.Time) CircuitState {
+	switch {
+	case b.failures < b.threshold:
+		return CircuitClosed
+	case now.Before(b.openUntil) || b.probing:
+		return CircuitOpen
+	default:
+		return CircuitHalfOpen
+	}
+}
+
+func isFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown:
+		return true
+	default:
+		return false
+	}
+}
+
+// WithCircuitBreaker makes every call of the client go through the given circuit breaker, so
+// that calls fail fast with a *CallError wrapping ErrCircuitOpen while the server is down.
+func WithCircuitBreaker(b *CircuitBreaker) Option {
+	return func(o *options) {
+		o.breaker = b
+	}
+}
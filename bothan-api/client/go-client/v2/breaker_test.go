@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	b := NewCircuitBreaker(2, 50*time.Millisecond)
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+
+	b.Record(unavailable)
+	b.Record(status.Error(codes.InvalidArgument, "empty signal id"))
+	b.Record(unavailable)
+	if state := b.State(); state != CircuitClosed {
+		t.Fatalf("expected a response of the server to reset the failures, got %v", state)
+	}
+
+	b.Record(unavailable)
+	if state := b.State(); state != CircuitOpen {
+		t.Fatalf("expected the circuit to open after 2 failures, got %v", state)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) || status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if state := b.State(); state != CircuitHalfOpen {
+		t.Fatalf("expected the circuit to be half-open after the cool-down, got %v", state)
+	}
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected a trial call to be allowed, got %v", err)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected a single trial call, got %v", err)
+	}
+	b.Record(unavailable)
+	if state := b.State(); state != CircuitOpen {
+		t.Fatalf("expected a failed trial call to open the circuit, got %v", state)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if err := b.Allow(); err != nil {
+		t.Fatal(err)
+	}
+	b.Record(nil)
+	if state := b.State(); state != CircuitClosed {
+		t.Fatalf("expected a successful trial call to close the circuit, got %v", state)
+	}
+}
+
+func TestCircuitBreakerIgnoresCancellation(t *testing.T) {
+	b := NewCircuitBreaker(1, time.Minute)
+
+	b.Record(context.Canceled)
+	b.Record(status.Error(codes.Canceled, "context canceled"))
+	if state := b.State(); state != CircuitClosed {
+		t.Errorf("expected cancelled calls to be ignored, got %v", state)
+	}
+}
+
+func TestWithCircuitBreaker(t *testing.T) {
+	server := &fakeQueryServer{err: status.Error(codes.Unavailable, "registry not loaded")}
+	c := newTestClient(t, server, WithCircuitBreaker(NewCircuitBreaker(2, time.Minute)))
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Prices(context.Background(), []string{"btc"}); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d: expected the circuit to be closed", i)
+		}
+	}
+
+	_, err := c.Prices(context.Background(), []string{"btc"})
+	var callErr *CallError
+	if !errors.As(err, &callErr) || !errors.Is(err, ErrCircuitOpen) || callErr.Code() != codes.Unavailable {
+		t.Fatalf("expected a CallError wrapping ErrCircuitOpen, got %v", err)
+	}
+	if _, err := c.Signals(context.Background()); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected Signals to fail fast, got %v", err)
+	}
+	if server.calls != 2 {
+		t.Errorf("expected the open circuit to reject calls before the server, got %d calls", server.calls)
+	}
+	if report := debugReport(t, c); report.CircuitBreaker != "open" {
+		t.Errorf("expected the report to show the open circuit, got %q", report.CircuitBreaker)
+	}
+}
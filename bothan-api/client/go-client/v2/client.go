@@ -0,0 +1,392 @@
+package client
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// Client queries a Bothan server over gRPC. It is safe for concurrent use.
+type Client struct {
+	// close releases the connection of the client, nil if the connection is owned by the
+	// caller of NewFromConn.
+	close       func() error
+	target      string
+	conn        grpc.ClientConnInterface
+	query       proto.QueryClient
+	timeout     time.Duration
+	consumer    string
+	callOptions []grpc.CallOption
+	breaker     *CircuitBreaker
+	strict      bool
+
+	config DebugConfig
+	stats  callStats
+}
+
+// New creates a client for the server at the given target, e.g. "localhost:50051". The
+// connection is insecure unless the dial options given with WithDialOptions say otherwise.
+func New(target string, opts ...Option) (*Client, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	dialOptions := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, o.dialOptions...)
+	connection, err := grpc.Dial(target, dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newClient(connection, o)
+	c.close = connection.Close
+	return c, nil
+}
+
+// NewFromConn creates a client on an existing connection, e.g. one shared with other gRPC
+// clients of the application. The dial options of WithDialOptions are ignored and Close
+// leaves the connection open.
+func NewFromConn(conn grpc.ClientConnInterface, opts ...Option) *Client {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	o.dialOptions = nil
+	c := newClient(conn, o)
+	c.config.SharedConn = true
+	return c
+}
+
+func newClient(conn grpc.ClientConnInterface, o options) *Client {
+	c := &Client{
+		conn:        conn,
+		query:       proto.NewQueryClient(conn),
+		timeout:     o.timeout,
+		consumer:    o.consumer,
+		callOptions: o.callOptions,
+		breaker:     o.breaker,
+		strict:      o.strict,
+		config: DebugConfig{
+			Timeout:       o.timeout.String(),
+			Consumer:      o.consumer,
+			TLS:           o.tls,
+			Authenticated: o.authenticated,
+			DialOptions:   len(o.dialOptions),
+			Strict:        o.strict,
+		},
+	}
+	if target, ok := conn.(interface{ Target() string }); ok {
+		c.target = target.Target()
+	}
+	return c
+}
+
+// Raw returns the generated gRPC client of the query service, for methods the client does not
+// wrap yet. Calls made with it bypass the options of the client.
+func (c *Client) Raw() proto.QueryClient {
+	return c.query
+}
+
+// Close closes the connection of the client, unless it was created with NewFromConn. Clients
+// of a Pool release their reference to the shared connection instead.
+func (c *Client) Close() error {
+	if c.close == nil {
+		return nil
+	}
+	return c.close()
+}
+
+// State returns the state of the connection of the client. Connections given to NewFromConn
+// that are not a *grpc.ClientConn do not report their state and are assumed to be ready.
+func (c *Client) State() connectivity.State {
+	conn, ok := c.conn.(*grpc.ClientConn)
+	if !ok {
+		return connectivity.Ready
+	}
+	return conn.GetState()
+}
+
+// WaitForReady connects the client if its connection is idle and blocks until the connection
+// is ready, the context is done, in which case it returns the error of the context, or the
+// connection is closed, in which case it returns ErrConnectionClosed.
+func (c *Client) WaitForReady(ctx context.Context) error {
+	conn, ok := c.conn.(*grpc.ClientConn)
+	if !ok {
+		return nil
+	}
+
+	for {
+		switch state := conn.GetState(); state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.Shutdown:
+			return ErrConnectionClosed
+		default:
+			if state == connectivity.Idle {
+				conn.Connect()
+			}
+			if !conn.WaitForStateChange(ctx, state) {
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// Price is the price of a single signal of a query.
+type Price struct {
+	SignalID string
+	// Value is the decimal price of the signal, empty unless Status is available.
+	Value string
+	// Fixed is the price as a fixed-point number, with a nil mantissa unless Status is
+	// available and the price is a valid number.
+	Fixed  FixedPoint
+	Status proto.PriceStatus
+	// Time is the time of the oldest source data the price was computed from, as reported by
+	// the server. It is the time the response was received if the server did not report one,
+	// e.g. for prices that are not available.
+	Time time.Time
+	// Err is a *SignalError if the price of the signal cannot be used.
+	Err error
+}
+
+// Prices queries the prices of the given signals, which may include references to signal
+// groups. The prices are returned in the order of the signals, with every group replaced by
+// its signals and duplicates removed. The returned error is only set if the query is invalid,
+// see NormalizeSignalIDs and WithStrict, or the call itself failed, errors of individual
+// signals are reported in their Price. The time the server spent on the query is reported to the
+// ServerTimings of the context, see WithServerTiming.
+func (c *Client) Prices(ctx context.Context, signalIDs []string) ([]Price, error) {
+	signalIDs, err := NormalizeSignalIDs(signalIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.queryPrices(ctx, &proto.QueryPricesRequest{SignalIds: signalIDs, Strict: c.strict})
+	if err != nil {
+		return nil, err
+	}
+
+	prices := newPrices(signalIDs, resp, time.Now())
+	if c.strict {
+		if err := unsupportedSignalIDs(prices); err != nil {
+			return nil, err
+		}
+	}
+	return prices, nil
+}
+
+// PricesResponse queries the prices of the given signals as given and returns the response of
+// the server unchanged, for callers that need its messages rather than Prices, e.g. the client
+// of version 1. Unlike Prices, the signal IDs are not normalized and WithStrict does not apply.
+func (c *Client) PricesResponse(ctx context.Context, signalIDs []string) (*proto.QueryPricesResponse, error) {
+	return c.queryPrices(ctx, &proto.QueryPricesRequest{SignalIds: signalIDs})
+}
+
+// queryPrices makes a Prices call, reporting the time the server spent on it to the
+// ServerTimings of the context.
+func (c *Client) queryPrices(ctx context.Context, req *proto.QueryPricesRequest) (*proto.QueryPricesResponse, error) {
+	if err := c.breaker.Allow(); err != nil {
+		return nil, &CallError{Method: proto.Query_Prices_FullMethodName, Err: err}
+	}
+
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	var trailer metadata.MD
+	callOptions := c.callOptions
+	if req.Strict {
+		callOptions = append(slices.Clip(callOptions), grpc.Trailer(&trailer))
+	}
+
+	start := time.Now()
+	resp, err := c.query.Prices(ctx, req, callOptions...)
+	c.stats.record(proto.Query_Prices_FullMethodName, start, err)
+	c.breaker.Record(err)
+	if err != nil {
+		if unknown := unknownSignalIDs(err, trailer); unknown != nil {
+			return nil, unknown
+		}
+		return nil, &CallError{Method: proto.Query_Prices_FullMethodName, Err: err}
+	}
+	ReportServerTiming(ctx, resp.ServerTiming)
+
+	return resp, nil
+}
+
+// Signals returns every signal known to the registry of the server, querying it page by page.
+func (c *Client) Signals(ctx context.Context) ([]*proto.SignalInfo, error) {
+	var signals []*proto.SignalInfo
+	pageToken := ""
+	for {
+		resp, err := c.signals(ctx, pageToken)
+		if err != nil {
+			return nil, &CallError{Method: proto.Query_Signals_FullMethodName, Err: err}
+		}
+
+		signals = append(signals, resp.Signals...)
+		if resp.NextPageToken == "" {
+			return signals, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func (c *Client) signals(ctx context.Context, pageToken string) (*proto.QuerySignalsResponse, error) {
+	if err := c.breaker.Allow(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := c.query.Signals(ctx, &proto.QuerySignalsRequest{PageToken: pageToken}, c.callOptions...)
+	c.stats.record(proto.Query_Signals_FullMethodName, start, err)
+	c.breaker.Record(err)
+	return resp, err
+}
+
+// Registry returns the registry the server loaded, with its configured version and the
+// SHA-256 hash of its file, e.g. to verify that a server uses the intended registry.
+func (c *Client) Registry(ctx context.Context) (*proto.QueryRegistryResponse, error) {
+	if err := c.breaker.Allow(); err != nil {
+		return nil, &CallError{Method: proto.Query_Registry_FullMethodName, Err: err}
+	}
+
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := c.query.Registry(ctx, &proto.QueryRegistryRequest{}, c.callOptions...)
+	c.stats.record(proto.Query_Registry_FullMethodName, start, err)
+	c.breaker.Record(err)
+	if err != nil {
+		return nil, &CallError{Method: proto.Query_Registry_FullMethodName, Err: err}
+	}
+	return resp, nil
+}
+
+// StatsSummary returns the number of signals of the server by price status, the update times
+// of the oldest and newest prices, the registry version and the uptime of the server, e.g.
+// for a status page. The statuses are those of the last price query of each signal, so the
+// call is cheap and does not make the server query its sources.
+func (c *Client) StatsSummary(ctx context.Context) (*proto.QueryStatsSummaryResponse, error) {
+	if err := c.breaker.Allow(); err != nil {
+		return nil, &CallError{Method: proto.Query_StatsSummary_FullMethodName, Err: err}
+	}
+
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := c.query.StatsSummary(ctx, &proto.QueryStatsSummaryRequest{}, c.callOptions...)
+	c.stats.record(proto.Query_StatsSummary_FullMethodName, start, err)
+	c.breaker.Record(err)
+	if err != nil {
+		return nil, &CallError{Method: proto.Query_StatsSummary_FullMethodName, Err: err}
+	}
+	return resp, nil
+}
+
+// ActiveSignalIDs returns the signal IDs the server keeps up to date, ordered by signal ID:
+// the signals that were queried since the server started and that a source supports. Unlike
+// Signals, it tells signals that were never queried apart from those whose price is
+// unavailable, e.g. to reconcile the signals a deployment should serve with those it does.
+func (c *Client) ActiveSignalIDs(ctx context.Context) ([]string, error) {
+	if err := c.breaker.Allow(); err != nil {
+		return nil, &CallError{Method: proto.Query_ActiveSignals_FullMethodName, Err: err}
+	}
+
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := c.query.ActiveSignals(ctx, &proto.QueryActiveSignalsRequest{}, c.callOptions...)
+	c.stats.record(proto.Query_ActiveSignals_FullMethodName, start, err)
+	c.breaker.Record(err)
+	if err != nil {
+		return nil, &CallError{Method: proto.Query_ActiveSignals_FullMethodName, Err: err}
+	}
+	return resp.SignalIds, nil
+}
+
+// callContext returns the context of a call, which carries the consumer name and the timeout
+// of the client.
+func (c *Client) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.consumer != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, strings.ToLower(ConsumerHeader), c.consumer)
+	}
+	if c.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// newPrices maps the prices of a response to the queried signals, after expanding the groups
+// the server reported.
+func newPrices(signalIDs []string, resp *proto.QueryPricesResponse, t time.Time) []Price {
+	groups := make(map[string][]string, len(resp.Expansions))
+	for _, expansion := range resp.Expansions {
+		groups[expansion.Group] = expansion.SignalIds
+	}
+
+	data := make(map[string]*proto.PriceData, len(resp.Prices))
+	for _, price := range resp.Prices {
+		data[price.SignalId] = price
+	}
+
+	prices := make([]Price, 0, len(signalIDs))
+	seen := make(map[string]struct{}, len(signalIDs))
+	add := func(signalID string) {
+		if _, ok := seen[signalID]; ok {
+			return
+		}
+		seen[signalID] = struct{}{}
+		prices = append(prices, newPrice(signalID, data[signalID], t))
+	}
+	for _, signalID := range signalIDs {
+		if members, ok := groups[signalID]; ok {
+			for _, member := range members {
+				add(member)
+			}
+		} else {
+			add(signalID)
+		}
+	}
+
+	return prices
+}
+
+func newPrice(signalID string, data *proto.PriceData, t time.Time) Price {
+	if data == nil {
+		return Price{SignalID: signalID, Time: t, Err: &SignalError{SignalID: signalID, Err: ErrPriceMissing}}
+	}
+
+	price := Price{SignalID: signalID, Value: data.Price, Status: data.PriceStatus, Time: t}
+	if data.Timestamp != 0 {
+		price.Time = time.Unix(int64(data.Timestamp), 0)
+	}
+	switch data.PriceStatus {
+	case proto.PriceStatus_PRICE_STATUS_AVAILABLE:
+		fixed, err := newFixedPrice(data)
+		if err != nil {
+			price.Err = &SignalError{SignalID: signalID, Status: data.PriceStatus, Err: err}
+		}
+		price.Fixed = fixed
+	case proto.PriceStatus_PRICE_STATUS_UNSUPPORTED:
+		price.Err = &SignalError{SignalID: signalID, Status: data.PriceStatus, Err: ErrSignalUnsupported}
+	default:
+		price.Err = &SignalError{SignalID: signalID, Status: data.PriceStatus, Err: ErrPriceUnavailable}
+	}
+
+	return price
+}
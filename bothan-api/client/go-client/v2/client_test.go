@@ -0,0 +1,499 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+type fakeQueryServer struct {
+	proto.UnimplementedQueryServer
+	err      error
+	consumer chan string
+	auth     chan string
+	metadata chan metadata.MD
+	calls    int
+	// strict makes the server reject strict queries of signals other than btc and eth.
+	strict bool
+	// version is reported in the response headers if set.
+	version string
+	// serverTime is reported in the response headers of price queries if set.
+	serverTime time.Time
+	// health is served next to the query service if set.
+	health *health.Server
+}
+
+func (s *fakeQueryServer) Prices(ctx context.Context, req *proto.QueryPricesRequest) (*proto.QueryPricesResponse, error) {
+	s.calls++
+	if md, ok := metadata.FromIncomingContext(ctx); ok && s.consumer != nil {
+		s.consumer <- firstOf(md.Get("x-bothan-consumer"))
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok && s.auth != nil {
+		s.auth <- firstOf(md.Get("authorization"))
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok && s.metadata != nil {
+		s.metadata <- md
+	}
+	if !s.serverTime.IsZero() {
+		grpc.SetHeader(ctx, metadata.Pairs(ServerTimeHeader, strconv.FormatInt(s.serverTime.UnixMilli(), 10)))
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.strict && req.Strict {
+		var unknown []string
+		for _, id := range req.SignalIds {
+			if id != "btc" && id != "eth" && !strings.HasPrefix(id, "@") {
+				unknown = append(unknown, id)
+			}
+		}
+		if len(unknown) > 0 {
+			grpc.SetTrailer(ctx, metadata.Pairs(UnknownSignalIDsHeader, strings.Join(unknown, ",")))
+			return nil, status.Error(codes.NotFound, "unknown signal ids")
+		}
+	}
+
+	return &proto.QueryPricesResponse{
+		Prices: []*proto.PriceData{
+			{SignalId: "btc", Price: "60000", PriceStatus: proto.PriceStatus_PRICE_STATUS_AVAILABLE, Timestamp: 1700000000},
+			{SignalId: "eth", PriceStatus: proto.PriceStatus_PRICE_STATUS_UNAVAILABLE},
+			{SignalId: "foo", PriceStatus: proto.PriceStatus_PRICE_STATUS_UNSUPPORTED},
+		},
+		Expansions:   []*proto.GroupExpansion{{Group: "@majors", SignalIds: []string{"btc", "eth"}}},
+		ServerTiming: &proto.ServerTiming{QueueTimeUs: 1500, ProcessingTimeUs: 250},
+	}, nil
+}
+
+func (s *fakeQueryServer) Signals(ctx context.Context, req *proto.QuerySignalsRequest) (*proto.QuerySignalsResponse, error) {
+	if s.version != "" {
+		grpc.SetHeader(ctx, metadata.Pairs(VersionHeader, s.version))
+	}
+	if req.PageToken == "" {
+		return &proto.QuerySignalsResponse{Signals: []*proto.SignalInfo{{SignalId: "btc"}}, NextPageToken: "1"}, nil
+	}
+	return &proto.QuerySignalsResponse{Signals: []*proto.SignalInfo{{SignalId: "eth"}}}, nil
+}
+
+func (s *fakeQueryServer) Registry(context.Context, *proto.QueryRegistryRequest) (*proto.QueryRegistryResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &proto.QueryRegistryResponse{Version: "1.0.0", Hash: "ab12", Registry: `{"btc":{}}`}, nil
+}
+
+func (s *fakeQueryServer) StatsSummary(context.Context, *proto.QueryStatsSummaryRequest) (*proto.QueryStatsSummaryResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &proto.QueryStatsSummaryResponse{TotalSignals: 3, AvailableSignals: 2, UnavailableSignals: 1, RegistryVersion: "1.0.0"}, nil
+}
+
+func (s *fakeQueryServer) ActiveSignals(context.Context, *proto.QueryActiveSignalsRequest) (*proto.QueryActiveSignalsResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &proto.QueryActiveSignalsResponse{SignalIds: []string{"btc", "eth"}}, nil
+}
+
+func firstOf(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// serve serves the fake server over an in-memory listener, with the given server options,
+// and returns the dial option that connects to it.
+func serve(t *testing.T, server *fakeQueryServer, opts ...grpc.ServerOption) grpc.DialOption {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer(opts...)
+	proto.RegisterQueryServer(grpcServer, server)
+	if server.health != nil {
+		healthpb.RegisterHealthServer(grpcServer, server.health)
+	}
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	return grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	})
+}
+
+func newTestClient(t *testing.T, server *fakeQueryServer, opts ...Option) *Client {
+	t.Helper()
+
+	opts = append(opts, WithDialOptions(serve(t, server)))
+	c, err := New("passthrough:///bufconn", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+
+	return c
+}
+
+func TestNewFromConn(t *testing.T) {
+	server := &fakeQueryServer{consumer: make(chan string, 1)}
+	shared, err := grpc.Dial("passthrough:///bufconn", serve(t, server), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shared.Close()
+
+	c := NewFromConn(shared, WithConsumer("oracle"))
+	if _, err := c.Prices(context.Background(), []string{"btc"}); err != nil {
+		t.Fatal(err)
+	}
+	if consumer := <-server.consumer; consumer != "oracle" {
+		t.Errorf("expected the consumer to be sent on a shared connection, got %q", consumer)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.Raw().Signals(context.Background(), &proto.QuerySignalsRequest{})
+	if err != nil {
+		t.Fatalf("expected the shared connection to stay open: %v", err)
+	}
+	if len(resp.Signals) != 1 {
+		t.Errorf("unexpected response %v", resp)
+	}
+}
+
+func TestPrices(t *testing.T) {
+	c := newTestClient(t, &fakeQueryServer{})
+
+	prices, err := c.Prices(context.Background(), []string{"@majors", "foo", "btc", "sol"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		signalID string
+		err      error
+	}{{"btc", nil}, {"eth", ErrPriceUnavailable}, {"foo", ErrSignalUnsupported}, {"sol", ErrPriceMissing}}
+	if len(prices) != len(want) {
+		t.Fatalf("expected %d prices, got %+v", len(want), prices)
+	}
+	for i, w := range want {
+		price := prices[i]
+		if price.SignalID != w.signalID || !errors.Is(price.Err, w.err) {
+			t.Errorf("expected %s with error %v, got %+v", w.signalID, w.err, price)
+		}
+		var signalErr *SignalError
+		if w.err != nil && (!errors.As(price.Err, &signalErr) || signalErr.SignalID != w.signalID) {
+			t.Errorf("expected a *SignalError for %s, got %v", w.signalID, price.Err)
+		}
+	}
+	if prices[0].Value != "60000" {
+		t.Errorf("unexpected price %q", prices[0].Value)
+	}
+	if !prices[0].Time.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("expected the time reported by the server, got %v", prices[0].Time)
+	}
+	if prices[1].Time.IsZero() {
+		t.Error("expected the receive time for a price without a timestamp")
+	}
+}
+
+func TestPricesStrict(t *testing.T) {
+	for _, server := range []*fakeQueryServer{{strict: true}, {}} {
+		c := newTestClient(t, server, WithStrict())
+
+		_, err := c.Prices(context.Background(), []string{"btc", "foo"})
+		var unknown *UnknownSignalIDsError
+		if !errors.As(err, &unknown) || !slices.Equal(unknown.SignalIDs, []string{"foo"}) {
+			t.Fatalf("expected foo to be unknown to a server with strict support %v, got %v", server.strict, err)
+		}
+		if !errors.Is(err, ErrSignalUnsupported) {
+			t.Errorf("expected the error to match ErrSignalUnsupported, got %v", err)
+		}
+	}
+
+	c := newTestClient(t, &fakeQueryServer{strict: true}, WithStrict())
+	if _, err := c.Prices(context.Background(), []string{"@majors"}); err != nil {
+		t.Errorf("expected a query of known signals to succeed, got %v", err)
+	}
+}
+
+func TestPricesServerTiming(t *testing.T) {
+	c := newTestClient(t, &fakeQueryServer{})
+
+	var outer, inner ServerTiming
+	ctx := WithServerTiming(WithServerTiming(context.Background(), &outer), &inner)
+	if _, err := c.Prices(ctx, []string{"btc"}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := ServerTiming{Queue: 1500 * time.Microsecond, Processing: 250 * time.Microsecond, Reported: true}
+	if outer != want || inner != want {
+		t.Errorf("expected %+v in both timings, got %+v and %+v", want, outer, inner)
+	}
+	if want.Total() != 1750*time.Microsecond {
+		t.Errorf("unexpected total %v", want.Total())
+	}
+}
+
+func TestPricesRejectsEmptySignalID(t *testing.T) {
+	server := &fakeQueryServer{}
+	c := newTestClient(t, server)
+
+	if _, err := c.Prices(context.Background(), []string{"btc", ""}); !errors.Is(err, ErrEmptySignalID) {
+		t.Errorf("expected ErrEmptySignalID, got %v", err)
+	}
+	if server.calls != 0 {
+		t.Errorf("expected no call to the server, got %d", server.calls)
+	}
+}
+
+func TestPricesResponse(t *testing.T) {
+	c := newTestClient(t, &fakeQueryServer{strict: true}, WithStrict())
+
+	// The signal IDs are sent as given, and the response is returned unchanged.
+	resp, err := c.PricesResponse(context.Background(), []string{"btc", "foo", "btc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Prices) != 3 || resp.Prices[0].Timestamp != 1700000000 || len(resp.Expansions) != 1 {
+		t.Errorf("unexpected response %v", resp)
+	}
+
+	if _, err := newTestClient(t, &fakeQueryServer{err: status.Error(codes.Unavailable, "down")}).PricesResponse(context.Background(), nil); status.Code(err) != codes.Unavailable {
+		t.Errorf("expected Unavailable, got %v", err)
+	}
+}
+
+func TestPricesCallError(t *testing.T) {
+	c := newTestClient(t, &fakeQueryServer{err: status.Error(codes.Unavailable, "down")})
+
+	_, err := c.Prices(context.Background(), []string{"btc"})
+	var callErr *CallError
+	if !errors.As(err, &callErr) || callErr.Method != proto.Query_Prices_FullMethodName {
+		t.Fatalf("expected a *CallError, got %v", err)
+	}
+	if callErr.Code() != codes.Unavailable || status.Code(err) != codes.Unavailable {
+		t.Errorf("expected Unavailable, got %v", callErr.Code())
+	}
+}
+
+func TestSignals(t *testing.T) {
+	c := newTestClient(t, &fakeQueryServer{})
+
+	signals, err := c.Signals(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signals) != 2 || signals[0].SignalId != "btc" || signals[1].SignalId != "eth" {
+		t.Errorf("unexpected signals %v", signals)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	c := newTestClient(t, &fakeQueryServer{})
+
+	registry, err := c.Registry(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if registry.Version != "1.0.0" || registry.Hash != "ab12" || registry.Registry != `{"btc":{}}` {
+		t.Errorf("unexpected registry %v", registry)
+	}
+
+	c = newTestClient(t, &fakeQueryServer{err: status.Error(codes.Unimplemented, "unknown method")})
+	var callErr *CallError
+	if _, err := c.Registry(context.Background()); !errors.As(err, &callErr) || callErr.Method != proto.Query_Registry_FullMethodName {
+		t.Errorf("expected a *CallError, got %v", err)
+	}
+}
+
+func TestStatsSummary(t *testing.T) {
+	c := newTestClient(t, &fakeQueryServer{})
+
+	summary, err := c.StatsSummary(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.TotalSignals != 3 || summary.AvailableSignals != 2 || summary.UnavailableSignals != 1 || summary.RegistryVersion != "1.0.0" {
+		t.Errorf("unexpected summary %v", summary)
+	}
+
+	c = newTestClient(t, &fakeQueryServer{err: status.Error(codes.Unimplemented, "unknown method")})
+	var callErr *CallError
+	if _, err := c.StatsSummary(context.Background()); !errors.As(err, &callErr) || callErr.Method != proto.Query_StatsSummary_FullMethodName {
+		t.Errorf("expected a *CallError, got %v", err)
+	}
+}
+
+func TestActiveSignalIDs(t *testing.T) {
+	c := newTestClient(t, &fakeQueryServer{})
+
+	signalIDs, err := c.ActiveSignalIDs(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(signalIDs, []string{"btc", "eth"}) {
+		t.Errorf("unexpected signal IDs %v", signalIDs)
+	}
+
+	c = newTestClient(t, &fakeQueryServer{err: status.Error(codes.Unimplemented, "unknown method")})
+	var callErr *CallError
+	if _, err := c.ActiveSignalIDs(context.Background()); !errors.As(err, &callErr) || callErr.Method != proto.Query_ActiveSignals_FullMethodName {
+		t.Errorf("expected a *CallError, got %v", err)
+	}
+}
+
+func TestServerVersion(t *testing.T) {
+	for _, version := range []string{"0.1.2", ""} {
+		c := newTestClient(t, &fakeQueryServer{version: version})
+
+		got, err := c.ServerVersion(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != version {
+			t.Errorf("expected version %q, got %q", version, got)
+		}
+	}
+}
+
+func TestWithConsumer(t *testing.T) {
+	server := &fakeQueryServer{consumer: make(chan string, 1)}
+	c := newTestClient(t, server, WithConsumer("oracle"))
+
+	if _, err := c.Prices(context.Background(), []string{"btc"}); err != nil {
+		t.Fatal(err)
+	}
+	if consumer := <-server.consumer; consumer != "oracle" {
+		t.Errorf("expected the consumer to be sent, got %q", consumer)
+	}
+}
+
+func TestWithHeaders(t *testing.T) {
+	server := &fakeQueryServer{metadata: make(chan metadata.MD, 1)}
+	c := newTestClient(t, server, WithHeaders(map[string]string{"X-Route": "eu"}), WithConsumer("oracle"))
+
+	if _, err := c.Prices(context.Background(), []string{"btc"}); err != nil {
+		t.Fatal(err)
+	}
+	md := <-server.metadata
+	if route := firstOf(md.Get("x-route")); route != "eu" {
+		t.Errorf("expected the header to be sent, got %q", route)
+	}
+	if consumer := firstOf(md.Get("x-bothan-consumer")); consumer != "oracle" {
+		t.Errorf("expected the consumer to be sent too, got %q", consumer)
+	}
+}
+
+func TestWithUnaryInterceptor(t *testing.T) {
+	var methods []string
+	record := func(prefix string) grpc.UnaryClientInterceptor {
+		return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			methods = append(methods, prefix+method)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+	}
+	c := newTestClient(t, &fakeQueryServer{}, WithUnaryInterceptor(record("first "), record("second ")))
+
+	if _, err := c.Prices(context.Background(), []string{"btc"}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"first " + proto.Query_Prices_FullMethodName, "second " + proto.Query_Prices_FullMethodName}
+	if !slices.Equal(methods, want) {
+		t.Errorf("expected the interceptors to run in order, got %v", methods)
+	}
+}
+
+func TestWaitForReady(t *testing.T) {
+	c := newTestClient(t, &fakeQueryServer{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := c.WaitForReady(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if state := c.State(); state != connectivity.Ready {
+		t.Errorf("expected a ready connection, got %v", state)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if state := c.State(); state != connectivity.Shutdown {
+		t.Errorf("expected a closed connection, got %v", state)
+	}
+	if err := c.WaitForReady(ctx); !errors.Is(err, ErrConnectionClosed) {
+		t.Errorf("expected ErrConnectionClosed, got %v", err)
+	}
+}
+
+func TestWaitForReadyTimeout(t *testing.T) {
+	unreachable := grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return nil, errors.New("connection refused")
+	})
+	c, err := New("passthrough:///unreachable", WithDialOptions(unreachable))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := c.WaitForReady(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline to be exceeded, got %v", err)
+	}
+}
+
+func TestWithKeepalive(t *testing.T) {
+	c := newTestClient(t, &fakeQueryServer{}, WithKeepalive(10*time.Second, time.Second, true))
+
+	if _, err := c.Prices(context.Background(), []string{"btc"}); err != nil {
+		t.Fatal(err)
+	}
+	if report := debugReport(t, c); report.Config.DialOptions != 2 {
+		t.Errorf("expected the keepalive to be a dial option, got %+v", report.Config)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	server := &fakeQueryServer{}
+	c := newTestClient(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := c.Watch(ctx, []string{"btc"}, 10*time.Millisecond)
+	for i := 0; i < 3; i++ {
+		prices, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(prices) != 1 || prices[0].Value != "60000" {
+			t.Fatalf("unexpected prices %+v", prices)
+		}
+	}
+
+	cancel()
+	if _, err := stream.Recv(); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the stream to end with its context, got %v", err)
+	}
+	if server.calls != 3 {
+		t.Errorf("expected 3 queries, got %d", server.calls)
+	}
+}
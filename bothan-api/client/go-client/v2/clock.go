@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ServerTimeHeader is the response metadata in which the server reports its unix time in
+// milliseconds when it sent the response.
+const ServerTimeHeader = "bothan-server-time"
+
+// WithClockSkewCheck compares the time the server reports in every response with the local
+// clock, and calls onSkew with the skew when it exceeds the threshold. A positive skew means
+// the clock of the server is ahead. Skewed clocks silently break the staleness checks of
+// prices and the timestamps of monitoring. Responses of servers that predate the reporting
+// are not checked.
+func WithClockSkewCheck(threshold time.Duration, onSkew func(skew time.Duration)) Option {
+	return WithDialOptions(grpc.WithChainUnaryInterceptor(ClockSkewInterceptor(threshold, onSkew)))
+}
+
+// ClockSkewInterceptor returns an interceptor that checks the clock skew of every response,
+// see WithClockSkewCheck.
+func ClockSkewInterceptor(threshold time.Duration, onSkew func(skew time.Duration)) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var header metadata.MD
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header))...)
+		end := time.Now()
+
+		if skew, ok := clockSkew(header, start, end); ok && (skew > threshold || skew < -threshold) {
+			onSkew(skew)
+		}
+		return err
+	}
+}
+
+// clockSkew returns the difference between the time reported in the header and the middle
+// of the call, which halves the error caused by the latency of the network.
+func clockSkew(header metadata.MD, start, end time.Time) (time.Duration, bool) {
+	values := header.Get(ServerTimeHeader)
+	if len(values) == 0 {
+		return 0, false
+	}
+	millis, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	local := start.Add(end.Sub(start) / 2)
+	return time.UnixMilli(millis).Sub(local), true
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithClockSkewCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		offset time.Duration
+		warned bool
+	}{
+		{"in sync", 0, false},
+		{"server ahead", time.Hour, true},
+		{"server behind", -time.Hour, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var skews []time.Duration
+			server := &fakeQueryServer{serverTime: time.Now().Add(tt.offset)}
+			c := newTestClient(t, server, WithClockSkewCheck(time.Minute, func(skew time.Duration) {
+				skews = append(skews, skew)
+			}))
+
+			if _, err := c.Prices(context.Background(), []string{"btc"}); err != nil {
+				t.Fatal(err)
+			}
+			if warned := len(skews) > 0; warned != tt.warned {
+				t.Fatalf("expected warned %v, got skews %v", tt.warned, skews)
+			}
+			if tt.warned && (skews[0]-tt.offset).Abs() > time.Minute {
+				t.Errorf("expected a skew of about %s, got %s", tt.offset, skews[0])
+			}
+		})
+	}
+}
+
+func TestWithClockSkewCheckWithoutServerTime(t *testing.T) {
+	c := newTestClient(t, &fakeQueryServer{}, WithClockSkewCheck(0, func(skew time.Duration) {
+		t.Errorf("expected no check without a server time, got skew %s", skew)
+	}))
+
+	if _, err := c.Prices(context.Background(), []string{"btc"}); err != nil {
+		t.Fatal(err)
+	}
+}
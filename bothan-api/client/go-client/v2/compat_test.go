@@ -0,0 +1,215 @@
+//go:build compat
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// The compatibility matrix runs the client against Bothan servers built from git refs, e.g.
+// release tags, to surface breaking server changes before a release:
+//
+//	BOTHAN_COMPAT_REFS="v0.1.0 v0.2.0 main" go test -tags compat -run TestCompatibility -timeout 2h .
+//
+// Every ref is checked out into a temporary worktree and built with the Dockerfile of the
+// server, which requires git and docker. The matrix is written as Markdown to
+// BOTHAN_COMPAT_REPORT, compat-matrix.md by default.
+
+const (
+	compatImage         = "bothan-api-compat"
+	compatConfig        = "../../../server/config.toml.example"
+	compatReadyTimeout  = 2 * time.Minute
+	compatUnknownSignal = "COMPAT:UNKNOWN-SIGNAL"
+)
+
+// compatCheck is a column of the compatibility matrix. Checks that fail with Unimplemented are
+// reported as unsupported rather than failed, as the feature is newer than the server.
+type compatCheck struct {
+	name string
+	run  func(ctx context.Context, target string) error
+}
+
+var compatChecks = []compatCheck{
+	{"signals", func(ctx context.Context, target string) error {
+		return withCompatClient(target, func(c *Client) error {
+			_, err := c.Signals(ctx)
+			return err
+		})
+	}},
+	{"prices", func(ctx context.Context, target string) error {
+		return withCompatClient(target, func(c *Client) error {
+			signals, err := c.Signals(ctx)
+			if err != nil {
+				return err
+			}
+			var ids []string
+			for _, signal := range signals[:min(len(signals), 5)] {
+				ids = append(ids, signal.SignalId)
+			}
+			prices, err := c.Prices(ctx, ids)
+			if err != nil {
+				return err
+			}
+			for _, price := range prices {
+				if errors.Is(price.Err, ErrSignalUnsupported) || errors.Is(price.Err, ErrPriceMissing) {
+					return price.Err
+				}
+			}
+			return nil
+		})
+	}},
+	{"strict", func(ctx context.Context, target string) error {
+		return withCompatClient(target, func(c *Client) error {
+			_, err := c.Prices(ctx, []string{compatUnknownSignal})
+			var unknown *UnknownSignalIDsError
+			if !errors.As(err, &unknown) {
+				return fmt.Errorf("expected an *UnknownSignalIDsError, got %v", err)
+			}
+			return nil
+		}, WithStrict())
+	}},
+	{"source_stats", func(ctx context.Context, target string) error {
+		return withCompatClient(target, func(c *Client) error {
+			_, err := c.Raw().SourceStats(ctx, &proto.QuerySourceStatsRequest{})
+			return err
+		})
+	}},
+}
+
+func withCompatClient(target string, f func(c *Client) error, opts ...Option) error {
+	c, err := New(target, opts...)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	return f(c)
+}
+
+func TestCompatibility(t *testing.T) {
+	refs := strings.Fields(os.Getenv("BOTHAN_COMPAT_REFS"))
+	if len(refs) == 0 {
+		t.Skip("BOTHAN_COMPAT_REFS is not set")
+	}
+	config, err := filepath.Abs(compatConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matrix := make(map[string]map[string]string, len(refs))
+	for _, ref := range refs {
+		t.Run(ref, func(t *testing.T) {
+			results := make(map[string]string, len(compatChecks)+1)
+			matrix[ref] = results
+
+			target, err := startCompatServer(t, ref, config)
+			if err != nil {
+				results["server"] = "fail"
+				t.Fatal(err)
+			}
+			results["server"] = "ok"
+
+			for _, check := range compatChecks {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+				err := check.run(ctx, target)
+				cancel()
+
+				switch {
+				case err == nil:
+					results[check.name] = "ok"
+				case status.Code(err) == codes.Unimplemented:
+					results[check.name] = "unsupported"
+				default:
+					results[check.name] = "fail"
+					t.Errorf("%s: %v", check.name, err)
+				}
+			}
+		})
+	}
+
+	report := os.Getenv("BOTHAN_COMPAT_REPORT")
+	if report == "" {
+		report = "compat-matrix.md"
+	}
+	if err := os.WriteFile(report, []byte(formatCompatMatrix(refs, matrix)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// startCompatServer builds the server of the given ref, runs it with the given configuration
+// until the end of the test and waits for it to be ready. It returns the target of the server.
+func startCompatServer(t *testing.T, ref, config string) (string, error) {
+	t.Helper()
+
+	worktree := filepath.Join(t.TempDir(), "bothan")
+	if _, err := command("git", "worktree", "add", "--detach", worktree, ref); err != nil {
+		return "", err
+	}
+	defer command("git", "worktree", "remove", "--force", worktree)
+
+	image := compatImage + ":" + strings.NewReplacer("/", "-", "@", "-").Replace(ref)
+	if _, err := command("docker", "build", "-q", "-t", image, "-f", filepath.Join(worktree, "bothan-api/server/Dockerfile"), worktree); err != nil {
+		return "", err
+	}
+
+	container, err := command("docker", "run", "-d", "--rm", "-p", "127.0.0.1::50051", "-v", config+":/app/config.toml:ro", image)
+	if err != nil {
+		return "", err
+	}
+	t.Cleanup(func() { command("docker", "stop", container) })
+
+	addr, err := command("docker", "port", container, "50051/tcp")
+	if err != nil {
+		return "", err
+	}
+	target := strings.Fields(addr)[0]
+
+	ctx, cancel := context.WithTimeout(context.Background(), compatReadyTimeout)
+	defer cancel()
+	if err := withCompatClient(target, func(c *Client) error { return c.WaitForReady(ctx) }); err != nil {
+		return "", fmt.Errorf("server of %s is not ready: %w", ref, err)
+	}
+	return target, nil
+}
+
+// command runs the given command and returns its trimmed output.
+func command(name string, args ...string) (string, error) {
+	out, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%s %s: %w\n%s", name, strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// formatCompatMatrix formats the results of the refs as a Markdown table with a row per ref
+// and a column per check. Checks that did not run are left empty.
+func formatCompatMatrix(refs []string, matrix map[string]map[string]string) string {
+	columns := []string{"server"}
+	for _, check := range compatChecks {
+		columns = append(columns, check.name)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "| ref | %s |\n", strings.Join(columns, " | "))
+	b.WriteString(strings.Repeat("|---", len(columns)+1) + "|\n")
+	for _, ref := range refs {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cells[i] = matrix[ref][column]
+		}
+		fmt.Fprintf(&b, "| %s | %s |\n", ref, strings.Join(cells, " | "))
+	}
+	return b.String()
+}
@@ -0,0 +1,15 @@
+package client
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+// WithCompression compresses the calls of the client with gzip and asks the server to compress
+// its responses, which pays off for queries of hundreds of signals. Calls are not compressed
+// by default. Servers that do not accept gzip reject the calls with Unimplemented.
+func WithCompression() Option {
+	return func(o *options) {
+		o.callOptions = append(o.callOptions, grpc.UseCompressor(gzip.Name))
+	}
+}
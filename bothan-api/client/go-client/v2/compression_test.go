@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+)
+
+// compressionRecorder records the compression of the calls a server receives.
+type compressionRecorder struct {
+	compression chan string
+}
+
+func (r compressionRecorder) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (r compressionRecorder) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if header, ok := s.(*stats.InHeader); ok {
+		r.compression <- header.Compression
+	}
+}
+
+func (r compressionRecorder) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (r compressionRecorder) HandleConn(context.Context, stats.ConnStats) {}
+
+func TestWithCompression(t *testing.T) {
+	recorder := compressionRecorder{compression: make(chan string, 1)}
+	c, err := New("passthrough:///bufconn", WithCompression(),
+		WithDialOptions(serve(t, &fakeQueryServer{}, grpc.StatsHandler(recorder))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if _, err := c.Prices(context.Background(), []string{"btc"}); err != nil {
+		t.Fatal(err)
+	}
+	if compression := <-recorder.compression; compression != "gzip" {
+		t.Errorf("expected the call to be compressed with gzip, got %q", compression)
+	}
+}
@@ -0,0 +1,24 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ConsumerHeader is the header, and in lower case the gRPC metadata key, in which a client
+// names the service it queries for. The proxy labels its usage metrics with it, so that load
+// can be attributed to downstream services.
+const ConsumerHeader = "X-Bothan-Consumer"
+
+// ConsumerInterceptor returns an interceptor that tags every call with the given consumer
+// name.
+func ConsumerInterceptor(name string) grpc.UnaryClientInterceptor {
+	key := strings.ToLower(ConsumerHeader)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = metadata.AppendToOutgoingContext(ctx, key, name)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
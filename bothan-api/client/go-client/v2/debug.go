@@ -0,0 +1,151 @@
+package client
+
+import (
+	"encoding/json"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/connectivity"
+)
+
+const (
+	// latencySamples is the number of recent calls the latency stats of a DebugReport cover.
+	latencySamples = 256
+	// errorSamples is the number of recent errors a DebugReport includes.
+	errorSamples = 16
+)
+
+// DebugReport is a snapshot of the state of a client, meant to be attached to support
+// requests. It holds no credentials: the config only tells whether they are set.
+type DebugReport struct {
+	Time            time.Time     `json:"time"`
+	Endpoint        string        `json:"endpoint"`
+	ConnectionState string        `json:"connection_state"`
+	Config          DebugConfig   `json:"config"`
+	CircuitBreaker  string        `json:"circuit_breaker,omitempty"`
+	Calls           int64         `json:"calls"`
+	Failures        int64         `json:"failures"`
+	Latency         LatencyStats  `json:"latency"`
+	RecentErrors    []ErrorSample `json:"recent_errors"`
+}
+
+// DebugConfig is the configuration of a client, with its credentials redacted.
+type DebugConfig struct {
+	Timeout  string `json:"timeout"`
+	Consumer string `json:"consumer,omitempty"`
+	// TLS and Authenticated tell whether WithTLS and WithToken or WithTokenSource were given.
+	// Credentials given with WithDialOptions only show in the number of dial options.
+	TLS           bool `json:"tls"`
+	Authenticated bool `json:"authenticated"`
+	DialOptions   int  `json:"dial_options"`
+	// Strict tells whether WithStrict was given.
+	Strict bool `json:"strict"`
+	// SharedConn is set for clients created with NewFromConn or from a Pool.
+	SharedConn bool `json:"shared_conn"`
+}
+
+// LatencyStats summarizes the latencies of the recent calls of a client in milliseconds,
+// failed calls included.
+type LatencyStats struct {
+	Samples int     `json:"samples"`
+	Min     float64 `json:"min_ms"`
+	Mean    float64 `json:"mean_ms"`
+	P50     float64 `json:"p50_ms"`
+	P95     float64 `json:"p95_ms"`
+	Max     float64 `json:"max_ms"`
+}
+
+// ErrorSample is a recent failed call of a client.
+type ErrorSample struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	Error  string    `json:"error"`
+}
+
+// DebugReport returns a DebugReport of the client as indented JSON, which integrators can
+// hand to support teams when debugging issues with the server.
+func (c *Client) DebugReport() ([]byte, error) {
+	report := DebugReport{
+		Time:            time.Now(),
+		Endpoint:        c.target,
+		ConnectionState: "UNKNOWN",
+		Config:          c.config,
+	}
+	if conn, ok := c.conn.(interface{ GetState() connectivity.State }); ok {
+		report.ConnectionState = conn.GetState().String()
+	}
+	if c.breaker != nil {
+		report.CircuitBreaker = c.breaker.State().String()
+	}
+	c.stats.snapshot(&report)
+
+	return json.MarshalIndent(report, "", "  ")
+}
+
+// callStats keeps the latencies and errors of the recent calls of a client in ring buffers.
+type callStats struct {
+	mu        sync.Mutex
+	calls     int64
+	failures  int64
+	latencies [latencySamples]time.Duration
+	errors    [errorSamples]ErrorSample
+}
+
+// record records a call of the given method that started at the given time.
+func (s *callStats) record(method string, start time.Time, err error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latencies[s.calls%latencySamples] = now.Sub(start)
+	s.calls++
+	if err != nil {
+		s.errors[s.failures%errorSamples] = ErrorSample{Time: now, Method: method, Error: err.Error()}
+		s.failures++
+	}
+}
+
+// snapshot fills in the call stats of the report, with the recent errors oldest first.
+func (s *callStats) snapshot(report *DebugReport) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	report.Calls = s.calls
+	report.Failures = s.failures
+	report.Latency = latencyStats(slices.Clone(s.latencies[:min(s.calls, latencySamples)]))
+
+	n := min(s.failures, errorSamples)
+	report.RecentErrors = make([]ErrorSample, 0, n)
+	for i := s.failures - n; i < s.failures; i++ {
+		report.RecentErrors = append(report.RecentErrors, s.errors[i%errorSamples])
+	}
+}
+
+func latencyStats(latencies []time.Duration) LatencyStats {
+	if len(latencies) == 0 {
+		return LatencyStats{}
+	}
+
+	slices.Sort(latencies)
+	var total time.Duration
+	for _, latency := range latencies {
+		total += latency
+	}
+	percentile := func(p int) float64 {
+		return milliseconds(latencies[(len(latencies)-1)*p/100])
+	}
+
+	return LatencyStats{
+		Samples: len(latencies),
+		Min:     milliseconds(latencies[0]),
+		Mean:    milliseconds(total / time.Duration(len(latencies))),
+		P50:     percentile(50),
+		P95:     percentile(95),
+		Max:     milliseconds(latencies[len(latencies)-1]),
+	}
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func debugReport(t *testing.T, c *Client) DebugReport {
+	t.Helper()
+
+	b, err := c.DebugReport()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report DebugReport
+	if err := json.Unmarshal(b, &report); err != nil {
+		t.Fatalf("invalid report %s: %v", b, err)
+	}
+
+	return report
+}
+
+func TestDebugReport(t *testing.T) {
+	server := &fakeQueryServer{}
+	c := newTestClient(t, server, WithTimeout(time.Second), WithConsumer("oracle"))
+
+	if _, err := c.Prices(context.Background(), []string{"btc"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Signals(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	server.err = status.Error(codes.Unavailable, "registry not loaded")
+	if _, err := c.Prices(context.Background(), []string{"btc"}); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	report := debugReport(t, c)
+	if report.Endpoint != "passthrough:///bufconn" {
+		t.Errorf("unexpected endpoint %q", report.Endpoint)
+	}
+	if report.ConnectionState != "READY" {
+		t.Errorf("unexpected connection state %q", report.ConnectionState)
+	}
+	if report.Config.Timeout != "1s" || report.Config.Consumer != "oracle" || report.Config.SharedConn {
+		t.Errorf("unexpected config %+v", report.Config)
+	}
+	// Signals queries two pages.
+	if report.Calls != 4 || report.Failures != 1 || report.Latency.Samples != 4 {
+		t.Errorf("expected 4 calls with 1 failure, got %+v", report)
+	}
+	if report.Latency.Min > report.Latency.P50 || report.Latency.P50 > report.Latency.Max {
+		t.Errorf("inconsistent latency stats %+v", report.Latency)
+	}
+	if len(report.RecentErrors) != 1 {
+		t.Fatalf("expected 1 recent error, got %v", report.RecentErrors)
+	}
+	sample := report.RecentErrors[0]
+	if sample.Method != proto.Query_Prices_FullMethodName || !strings.Contains(sample.Error, "registry not loaded") {
+		t.Errorf("unexpected error sample %+v", sample)
+	}
+}
+
+func TestDebugReportKeepsRecentErrors(t *testing.T) {
+	var stats callStats
+	for i := 0; i < errorSamples+3; i++ {
+		stats.record("method", time.Now(), status.Errorf(codes.Internal, "error %d", i))
+	}
+
+	var report DebugReport
+	stats.snapshot(&report)
+	if len(report.RecentErrors) != errorSamples {
+		t.Fatalf("expected %d recent errors, got %d", errorSamples, len(report.RecentErrors))
+	}
+	if first := report.RecentErrors[0].Error; !strings.HasSuffix(first, "error 3") {
+		t.Errorf("expected the oldest kept error first, got %q", first)
+	}
+	if last := report.RecentErrors[errorSamples-1].Error; !strings.HasSuffix(last, "error 18") {
+		t.Errorf("expected the newest error last, got %q", last)
+	}
+}
+
+func TestDebugReportRedactsCredentials(t *testing.T) {
+	c := newTestClient(t, &fakeQueryServer{}, WithToken("s3cret"))
+
+	b, err := c.DebugReport()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "s3cret") {
+		t.Errorf("expected the token to be redacted from %s", b)
+	}
+	if report := debugReport(t, c); !report.Config.Authenticated || report.Config.TLS {
+		t.Errorf("unexpected config %+v", report.Config)
+	}
+}
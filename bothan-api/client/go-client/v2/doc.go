@@ -0,0 +1,41 @@
+// Package client is version 2 of the Go client of Bothan.
+//
+// Every method takes a context, which bounds and cancels the call, and the client is
+// configured with functional options rather than positional arguments:
+//
+//	c, err := client.New("localhost:50051", client.WithTimeout(5*time.Second))
+//	if err != nil {
+//		return err
+//	}
+//	defer c.Close()
+//
+//	prices, err := c.Prices(ctx, []string{"CS:BTC-USD", "CS:ETH-USD"})
+//
+// Failed calls return a *CallError and unusable prices carry a *SignalError, both of which
+// work with errors.Is and errors.As. Clients created with WithStrict fail with an
+// *UnknownSignalIDsError instead if a queried signal is not in the registry of the server.
+//
+// Applications with many independent clients of the same server, e.g. one per plugin, can
+// create them from a Pool, which dials one connection per target and closes it with its last
+// client.
+//
+// When reporting an issue, include the output of DebugReport. It has the endpoint,
+// connection state and configuration of the client, without credentials, plus the latencies
+// and errors of its recent calls.
+//
+// # Migrating from version 1
+//
+// The GRPC client of version 1 is a thin wrapper around this package and keeps its API, and
+// the errors of both versions are the same values, so downstream code can move one call site
+// at a time:
+//
+//   - NewGRPC(url, timeout, opts...) becomes New(url, WithTimeout(timeout),
+//     WithDialOptions(opts...)).
+//   - QueryPrices and GetPriceMap become Prices, which returns the prices in the order of the
+//     queried signals with group references expanded. PricesResponse returns the messages of
+//     the server unchanged, like QueryPrices did.
+//   - ListSignals becomes Signals.
+//   - Polling loops become Watch.
+//
+// The REST client remains in version 1 for now.
+package client
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+var (
+	// ErrSignalUnsupported is reported for signals that are not in the registry of the server.
+	ErrSignalUnsupported = errors.New("signal is not supported")
+	// ErrPriceUnavailable is reported for supported signals whose price is not available yet.
+	ErrPriceUnavailable = errors.New("price is not available")
+	// ErrPriceMissing is reported for signals that are missing from the response.
+	ErrPriceMissing = errors.New("price is missing from the response")
+	// ErrConnectionClosed is returned by WaitForReady once the connection of the client is
+	// closed.
+	ErrConnectionClosed = errors.New("connection is closed")
+)
+
+// SignalError reports why the price of a signal cannot be used. Err is one of
+// ErrSignalUnsupported, ErrPriceUnavailable and ErrPriceMissing.
+type SignalError struct {
+	SignalID string
+	Status   proto.PriceStatus
+	Err      error
+}
+
+func (e *SignalError) Error() string {
+	return fmt.Sprintf("%s: %v", e.SignalID, e.Err)
+}
+
+func (e *SignalError) Unwrap() error {
+	return e.Err
+}
+
+// CallError reports a failed call to the server. Err is the error returned by gRPC, whose
+// status is also reported by GRPCStatus, so status.Code and status.FromError work on it.
+type CallError struct {
+	// Method is the full gRPC method name, e.g. "/query.Query/Prices".
+	Method string
+	Err    error
+}
+
+func (e *CallError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Method, e.Err)
+}
+
+func (e *CallError) Unwrap() error {
+	return e.Err
+}
+
+// Code returns the gRPC code of the failure.
+func (e *CallError) Code() codes.Code {
+	return status.Code(e.Err)
+}
+
+func (e *CallError) GRPCStatus() *status.Status {
+	return status.Convert(e.Err)
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// MaxFixedPointScale is the largest scale accepted from a server, enough for the 78 digits of a
+// 256-bit integer. Larger scales would make rescaling compute huge powers of ten.
+const MaxFixedPointScale = 77
+
+// ErrInvalidFixedPoint is returned for mantissas and decimals that are not numbers, and for
+// scales outside 0 to MaxFixedPointScale.
+var ErrInvalidFixedPoint = errors.New("invalid fixed-point number")
+
+// FixedPoint is a decimal number as an integer mantissa and a scale, Mantissa × 10^-Scale,
+// e.g. 67012.125 is the mantissa 67012125 with the scale 3. Unlike a price with an implicit
+// multiplier, it states its own scale, and Rescale converts it to the scale of a consumer.
+type FixedPoint struct {
+	Mantissa *big.Int
+	Scale    int32
+}
+
+// NewFixedPoint converts the fixed-point price of a response.
+func NewFixedPoint(p *proto.FixedPoint) (FixedPoint, error) {
+	if p.Scale < 0 || p.Scale > MaxFixedPointScale {
+		return FixedPoint{}, fmt.Errorf("%w: scale %d", ErrInvalidFixedPoint, p.Scale)
+	}
+	mantissa, ok := new(big.Int).SetString(p.Mantissa, 10)
+	if !ok {
+		return FixedPoint{}, fmt.Errorf("%w: mantissa %q", ErrInvalidFixedPoint, p.Mantissa)
+	}
+	return FixedPoint{Mantissa: mantissa, Scale: p.Scale}, nil
+}
+
+// ParseFixedPoint parses a decimal like "67012.125" or "-0.5", e.g. the price of a server that
+// predates fixed-point prices.
+func ParseFixedPoint(decimal string) (FixedPoint, error) {
+	integer, fraction, _ := strings.Cut(decimal, ".")
+	digits := strings.TrimPrefix(integer, "-") + fraction
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return FixedPoint{}, fmt.Errorf("%w: %q", ErrInvalidFixedPoint, decimal)
+	}
+	if len(fraction) > MaxFixedPointScale {
+		return FixedPoint{}, fmt.Errorf("%w: %q has more than %d decimals", ErrInvalidFixedPoint, decimal, MaxFixedPointScale)
+	}
+
+	mantissa, _ := new(big.Int).SetString(digits, 10)
+	if strings.HasPrefix(integer, "-") {
+		mantissa.Neg(mantissa)
+	}
+	return FixedPoint{Mantissa: mantissa, Scale: int32(len(fraction))}, nil
+}
+
+// Rescale returns the mantissa of the number at the given scale, e.g. the price multiplied by
+// 10^9 for the scale 9. Digits beyond the scale are truncated toward zero.
+func (f FixedPoint) Rescale(scale int32) *big.Int {
+	if f.Mantissa == nil {
+		return new(big.Int)
+	}
+
+	result := new(big.Int).Set(f.Mantissa)
+	switch diff := int64(scale) - int64(f.Scale); {
+	case diff > 0:
+		result.Mul(result, pow10(diff))
+	case diff < 0:
+		result.Quo(result, pow10(-diff))
+	}
+	return result
+}
+
+// String returns the number as a decimal, e.g. "67012.125".
+func (f FixedPoint) String() string {
+	if f.Mantissa == nil {
+		return "0"
+	}
+	if f.Scale <= 0 {
+		return f.Rescale(0).String()
+	}
+
+	digits := new(big.Int).Abs(f.Mantissa).String()
+	if pad := int(f.Scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	split := len(digits) - int(f.Scale)
+	sign := ""
+	if f.Mantissa.Sign() < 0 {
+		sign = "-"
+	}
+	return sign + digits[:split] + "." + digits[split:]
+}
+
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
+
+// newFixedPrice returns the fixed-point price of an available price, from the fixed-point
+// price of the response or, for servers that predate it, from the decimal price. It fails if
+// neither is a valid number.
+func newFixedPrice(data *proto.PriceData) (FixedPoint, error) {
+	if data.FixedPrice != nil {
+		if fixed, err := NewFixedPoint(data.FixedPrice); err == nil {
+			return fixed, nil
+		}
+	}
+	return ParseFixedPoint(data.Price)
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+func TestParseFixedPoint(t *testing.T) {
+	cases := []struct {
+		decimal  string
+		mantissa string
+		scale    int32
+	}{
+		{"67012.125", "67012125", 3},
+		{"0.00012", "12", 5},
+		{"100", "100", 0},
+		{"-1.5", "-15", 1},
+		{".5", "5", 1},
+	}
+	for _, c := range cases {
+		f, err := ParseFixedPoint(c.decimal)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.Mantissa.String() != c.mantissa || f.Scale != c.scale {
+			t.Errorf("ParseFixedPoint(%q) = %s, %d", c.decimal, f.Mantissa, f.Scale)
+		}
+	}
+
+	for _, decimal := range []string{"", "-", "1.2.3", "1e9", "abc"} {
+		if _, err := ParseFixedPoint(decimal); !errors.Is(err, ErrInvalidFixedPoint) {
+			t.Errorf("expected ErrInvalidFixedPoint for %q, got %v", decimal, err)
+		}
+	}
+}
+
+func TestFixedPointRescale(t *testing.T) {
+	f, err := NewFixedPoint(&proto.FixedPoint{Mantissa: "67012125", Scale: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for scale, expected := range map[int32]string{9: "67012125000000", 3: "67012125", 1: "670121", 0: "67012"} {
+		if got := f.Rescale(scale).String(); got != expected {
+			t.Errorf("expected %s at scale %d, got %s", expected, scale, got)
+		}
+	}
+	if got := (FixedPoint{Mantissa: f.Rescale(0).Neg(f.Rescale(3)), Scale: 3}).Rescale(1).String(); got != "-670121" {
+		t.Errorf("expected negative numbers to be truncated toward zero, got %s", got)
+	}
+
+	if _, err := NewFixedPoint(&proto.FixedPoint{Mantissa: "12.5"}); !errors.Is(err, ErrInvalidFixedPoint) {
+		t.Errorf("expected ErrInvalidFixedPoint, got %v", err)
+	}
+}
+
+func TestFixedPointExtremeScales(t *testing.T) {
+	for _, scale := range []int32{-1, MaxFixedPointScale + 1, math.MaxInt32, math.MinInt32} {
+		if _, err := NewFixedPoint(&proto.FixedPoint{Mantissa: "1", Scale: scale}); !errors.Is(err, ErrInvalidFixedPoint) {
+			t.Errorf("expected ErrInvalidFixedPoint for scale %d, got %v", scale, err)
+		}
+	}
+	if _, err := ParseFixedPoint("0." + strings.Repeat("1", MaxFixedPointScale+1)); !errors.Is(err, ErrInvalidFixedPoint) {
+		t.Errorf("expected ErrInvalidFixedPoint for too many decimals, got %v", err)
+	}
+
+	f, err := NewFixedPoint(&proto.FixedPoint{Mantissa: "1", Scale: MaxFixedPointScale})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.Rescale(0).String(); got != "0" {
+		t.Errorf("expected 0 at scale 0, got %s", got)
+	}
+	if got := f.Rescale(MaxFixedPointScale + 1).String(); got != "10" {
+		t.Errorf("expected 10 at scale %d, got %s", MaxFixedPointScale+1, got)
+	}
+}
+
+func TestFixedPointString(t *testing.T) {
+	for decimal, expected := range map[string]string{"67012.125": "67012.125", "0.00012": "0.00012", "100": "100", "-1.5": "-1.5", ".5": "0.5"} {
+		f, err := ParseFixedPoint(decimal)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := f.String(); got != expected {
+			t.Errorf("expected %s, got %s", expected, got)
+		}
+	}
+}
+
+func TestNewFixedPrice(t *testing.T) {
+	fromResponse, err := newFixedPrice(&proto.PriceData{Price: "1.5", FixedPrice: &proto.FixedPoint{Mantissa: "150", Scale: 2}})
+	if err != nil || fromResponse.Mantissa.String() != "150" || fromResponse.Scale != 2 {
+		t.Errorf("expected the fixed-point price of the response, got %s, %v", fromResponse, err)
+	}
+
+	// Servers that predate fixed-point prices only send the decimal price.
+	fromDecimal, err := newFixedPrice(&proto.PriceData{Price: "1.5"})
+	if err != nil || fromDecimal.Mantissa.String() != "15" || fromDecimal.Scale != 1 {
+		t.Errorf("expected the parsed decimal price, got %s, %v", fromDecimal, err)
+	}
+
+	malformed := &proto.PriceData{Price: "abc", FixedPrice: &proto.FixedPoint{Mantissa: "1", Scale: math.MaxInt32}}
+	if _, err := newFixedPrice(malformed); !errors.Is(err, ErrInvalidFixedPoint) {
+		t.Errorf("expected ErrInvalidFixedPoint, got %v", err)
+	}
+
+	malformed.SignalId, malformed.PriceStatus = "crypto_price.btcusd", proto.PriceStatus_PRICE_STATUS_AVAILABLE
+	price := newPrice("crypto_price.btcusd", malformed, time.Now())
+	var signalErr *SignalError
+	if !errors.As(price.Err, &signalErr) || !errors.Is(price.Err, ErrInvalidFixedPoint) || price.Fixed.Mantissa != nil {
+		t.Errorf("expected a malformed available price to fail, got %+v", price)
+	}
+}
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// WithHeaders sends the given metadata with every call of the client, e.g. for a load
+// balancer in front of the server that routes on headers. Keys are lower cased, as gRPC
+// requires.
+func WithHeaders(headers map[string]string) Option {
+	return WithDialOptions(grpc.WithChainUnaryInterceptor(HeadersInterceptor(headers)))
+}
+
+// HeadersInterceptor returns an interceptor that sends the given metadata with every call.
+func HeadersInterceptor(headers map[string]string) grpc.UnaryClientInterceptor {
+	pairs := make([]string, 0, 2*len(headers))
+	for key, value := range headers {
+		pairs = append(pairs, strings.ToLower(key), value)
+	}
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = metadata.AppendToOutgoingContext(ctx, pairs...)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// ErrNotServing is returned by Healthy for servers that report themselves as not serving.
+var ErrNotServing = errors.New("server is not serving")
+
+// Healthy checks that the server is live with the standard gRPC health checking protocol,
+// grpc.health.v1, for orchestrators and monitoring probes. Servers that do not implement the
+// protocol are checked with a query of a single signal instead. Health checks bypass the
+// circuit breaker, so that they report the server rather than the client.
+func (c *Client) Healthy(ctx context.Context) error {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	resp, err := healthpb.NewHealthClient(c.conn).Check(ctx, &healthpb.HealthCheckRequest{}, c.callOptions...)
+	if status.Code(err) == codes.Unimplemented {
+		if _, err := c.query.Signals(ctx, &proto.QuerySignalsRequest{PageSize: 1}, c.callOptions...); err != nil {
+			return &CallError{Method: proto.Query_Signals_FullMethodName, Err: err}
+		}
+		return nil
+	}
+	if err != nil {
+		return &CallError{Method: healthpb.Health_Check_FullMethodName, Err: err}
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("%w: %s", ErrNotServing, resp.Status)
+	}
+	return nil
+}
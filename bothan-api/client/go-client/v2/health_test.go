@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealthy(t *testing.T) {
+	healthServer := health.NewServer()
+	c := newTestClient(t, &fakeQueryServer{health: healthServer})
+
+	if err := c.Healthy(context.Background()); err != nil {
+		t.Fatalf("expected a serving server to be healthy, got %v", err)
+	}
+
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	if err := c.Healthy(context.Background()); !errors.Is(err, ErrNotServing) {
+		t.Errorf("expected ErrNotServing, got %v", err)
+	}
+}
+
+func TestHealthyFallback(t *testing.T) {
+	c := newTestClient(t, &fakeQueryServer{})
+
+	if err := c.Healthy(context.Background()); err != nil {
+		t.Fatalf("expected a server without the health service to be checked with a query, got %v", err)
+	}
+}
@@ -0,0 +1,23 @@
+package client
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// WithKeepalive makes the client ping the server after the given interval without activity,
+// and close the connection if a ping is not acknowledged within the timeout, so that
+// connections silently dropped by NATs or load balancers are noticed and redialed. With
+// permitWithoutStream, the client also pings while no call is in flight, which keeps idle
+// connections alive. gRPC raises intervals below 10 seconds to 10 seconds, and servers may
+// close connections that ping more often than they permit. Like WithDialOptions, it is
+// ignored by NewFromConn.
+func WithKeepalive(interval, timeout time.Duration, permitWithoutStream bool) Option {
+	return WithDialOptions(grpc.WithKeepaliveParams(keepalive.ClientParameters{
+		Time:                interval,
+		Timeout:             timeout,
+		PermitWithoutStream: permitWithoutStream,
+	}))
+}
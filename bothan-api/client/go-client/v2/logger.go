@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// Logger receives the log records of the calls of a client. *slog.Logger implements it, and
+// other loggers are adapted by implementing its single method, e.g. with the zerologger
+// module for zerolog.
+type Logger interface {
+	LogAttrs(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr)
+}
+
+// WithLogger logs the start and the end of every call of the client at debug level, and
+// failed calls at warn level, with the method, the queried signal IDs and the duration. Calls
+// are not logged by default.
+func WithLogger(l Logger) Option {
+	return WithDialOptions(grpc.WithChainUnaryInterceptor(LoggerInterceptor(l)))
+}
+
+// LoggerInterceptor returns an interceptor that logs every call to the given logger, see
+// WithLogger.
+func LoggerInterceptor(l Logger) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		attrs := []slog.Attr{slog.String("method", method)}
+		if r, ok := req.(*proto.QueryPricesRequest); ok {
+			attrs = append(attrs, slog.Any("signal_ids", r.SignalIds))
+		}
+		l.LogAttrs(ctx, slog.LevelDebug, "bothan call started", attrs...)
+
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+		if err != nil {
+			attrs = append(attrs, slog.String("code", status.Code(err).String()), slog.String("error", err.Error()))
+			l.LogAttrs(ctx, slog.LevelWarn, "bothan call failed", attrs...)
+			return err
+		}
+		l.LogAttrs(ctx, slog.LevelDebug, "bothan call finished", attrs...)
+		return nil
+	}
+}
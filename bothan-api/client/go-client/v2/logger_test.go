@@ -0,0 +1,46 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	server := &fakeQueryServer{}
+	c := newTestClient(t, server, WithLogger(logger))
+
+	if _, err := c.Prices(context.Background(), []string{"btc"}); err != nil {
+		t.Fatal(err)
+	}
+	server.err = status.Error(codes.Unavailable, "down")
+	if _, err := c.Prices(context.Background(), []string{"eth"}); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expected := []string{
+		`level=DEBUG msg="bothan call started" method=/query.Query/Prices signal_ids=[btc]`,
+		`level=DEBUG msg="bothan call finished" method=/query.Query/Prices signal_ids=[btc] duration=`,
+		`level=DEBUG msg="bothan call started" method=/query.Query/Prices signal_ids=[eth]`,
+		`level=WARN msg="bothan call failed" method=/query.Query/Prices signal_ids=[eth] duration=`,
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d records, got %q", len(expected), lines)
+	}
+	for i, line := range lines {
+		if !strings.Contains(line, expected[i]) {
+			t.Errorf("expected %q to contain %q", line, expected[i])
+		}
+	}
+	if !strings.Contains(lines[3], "code=Unavailable") {
+		t.Errorf("expected the code of the failed call, got %q", lines[3])
+	}
+}
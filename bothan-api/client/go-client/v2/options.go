@@ -0,0 +1,54 @@
+package client
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Option configures a Client.
+type Option func(*options)
+
+type options struct {
+	timeout     time.Duration
+	dialOptions []grpc.DialOption
+	consumer    string
+	callOptions []grpc.CallOption
+	breaker     *CircuitBreaker
+	strict      bool
+
+	// tls and authenticated are reported by DebugReport in place of the credentials.
+	tls           bool
+	authenticated bool
+}
+
+// WithTimeout bounds every call, in addition to the deadline of its context. Calls are only
+// bounded by their context by default.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithDialOptions adds gRPC dial options, which are applied after the default ones and can
+// override them, e.g. to use TLS or the WebSocket tunnel of a bothan-api-proxy.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, opts...)
+	}
+}
+
+// WithUnaryInterceptor chains the given interceptors, e.g. for logging, auth or metrics, into
+// the connection of the client, in the order given. Like other dial options, they do not
+// apply to clients created with NewFromConn.
+func WithUnaryInterceptor(interceptors ...grpc.UnaryClientInterceptor) Option {
+	return WithDialOptions(grpc.WithChainUnaryInterceptor(interceptors...))
+}
+
+// WithConsumer tags every call of the client with the given consumer name, see
+// ConsumerHeader.
+func WithConsumer(name string) Option {
+	return func(o *options) {
+		o.consumer = name
+	}
+}
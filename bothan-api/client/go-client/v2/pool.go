@@ -0,0 +1,83 @@
+package client
+
+import (
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Pool shares one connection among the clients of the same target, which saves the sockets
+// and goroutines of a connection per client in applications with many independent users of
+// the same server. A connection is closed when the last of its clients is closed. A Pool is
+// safe for concurrent use.
+type Pool struct {
+	dialOptions []grpc.DialOption
+
+	mu    sync.Mutex
+	conns map[string]*pooledConn
+}
+
+type pooledConn struct {
+	conn *grpc.ClientConn
+	refs int
+}
+
+// NewPool creates a pool that dials its connections with the given dial options, which are
+// applied after the default ones like those of WithDialOptions.
+func NewPool(dialOptions ...grpc.DialOption) *Pool {
+	return &Pool{dialOptions: dialOptions, conns: make(map[string]*pooledConn)}
+}
+
+// Client creates a client for the server at the given target on the connection of the pool,
+// dialing it if the target has none yet. The dial options of WithDialOptions are ignored, as
+// the connection is shared. Closing the client releases its reference to the connection; it
+// must be closed exactly once like any other client, later calls are no-ops.
+func (p *Pool) Client(target string, opts ...Option) (*Client, error) {
+	conn, err := p.acquire(target)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewFromConn(conn, opts...)
+	var once sync.Once
+	c.close = func() error {
+		var err error
+		once.Do(func() { err = p.release(target) })
+		return err
+	}
+	return c, nil
+}
+
+func (p *Pool) acquire(target string) (*grpc.ClientConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if pc, ok := p.conns[target]; ok {
+		pc.refs++
+		return pc.conn, nil
+	}
+
+	dialOptions := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, p.dialOptions...)
+	conn, err := grpc.Dial(target, dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	p.conns[target] = &pooledConn{conn: conn, refs: 1}
+	return conn, nil
+}
+
+func (p *Pool) release(target string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pc := p.conns[target]
+	pc.refs--
+	if pc.refs > 0 {
+		return nil
+	}
+
+	delete(p.conns, target)
+	return pc.conn.Close()
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc/connectivity"
+)
+
+func TestPool(t *testing.T) {
+	pool := NewPool(serve(t, &fakeQueryServer{}))
+
+	var clients [8]*Client
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c, err := pool.Client("passthrough:///bufconn", WithConsumer("plugin"))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			clients[i] = c
+		}(i)
+	}
+	wg.Wait()
+	if t.Failed() {
+		t.FailNow()
+	}
+
+	if len(pool.conns) != 1 || pool.conns["passthrough:///bufconn"].refs != len(clients) {
+		t.Fatalf("expected one connection shared by %d clients, got %+v", len(clients), pool.conns)
+	}
+	conn := pool.conns["passthrough:///bufconn"].conn
+
+	// Closing a client twice releases its reference only once.
+	for _, c := range clients[1:] {
+		c.Close()
+		c.Close()
+	}
+	if _, err := clients[0].Prices(context.Background(), []string{"btc"}); err != nil {
+		t.Fatalf("expected the connection to stay open for the last client: %v", err)
+	}
+
+	clients[0].Close()
+	if len(pool.conns) != 0 {
+		t.Errorf("expected the connection to be removed, got %+v", pool.conns)
+	}
+	if state := conn.GetState(); state != connectivity.Shutdown {
+		t.Errorf("expected the connection to be closed, got %v", state)
+	}
+
+	// A new client dials a new connection.
+	c, err := pool.Client("passthrough:///bufconn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if _, err := c.Prices(context.Background(), []string{"btc"}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrEmptySignalID is reported for queries that contain an empty signal ID, e.g. from a
+// trailing comma in a REST path.
+var ErrEmptySignalID = errors.New("empty signal ID")
+
+// NormalizeSignalIDs returns the signal IDs of a query with duplicates removed, keeping the
+// first occurrence of each, and fails with ErrEmptySignalID if one of them is empty. IDs are
+// otherwise used as given. The clients and the proxy normalize every query this way, so that
+// all routes treat the same list alike.
+func NormalizeSignalIDs(signalIDs []string) ([]string, error) {
+	normalized := make([]string, 0, len(signalIDs))
+	seen := make(map[string]struct{}, len(signalIDs))
+	for i, signalID := range signalIDs {
+		if signalID == "" {
+			return nil, fmt.Errorf("%w at position %d", ErrEmptySignalID, i)
+		}
+		if _, ok := seen[signalID]; ok {
+			continue
+		}
+		seen[signalID] = struct{}{}
+		normalized = append(normalized, signalID)
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+// signalIDList is a list of signal IDs drawn from a small alphabet, so that duplicates and
+// empty IDs are frequent.
+type signalIDList []string
+
+func (signalIDList) Generate(rand *rand.Rand, size int) reflect.Value {
+	alphabet := []string{"", "btc", "eth", "CS:BTC-USD", "@majors", "a,b"}
+	list := make(signalIDList, rand.Intn(size+1))
+	for i := range list {
+		list[i] = alphabet[rand.Intn(len(alphabet))]
+	}
+	return reflect.ValueOf(list)
+}
+
+func TestNormalizeSignalIDsProperties(t *testing.T) {
+	properties := map[string]func(signalIDList) bool{
+		"rejects exactly the lists with an empty ID": func(ids signalIDList) bool {
+			_, err := NormalizeSignalIDs(ids)
+			return errors.Is(err, ErrEmptySignalID) == slices.Contains(ids, "")
+		},
+		"keeps the first occurrence of every ID in order": func(ids signalIDList) bool {
+			normalized, err := NormalizeSignalIDs(ids)
+			if err != nil {
+				return true
+			}
+			var want []string
+			for _, id := range ids {
+				if !slices.Contains(want, id) {
+					want = append(want, id)
+				}
+			}
+			return slices.Equal(normalized, want)
+		},
+		"is idempotent": func(ids signalIDList) bool {
+			once, err := NormalizeSignalIDs(ids)
+			if err != nil {
+				return true
+			}
+			twice, err := NormalizeSignalIDs(once)
+			return err == nil && slices.Equal(once, twice)
+		},
+	}
+	for name, property := range properties {
+		t.Run(name, func(t *testing.T) {
+			if err := quick.Check(property, nil); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// UnknownSignalIDsHeader is the response metadata in which the server reports the unknown
+// signal IDs of a strict query, separated by commas.
+const UnknownSignalIDsHeader = "bothan-unknown-signal-ids"
+
+// WithStrict makes Prices fail with an *UnknownSignalIDsError if one of the queried signal
+// IDs is not in the registry of the server, which usually means it is misspelled, instead of
+// reporting ErrSignalUnsupported for its price. This catches misconfigured feed lists before
+// they silently return no prices. Servers that do not support strict queries are checked by
+// the client, from the statuses of the prices they return.
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// UnknownSignalIDsError is returned by the Prices of a client created with WithStrict if
+// signal IDs of the query are not in the registry of the server. It matches
+// ErrSignalUnsupported.
+type UnknownSignalIDsError struct {
+	SignalIDs []string
+}
+
+func (e *UnknownSignalIDsError) Error() string {
+	return "unknown signal IDs: " + strings.Join(e.SignalIDs, ", ")
+}
+
+func (e *UnknownSignalIDsError) Is(target error) bool {
+	return target == ErrSignalUnsupported
+}
+
+// unknownSignalIDs returns the *UnknownSignalIDsError of a strict query that the server
+// rejected with err and the given trailer, or nil if err is not about unknown signal IDs.
+func unknownSignalIDs(err error, trailer metadata.MD) error {
+	if status.Code(err) != codes.NotFound {
+		return nil
+	}
+	values := trailer.Get(UnknownSignalIDsHeader)
+	if len(values) == 0 || values[0] == "" {
+		return nil
+	}
+	return &UnknownSignalIDsError{SignalIDs: strings.Split(values[0], ",")}
+}
+
+// unsupportedSignalIDs returns an *UnknownSignalIDsError for the unsupported prices of a
+// strict query, or nil if there are none.
+func unsupportedSignalIDs(prices []Price) error {
+	var signalIDs []string
+	for _, price := range prices {
+		if price.Status == proto.PriceStatus_PRICE_STATUS_UNSUPPORTED {
+			signalIDs = append(signalIDs, price.SignalID)
+		}
+	}
+	if len(signalIDs) == 0 {
+		return nil
+	}
+	return &UnknownSignalIDsError{SignalIDs: signalIDs}
+}
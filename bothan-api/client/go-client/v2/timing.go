@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// ServerTiming is the time the server spent on a price query, which tells server side delays
+// apart from network latency. See WithServerTiming.
+type ServerTiming struct {
+	// Queue is the time the query waited on the server before being handled.
+	Queue time.Duration
+	// Processing is the time the server spent handling the query besides waiting.
+	Processing time.Duration
+	// Reported is false if the server did not report its timing, e.g. older servers.
+	Reported bool
+}
+
+// Total returns the whole time the server spent on the query.
+func (t ServerTiming) Total() time.Duration {
+	return t.Queue + t.Processing
+}
+
+type serverTimingKey struct{}
+
+// WithServerTiming returns a context that makes the price queries made with it store the
+// timing reported by the server in t, similar to grpc.Header. Contexts can carry several
+// ServerTimings, e.g. one of the application and one of a metrics decorator, which are all
+// filled.
+func WithServerTiming(ctx context.Context, t *ServerTiming) context.Context {
+	timings, _ := ctx.Value(serverTimingKey{}).([]*ServerTiming)
+	return context.WithValue(ctx, serverTimingKey{}, append(timings[:len(timings):len(timings)], t))
+}
+
+// ReportServerTiming stores the timing of a response in the ServerTimings of the context. It
+// is called by the clients of this module and only needs to be called by other clients that
+// support WithServerTiming.
+func ReportServerTiming(ctx context.Context, timing *proto.ServerTiming) {
+	var reported ServerTiming
+	if timing != nil {
+		reported = ServerTiming{
+			Queue:      time.Duration(timing.QueueTimeUs) * time.Microsecond,
+			Processing: time.Duration(timing.ProcessingTimeUs) * time.Microsecond,
+			Reported:   true,
+		}
+	}
+
+	timings, _ := ctx.Value(serverTimingKey{}).([]*ServerTiming)
+	for _, t := range timings {
+		*t = reported
+	}
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// ClientTLSConfig returns the TLS configuration of a client that presents the given
+// certificate to servers requiring mutual TLS. The server is verified against rootCAs, or
+// against the system roots if it is nil.
+func ClientTLSConfig(cert tls.Certificate, rootCAs *x509.CertPool) *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      rootCAs,
+		MinVersion:   tls.VersionTLS12,
+	}
+}
+
+// LoadClientTLSConfig is like ClientTLSConfig, with the certificate, its key and the root CAs
+// read from PEM files. An empty caFile verifies the server against the system roots.
+func LoadClientTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error loading client certificate: %w", err)
+	}
+
+	var rootCAs *x509.CertPool
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading root CAs: %w", err)
+		}
+		rootCAs = x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("error loading root CAs: no certificate found in " + caFile)
+		}
+	}
+
+	return ClientTLSConfig(cert, rootCAs), nil
+}
+
+// WithTLS makes the client connect over TLS with the given configuration, e.g. one of
+// ClientTLSConfig for servers that require client certificates.
+func WithTLS(config *tls.Config) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, grpc.WithTransportCredentials(credentials.NewTLS(config)))
+		o.tls = true
+	}
+}
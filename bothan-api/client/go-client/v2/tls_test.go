@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// issue creates a key and a certificate for localhost signed by parent, or self-signed if
+// parent is nil, and returns them PEM encoded.
+func issue(t *testing.T, parent *tls.Certificate, isCA bool) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "bothan test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+
+	issuer, signer := template, any(key)
+	if parent != nil {
+		issuer, signer = parent.Leaf, parent.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func keyPair(t *testing.T, certPEM, keyPEM []byte) tls.Certificate {
+	t.Helper()
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestMutualTLS(t *testing.T) {
+	caCertPEM, caKeyPEM := issue(t, nil, true)
+	ca := keyPair(t, caCertPEM, caKeyPEM)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Leaf)
+
+	serverCertPEM, serverKeyPEM := issue(t, &ca, false)
+	serverCert := keyPair(t, serverCertPEM, serverKeyPEM)
+	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	})))
+	proto.RegisterQueryServer(grpcServer, &fakeQueryServer{})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	dir := t.TempDir()
+	clientCertPEM, clientKeyPEM := issue(t, &ca, false)
+	files := map[string][]byte{"client.pem": clientCertPEM, "client.key": clientKeyPEM, "ca.pem": caCertPEM}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config, err := LoadClientTLSConfig(filepath.Join(dir, "client.pem"), filepath.Join(dir, "client.key"), filepath.Join(dir, "ca.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := New(listener.Addr().String(), WithTLS(config), WithTimeout(5*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if _, err := c.Prices(context.Background(), []string{"btc"}); err != nil {
+		t.Fatalf("expected the client certificate to be accepted: %v", err)
+	}
+
+	// Without a client certificate the handshake fails.
+	anonymous, err := New(listener.Addr().String(), WithTLS(&tls.Config{RootCAs: pool}), WithTimeout(5*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer anonymous.Close()
+	if _, err := anonymous.Prices(context.Background(), []string{"btc"}); err == nil {
+		t.Error("expected a call without a client certificate to fail")
+	}
+}
+
+func TestLoadClientTLSConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	certPEM, keyPEM := issue(t, nil, false)
+	_ = os.WriteFile(filepath.Join(dir, "cert.pem"), certPEM, 0o600)
+	_ = os.WriteFile(filepath.Join(dir, "key.pem"), keyPEM, 0o600)
+	_ = os.WriteFile(filepath.Join(dir, "empty.pem"), nil, 0o600)
+
+	if _, err := LoadClientTLSConfig(filepath.Join(dir, "missing.pem"), filepath.Join(dir, "key.pem"), ""); err == nil {
+		t.Error("expected an error for a missing certificate")
+	}
+	if _, err := LoadClientTLSConfig(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), filepath.Join(dir, "empty.pem")); err == nil {
+		t.Error("expected an error for a CA file without certificates")
+	}
+	config, err := LoadClientTLSConfig(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), "")
+	if err != nil || config.RootCAs != nil || len(config.Certificates) != 1 {
+		t.Errorf("expected a config with the system roots, got %+v, %v", config, err)
+	}
+}
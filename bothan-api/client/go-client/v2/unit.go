@@ -0,0 +1,51 @@
+package client
+
+import (
+	"strings"
+)
+
+// quotes are the quote assets recognized at the end of signal IDs of the form
+// "category.basequote", longest first so that e.g. "usdt" is not taken for "usd".
+var quotes = []string{"usdt", "usdc", "usd", "eur", "btc", "eth"}
+
+// SignalUnit is the base and quote asset of a signal, in upper case, e.g. BTC and USD for a
+// price of BTC in USD.
+type SignalUnit struct {
+	Base  string
+	Quote string
+}
+
+func (u SignalUnit) String() string {
+	return u.Base + "/" + u.Quote
+}
+
+// ParseSignalUnit parses the base and quote assets from a signal ID of the form "CS:BTC-USD"
+// or "crypto_price.btcusd", the same way the server fills in the Base and Quote of the
+// signals it lists. It reports false if the ID follows neither form.
+func ParseSignalUnit(signalID string) (SignalUnit, bool) {
+	if _, pair, ok := strings.Cut(signalID, ":"); ok {
+		base, quote, ok := strings.Cut(pair, "-")
+		if !ok {
+			return SignalUnit{}, false
+		}
+		return newSignalUnit(base, quote)
+	}
+
+	_, pair, ok := strings.Cut(signalID, ".")
+	if !ok {
+		return SignalUnit{}, false
+	}
+	for _, quote := range quotes {
+		if base, ok := strings.CutSuffix(pair, quote); ok {
+			return newSignalUnit(base, quote)
+		}
+	}
+	return SignalUnit{}, false
+}
+
+func newSignalUnit(base, quote string) (SignalUnit, bool) {
+	if base == "" || quote == "" {
+		return SignalUnit{}, false
+	}
+	return SignalUnit{Base: strings.ToUpper(base), Quote: strings.ToUpper(quote)}, true
+}
@@ -0,0 +1,27 @@
+package client
+
+import "testing"
+
+func TestParseSignalUnit(t *testing.T) {
+	tests := []struct {
+		signalID string
+		unit     SignalUnit
+		ok       bool
+	}{
+		{"CS:BTC-USD", SignalUnit{"BTC", "USD"}, true},
+		{"crypto_price.btcusd", SignalUnit{"BTC", "USD"}, true},
+		{"crypto_price.usdtusd", SignalUnit{"USDT", "USD"}, true},
+		{"crypto_price.ethusdt", SignalUnit{"ETH", "USDT"}, true},
+		{"btc", SignalUnit{}, false},
+		{"CS:BTC", SignalUnit{}, false},
+		{"CS:-USD", SignalUnit{}, false},
+		{"crypto_price.usd", SignalUnit{}, false},
+		{"crypto_price.btcjpy", SignalUnit{}, false},
+	}
+	for _, tt := range tests {
+		unit, ok := ParseSignalUnit(tt.signalID)
+		if unit != tt.unit || ok != tt.ok {
+			t.Errorf("ParseSignalUnit(%q) = %v, %v, expected %v, %v", tt.signalID, unit, ok, tt.unit, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	proto "github.com/bandprotocol/bothan/bothan-api/client/go-client/query"
+)
+
+// VersionHeader is the response metadata in which the server reports its version.
+const VersionHeader = "bothan-version"
+
+// ServerVersion returns the version the server reports, with a query of a single signal. It
+// returns an empty version for servers that predate the reporting.
+func (c *Client) ServerVersion(ctx context.Context) (string, error) {
+	if err := c.breaker.Allow(); err != nil {
+		return "", &CallError{Method: proto.Query_Signals_FullMethodName, Err: err}
+	}
+
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	var header metadata.MD
+	callOptions := append(slices.Clip(c.callOptions), grpc.Header(&header))
+	start := time.Now()
+	_, err := c.query.Signals(ctx, &proto.QuerySignalsRequest{PageSize: 1}, callOptions...)
+	c.stats.record(proto.Query_Signals_FullMethodName, start, err)
+	c.breaker.Record(err)
+	if err != nil {
+		return "", &CallError{Method: proto.Query_Signals_FullMethodName, Err: err}
+	}
+
+	if versions := header.Get(VersionHeader); len(versions) > 0 {
+		return versions[0], nil
+	}
+	return "", nil
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// PriceStream delivers the prices of a set of signals as they are updated, see Watch.
+type PriceStream struct {
+	client    *Client
+	ctx       context.Context
+	signalIDs []string
+	interval  time.Duration
+	next      time.Time
+}
+
+// Watch streams the prices of the given signals until ctx is done. The server has no
+// streaming method yet, so the stream queries the prices every interval. Callers of Watch
+// keep working unchanged once the server pushes updates.
+func (c *Client) Watch(ctx context.Context, signalIDs []string, interval time.Duration) *PriceStream {
+	return &PriceStream{client: c, ctx: ctx, signalIDs: signalIDs, interval: interval}
+}
+
+// Recv blocks until the next update of the prices and returns it. The first call returns
+// immediately with the current prices. A failed query is returned as a *CallError and the
+// stream carries on with the next update; once the context of the stream is done, Recv
+// returns its error.
+func (s *PriceStream) Recv() ([]Price, error) {
+	if wait := time.Until(s.next); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-s.ctx.Done():
+			return nil, s.ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if err := s.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.next = time.Now().Add(s.interval)
+	return s.client.Prices(s.ctx, s.signalIDs)
+}
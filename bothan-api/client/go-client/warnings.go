@@ -0,0 +1,69 @@
+package client
+
+import (
+	"fmt"
+	"time"
+)
+
+// WarningKind identifies a condition reported through a WarningHandler.
+type WarningKind int
+
+const (
+	// WarningStaleCache is reported when a Prefetcher answers a query from a cache that missed
+	// its last refresh but is still within its max age.
+	WarningStaleCache WarningKind = iota + 1
+	// WarningFallbackEndpoint is reported when a RestClient serves a request from a proxy other
+	// than the preferred one, see SetWarningHandler.
+	WarningFallbackEndpoint
+	// WarningClockSkew is reported when the clock of the server differs from the local clock by
+	// more than the threshold of WithClockSkewWarning.
+	WarningClockSkew
+)
+
+func (k WarningKind) String() string {
+	switch k {
+	case WarningStaleCache:
+		return "stale_cache"
+	case WarningFallbackEndpoint:
+		return "fallback_endpoint"
+	case WarningClockSkew:
+		return "clock_skew"
+	default:
+		return fmt.Sprintf("WarningKind(%d)", int(k))
+	}
+}
+
+// Warning describes a condition in which the client keeps working but serves degraded
+// results. Only the fields relevant to the kind are set.
+type Warning struct {
+	Kind WarningKind
+	// Endpoint is the base url that served the request, for WarningFallbackEndpoint.
+	Endpoint string
+	// Age is how long ago the served prices were fetched, for WarningStaleCache.
+	Age time.Duration
+	// Skew is how far the clock of the server is ahead of the local clock, for
+	// WarningClockSkew.
+	Skew time.Duration
+}
+
+func (w Warning) String() string {
+	switch w.Kind {
+	case WarningStaleCache:
+		return fmt.Sprintf("%s: serving prices fetched %s ago", w.Kind, w.Age)
+	case WarningFallbackEndpoint:
+		return fmt.Sprintf("%s: request served by %s", w.Kind, w.Endpoint)
+	case WarningClockSkew:
+		return fmt.Sprintf("%s: server clock is %s ahead", w.Kind, w.Skew)
+	default:
+		return w.Kind.String()
+	}
+}
+
+// WarningHandler is called synchronously with every warning, so it should return quickly.
+type WarningHandler func(Warning)
+
+func (h WarningHandler) warn(w Warning) {
+	if h != nil {
+		h(w)
+	}
+}
@@ -0,0 +1,26 @@
+module github.com/bandprotocol/bothan/bothan-api/client/go-client/zerologger
+
+go 1.22.0
+
+replace github.com/bandprotocol/bothan/bothan-api/client/go-client/v2 => ../v2
+
+replace github.com/bandprotocol/bothan/bothan-api/client/go-client/query => ../query
+
+require (
+	github.com/bandprotocol/bothan/bothan-api/client/go-client/v2 v2.0.0
+	github.com/rs/zerolog v1.33.0
+)
+
+require (
+	github.com/bandprotocol/bothan/bothan-api/client/go-client/query v0.1.0 // indirect
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.19.1 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.19 // indirect
+	golang.org/x/net v0.21.0 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	google.golang.org/genproto/googleapis/api v0.0.0-20240506185236-b8a5c65736ae // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240429193739-8cf5692501f6 // indirect
+	google.golang.org/grpc v1.63.2 // indirect
+	google.golang.org/protobuf v1.34.1 // indirect
+)
@@ -0,0 +1,81 @@
+// Package zerologger adapts a zerolog logger to the Logger of the Bothan clients, so that
+// their calls are logged by applications that use zerolog:
+//
+//	c, err := client.New(target, client.WithLogger(zerologger.New(log.Logger)))
+//
+// It is a separate module so that the client does not depend on zerolog.
+package zerologger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/rs/zerolog"
+)
+
+// Logger writes the log records of a client to a zerolog logger.
+type Logger struct {
+	logger zerolog.Logger
+}
+
+// New returns a Logger that writes to the given zerolog logger.
+func New(logger zerolog.Logger) *Logger {
+	return &Logger{logger: logger}
+}
+
+// LogAttrs writes a record with the given attributes as fields, at the zerolog level that
+// corresponds to the given level.
+func (l *Logger) LogAttrs(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	event := l.logger.WithLevel(zerologLevel(level)).Ctx(ctx)
+	if event == nil {
+		return
+	}
+	for _, attr := range attrs {
+		event = field(event, attr.Key, attr.Value.Resolve())
+	}
+	event.Msg(msg)
+}
+
+// zerologLevel maps a level to the highest zerolog level not above it, e.g. levels between
+// info and warn to info.
+func zerologLevel(level slog.Level) zerolog.Level {
+	switch {
+	case level >= slog.LevelError:
+		return zerolog.ErrorLevel
+	case level >= slog.LevelWarn:
+		return zerolog.WarnLevel
+	case level >= slog.LevelInfo:
+		return zerolog.InfoLevel
+	case level >= slog.LevelDebug:
+		return zerolog.DebugLevel
+	default:
+		return zerolog.TraceLevel
+	}
+}
+
+func field(event *zerolog.Event, key string, value slog.Value) *zerolog.Event {
+	switch value.Kind() {
+	case slog.KindString:
+		return event.Str(key, value.String())
+	case slog.KindInt64:
+		return event.Int64(key, value.Int64())
+	case slog.KindUint64:
+		return event.Uint64(key, value.Uint64())
+	case slog.KindFloat64:
+		return event.Float64(key, value.Float64())
+	case slog.KindBool:
+		return event.Bool(key, value.Bool())
+	case slog.KindDuration:
+		return event.Dur(key, value.Duration())
+	case slog.KindTime:
+		return event.Time(key, value.Time())
+	case slog.KindGroup:
+		dict := zerolog.Dict()
+		for _, attr := range value.Group() {
+			dict = field(dict, attr.Key, attr.Value.Resolve())
+		}
+		return event.Dict(key, dict)
+	default:
+		return event.Interface(key, value.Any())
+	}
+}
@@ -0,0 +1,53 @@
+package zerologger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	clientv2 "github.com/bandprotocol/bothan/bothan-api/client/go-client/v2"
+)
+
+var _ clientv2.Logger = (*Logger)(nil)
+
+func TestLogAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(zerolog.New(&buf).Level(zerolog.InfoLevel))
+
+	l.LogAttrs(context.Background(), slog.LevelDebug, "bothan call started")
+	l.LogAttrs(context.Background(), slog.LevelWarn, "bothan call failed",
+		slog.String("method", "/query.Query/Prices"),
+		slog.Any("signal_ids", []string{"btc"}),
+		slog.Duration("duration", 2*time.Millisecond),
+		slog.Group("peer", slog.Int("port", 50051)),
+	)
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected a single record above the level of the logger, got %q: %v", buf.String(), err)
+	}
+	expected := map[string]any{
+		"level":      "warn",
+		"message":    "bothan call failed",
+		"method":     "/query.Query/Prices",
+		"signal_ids": []any{"btc"},
+		"duration":   float64(2),
+		"peer":       map[string]any{"port": float64(50051)},
+	}
+	if b, _ := json.Marshal(record); string(b) != mustMarshal(expected) {
+		t.Errorf("expected %s, got %s", mustMarshal(expected), b)
+	}
+}
+
+func mustMarshal(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
@@ -1,17 +1,49 @@
+use std::collections::{HashMap, HashSet};
 use std::sync::Arc;
+use std::time::{Duration, Instant, SystemTime, UNIX_EPOCH};
 
 use tokio::sync::Mutex;
-use tonic::{Request, Response, Status};
+use tonic::metadata::{MetadataMap, MetadataValue};
+use tonic::{Code, Request, Response, Status};
 use tracing::info;
 
 use crate::manager::PriceServiceManager;
 use crate::proto::query::query_server::Query;
-use crate::proto::query::{QueryPricesRequest, QueryPricesResponse};
+use crate::proto::query::{
+    GroupExpansion, PriceData, PriceStatus, QueryActiveSignalsRequest, QueryActiveSignalsResponse,
+    QueryPricesRequest, QueryPricesResponse, QueryRegistryRequest, QueryRegistryResponse,
+    QuerySignalsRequest, QuerySignalsResponse, QuerySourceStatsRequest, QuerySourceStatsResponse,
+    QueryStatsSummaryRequest, QueryStatsSummaryResponse, ServerTiming, SignalInfo,
+};
+use crate::registry::unit::parse_signal_unit;
 use crate::utils::arc_mutex;
 
+/// The number of signals returned by the `Signals` RPC if the request does not specify it.
+const DEFAULT_PAGE_SIZE: usize = 100;
+/// The maximum number of signals returned by the `Signals` RPC.
+const MAX_PAGE_SIZE: usize = 1000;
+/// The prefix of the signal ids that refer to a signal group.
+const GROUP_PREFIX: char = '@';
+/// The response metadata keys that carry the `ServerTiming` of a response in microseconds,
+/// for clients that only look at headers.
+const QUEUE_TIME_KEY: &str = "bothan-queue-time-us";
+const PROCESSING_TIME_KEY: &str = "bothan-processing-time-us";
+/// The response metadata key that carries the version of the server, so that clients can
+/// check it without a dedicated RPC.
+const VERSION_KEY: &str = "bothan-version";
+/// The response metadata key that carries the unix time of the server in milliseconds when it
+/// sent the response, so that clients can detect clock skew, which breaks staleness checks.
+const SERVER_TIME_KEY: &str = "bothan-server-time";
+/// The metadata key that carries the unknown signal ids of a strict request.
+const UNKNOWN_SIGNAL_IDS_KEY: &str = "bothan-unknown-signal-ids";
+
 /// The `CryptoQueryServer` struct represents a server for querying cryptocurrency prices.
 pub struct CryptoQueryServer {
     manager: Arc<Mutex<PriceServiceManager>>,
+    groups: HashMap<String, Vec<String>>,
+    registry_version: String,
+    registry_hash: String,
+    started: Instant,
 }
 
 impl CryptoQueryServer {
@@ -19,8 +51,26 @@ impl CryptoQueryServer {
     pub fn new(manager: PriceServiceManager) -> Self {
         CryptoQueryServer {
             manager: arc_mutex!(manager),
+            groups: HashMap::new(),
+            registry_version: String::new(),
+            registry_hash: String::new(),
+            started: Instant::now(),
         }
     }
+
+    /// Sets the signal groups that requests can refer to as `@name`.
+    pub fn with_groups(mut self, groups: HashMap<String, Vec<String>>) -> Self {
+        self.groups = groups;
+        self
+    }
+
+    /// Sets the configured version and the hex encoded SHA-256 hash of the file of the
+    /// registry, which the `Registry` RPC returns along with the registry.
+    pub fn with_registry_info(mut self, version: String, hash: String) -> Self {
+        self.registry_version = version;
+        self.registry_hash = hash;
+        self
+    }
 }
 
 #[tonic::async_trait]
@@ -29,18 +79,473 @@ impl Query for CryptoQueryServer {
         &self, // Change to accept mutable reference
         request: Request<QueryPricesRequest>,
     ) -> Result<Response<QueryPricesResponse>, Status> {
-        let signal_ids = request.into_inner().signal_ids;
-        info!("crypto_price::received::{:?}", signal_ids);
+        let received = Instant::now();
+        let QueryPricesRequest {
+            signal_ids: requested_ids,
+            statuses,
+            strict,
+        } = request.into_inner();
+        info!("crypto_price::received::{:?}", requested_ids);
+        let (signal_ids, expansions) = expand_groups(&self.groups, requested_ids)
+            .map_err(|group| Status::invalid_argument(format!("unknown signal group {}", group)))?;
         let l = &signal_ids
             .iter()
             .map(|symbol| symbol.as_str())
             .collect::<Vec<&str>>();
 
+        let waiting = Instant::now();
         let mut manager = self.manager.lock().await;
-        let prices = manager.get_prices(l).await;
+        let waited = waiting.elapsed();
+        if strict {
+            let unknown = manager.unknown_ids(l);
+            if !unknown.is_empty() {
+                return Err(unknown_signal_ids(&unknown));
+            }
+        }
+        let mut prices = manager.get_prices(l).await;
+        retain_statuses(&mut prices, &statuses);
 
-        let response = QueryPricesResponse { prices };
+        let timing = server_timing(received.elapsed(), waited);
+        let response = QueryPricesResponse {
+            prices,
+            expansions,
+            server_timing: Some(timing.clone()),
+        };
         info!("crypto_price::response::{:?}", response);
-        Ok(Response::new(response))
+        let mut response = versioned(response);
+        insert_timing(response.metadata_mut(), &timing);
+        Ok(response)
+    }
+
+    async fn signals(
+        &self,
+        request: Request<QuerySignalsRequest>,
+    ) -> Result<Response<QuerySignalsResponse>, Status> {
+        let request = request.into_inner();
+        let page_size = match request.page_size as usize {
+            0 => DEFAULT_PAGE_SIZE,
+            size => size.min(MAX_PAGE_SIZE),
+        };
+
+        // The listing serves pickers and health checks, so it reports the cached statuses,
+        // read after releasing the lock, rather than querying and subscribing the sources.
+        let (signal_ids, cached) = {
+            let manager = self.manager.lock().await;
+            (manager.signal_ids(), manager.cached_prices())
+        };
+        let (page, next_page_token) = paginate(&signal_ids, &request.page_token, page_size);
+
+        let ids = page.iter().map(|id| id.as_str()).collect::<Vec<&str>>();
+        let active_ids = cached.active_ids().await;
+        let signals = cached
+            .get_prices(&ids)
+            .await
+            .into_iter()
+            .map(|price_data| {
+                let unit = parse_signal_unit(&price_data.signal_id);
+                let (base, quote) = unit.map(|u| (u.base, u.quote)).unwrap_or_default();
+                let active = active_ids.binary_search(&price_data.signal_id).is_ok();
+                SignalInfo {
+                    signal_id: price_data.signal_id,
+                    price_status: price_data.price_status,
+                    base,
+                    quote,
+                    active,
+                }
+            })
+            .collect();
+
+        Ok(versioned(QuerySignalsResponse {
+            signals,
+            next_page_token,
+        }))
+    }
+
+    async fn source_stats(
+        &self,
+        _: Request<QuerySourceStatsRequest>,
+    ) -> Result<Response<QuerySourceStatsResponse>, Status> {
+        let manager = self.manager.lock().await;
+        let sources = manager.source_stats().await;
+
+        Ok(versioned(QuerySourceStatsResponse { sources }))
+    }
+
+    async fn registry(
+        &self,
+        _: Request<QueryRegistryRequest>,
+    ) -> Result<Response<QueryRegistryResponse>, Status> {
+        let manager = self.manager.lock().await;
+        let registry = manager
+            .registry_json()
+            .map_err(|e| Status::internal(format!("cannot encode the registry: {}", e)))?;
+
+        Ok(versioned(QueryRegistryResponse {
+            version: self.registry_version.clone(),
+            hash: self.registry_hash.clone(),
+            registry,
+        }))
+    }
+
+    async fn stats_summary(
+        &self,
+        _: Request<QueryStatsSummaryRequest>,
+    ) -> Result<Response<QueryStatsSummaryResponse>, Status> {
+        // Read the cached results after releasing the lock, so that the summary neither waits
+        // for the sources nor blocks the price queries.
+        let (signal_ids, cached) = {
+            let manager = self.manager.lock().await;
+            (manager.signal_ids(), manager.cached_prices())
+        };
+        let ids = signal_ids
+            .iter()
+            .map(|id| id.as_str())
+            .collect::<Vec<&str>>();
+        let prices = cached.get_prices(&ids).await;
+
+        Ok(versioned(QueryStatsSummaryResponse {
+            registry_version: self.registry_version.clone(),
+            uptime_seconds: self.started.elapsed().as_secs(),
+            ..summarize(&prices)
+        }))
+    }
+
+    async fn active_signals(
+        &self,
+        _: Request<QueryActiveSignalsRequest>,
+    ) -> Result<Response<QueryActiveSignalsResponse>, Status> {
+        // Like the listing of the signals, read the cache after releasing the lock.
+        let cached = self.manager.lock().await.cached_prices();
+        let signal_ids = cached.active_ids().await;
+
+        Ok(versioned(QueryActiveSignalsResponse { signal_ids }))
+    }
+}
+
+/// Creates a response that reports the version and the current time of the server in its
+/// metadata.
+fn versioned<T>(message: T) -> Response<T> {
+    let mut response = Response::new(message);
+    response.metadata_mut().insert(
+        VERSION_KEY,
+        MetadataValue::from_static(env!("CARGO_PKG_VERSION")),
+    );
+    let now = SystemTime::now()
+        .duration_since(UNIX_EPOCH)
+        .unwrap_or_default();
+    response
+        .metadata_mut()
+        .insert(SERVER_TIME_KEY, MetadataValue::from(now.as_millis() as u64));
+    response
+}
+
+/// Splits the time spent on a request into the time it waited for the price manager and the
+/// time spent on everything else.
+fn server_timing(total: Duration, waited: Duration) -> ServerTiming {
+    ServerTiming {
+        queue_time_us: waited.as_micros() as u64,
+        processing_time_us: total.saturating_sub(waited).as_micros() as u64,
+    }
+}
+
+/// Adds the timing of a response to its metadata, which gRPC sends as response headers.
+fn insert_timing(metadata: &mut MetadataMap, timing: &ServerTiming) {
+    metadata.insert(QUEUE_TIME_KEY, MetadataValue::from(timing.queue_time_us));
+    metadata.insert(
+        PROCESSING_TIME_KEY,
+        MetadataValue::from(timing.processing_time_us),
+    );
+}
+
+/// Creates the NOT_FOUND status of a strict request with the given unknown signal ids, which
+/// are also sent in the metadata for clients to read them without parsing the message.
+fn unknown_signal_ids(unknown: &[&str]) -> Status {
+    let ids = unknown.join(",");
+    let mut metadata = MetadataMap::new();
+    if let Ok(value) = ids.parse() {
+        metadata.insert(UNKNOWN_SIGNAL_IDS_KEY, value);
+    }
+    Status::with_metadata(
+        Code::NotFound,
+        format!("unknown signal ids: {}", unknown.join(", ")),
+        metadata,
+    )
+}
+
+/// Keeps the prices with one of the given statuses, or all prices if no status is given.
+fn retain_statuses(prices: &mut Vec<PriceData>, statuses: &[i32]) {
+    if !statuses.is_empty() {
+        prices.retain(|price| statuses.contains(&price.price_status));
+    }
+}
+
+/// Replaces the references to signal groups in `signal_ids` by the signal ids of the groups,
+/// dropping duplicates, and returns them together with the expansion of each referenced group.
+/// Returns the reference of the first unknown group as error.
+fn expand_groups(
+    groups: &HashMap<String, Vec<String>>,
+    signal_ids: Vec<String>,
+) -> Result<(Vec<String>, Vec<GroupExpansion>), String> {
+    let mut seen = HashSet::new();
+    let mut expanded = Vec::with_capacity(signal_ids.len());
+    let mut expansions: Vec<GroupExpansion> = Vec::new();
+
+    for signal_id in signal_ids {
+        match signal_id.strip_prefix(GROUP_PREFIX) {
+            Some(name) => {
+                let members = groups.get(name).ok_or_else(|| signal_id.clone())?;
+                for member in members {
+                    if seen.insert(member.clone()) {
+                        expanded.push(member.clone());
+                    }
+                }
+                if !expansions.iter().any(|e| e.group == signal_id) {
+                    expansions.push(GroupExpansion {
+                        group: signal_id,
+                        signal_ids: members.clone(),
+                    });
+                }
+            }
+            None => {
+                if seen.insert(signal_id.clone()) {
+                    expanded.push(signal_id);
+                }
+            }
+        }
+    }
+
+    Ok((expanded, expansions))
+}
+
+/// Counts the given prices by status and finds the oldest and newest timestamp of the available
+/// ones.
+fn summarize(prices: &[PriceData]) -> QueryStatsSummaryResponse {
+    let mut summary = QueryStatsSummaryResponse {
+        total_signals: prices.len() as u64,
+        ..Default::default()
+    };
+    for price in prices {
+        match PriceStatus::try_from(price.price_status) {
+            Ok(PriceStatus::Available) => summary.available_signals += 1,
+            Ok(PriceStatus::Unavailable) => {
+                summary.unavailable_signals += 1;
+                continue;
+            }
+            Ok(PriceStatus::Unsupported) => {
+                summary.unsupported_signals += 1;
+                continue;
+            }
+            _ => continue,
+        }
+        if price.timestamp == 0 {
+            continue;
+        }
+        if summary.oldest_update == 0 || price.timestamp < summary.oldest_update {
+            summary.oldest_update = price.timestamp;
+        }
+        summary.newest_update = summary.newest_update.max(price.timestamp);
+    }
+
+    summary
+}
+
+/// Returns the page of the sorted `signal_ids` following the signal id `page_token`, together
+/// with the token of the next page, which is empty if there are no further signal ids.
+fn paginate<'a>(
+    signal_ids: &'a [String],
+    page_token: &str,
+    page_size: usize,
+) -> (&'a [String], String) {
+    let start = if page_token.is_empty() {
+        0
+    } else {
+        signal_ids.partition_point(|id| id.as_str() <= page_token)
+    };
+    let end = (start + page_size).min(signal_ids.len());
+
+    let page = &signal_ids[start..end];
+    let next_page_token = match page.last() {
+        Some(last) if end < signal_ids.len() => last.clone(),
+        _ => String::new(),
+    };
+
+    (page, next_page_token)
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    fn mock_signal_ids() -> Vec<String> {
+        ["A", "B", "C", "D", "E"]
+            .iter()
+            .map(|id| id.to_string())
+            .collect()
+    }
+
+    #[test]
+    fn test_versioned() {
+        let response = versioned(());
+        let version = response.metadata().get(VERSION_KEY).unwrap();
+        assert_eq!(version, env!("CARGO_PKG_VERSION"));
+        let server_time = response.metadata().get(SERVER_TIME_KEY).unwrap();
+        let server_time: u64 = server_time.to_str().unwrap().parse().unwrap();
+        assert!(server_time > 1_700_000_000_000);
+    }
+
+    #[test]
+    fn test_unknown_signal_ids() {
+        let status = unknown_signal_ids(&["BTCC", "ETHH"]);
+        assert_eq!(status.code(), Code::NotFound);
+        assert_eq!(status.message(), "unknown signal ids: BTCC, ETHH");
+        let ids = status.metadata().get(UNKNOWN_SIGNAL_IDS_KEY).unwrap();
+        assert_eq!(ids, "BTCC,ETHH");
+    }
+
+    #[test]
+    fn test_paginate() {
+        let signal_ids = mock_signal_ids();
+
+        let (page, token) = paginate(&signal_ids, "", 2);
+        assert_eq!(page, &signal_ids[0..2]);
+        assert_eq!(token, "B");
+
+        let (page, token) = paginate(&signal_ids, &token, 2);
+        assert_eq!(page, &signal_ids[2..4]);
+        assert_eq!(token, "D");
+
+        let (page, token) = paginate(&signal_ids, &token, 2);
+        assert_eq!(page, &signal_ids[4..5]);
+        assert_eq!(token, "");
+    }
+
+    #[test]
+    fn test_paginate_with_unknown_page_token() {
+        let signal_ids = mock_signal_ids();
+
+        let (page, token) = paginate(&signal_ids, "BB", 10);
+        assert_eq!(page, &signal_ids[2..5]);
+        assert_eq!(token, "");
+
+        let (page, token) = paginate(&signal_ids, "Z", 10);
+        assert!(page.is_empty());
+        assert_eq!(token, "");
+    }
+
+    fn mock_groups() -> HashMap<String, Vec<String>> {
+        HashMap::from([(
+            "stablecoins".to_string(),
+            vec!["USDT".to_string(), "USDC".to_string()],
+        )])
+    }
+
+    #[test]
+    fn test_expand_groups() {
+        let signal_ids = ["BTC", "@stablecoins", "USDC", "@stablecoins"]
+            .iter()
+            .map(|id| id.to_string())
+            .collect();
+
+        let (expanded, expansions) = expand_groups(&mock_groups(), signal_ids).unwrap();
+        assert_eq!(expanded, vec!["BTC", "USDT", "USDC"]);
+        assert_eq!(
+            expansions,
+            vec![GroupExpansion {
+                group: "@stablecoins".to_string(),
+                signal_ids: vec!["USDT".to_string(), "USDC".to_string()],
+            }]
+        );
+    }
+
+    #[test]
+    fn test_server_timing() {
+        let timing = server_timing(Duration::from_micros(1750), Duration::from_micros(1500));
+        assert_eq!(timing.queue_time_us, 1500);
+        assert_eq!(timing.processing_time_us, 250);
+
+        let mut metadata = MetadataMap::new();
+        insert_timing(&mut metadata, &timing);
+        assert_eq!(
+            metadata.get(QUEUE_TIME_KEY).unwrap().to_str().unwrap(),
+            "1500"
+        );
+        assert_eq!(
+            metadata.get(PROCESSING_TIME_KEY).unwrap().to_str().unwrap(),
+            "250"
+        );
+    }
+
+    #[test]
+    fn test_server_timing_saturates() {
+        let timing = server_timing(Duration::from_micros(10), Duration::from_micros(20));
+        assert_eq!(timing.processing_time_us, 0);
+    }
+
+    #[test]
+    fn test_expand_unknown_group() {
+        let signal_ids = vec!["BTC".to_string(), "@unknown".to_string()];
+
+        let result = expand_groups(&mock_groups(), signal_ids);
+        assert_eq!(result, Err("@unknown".to_string()));
+    }
+
+    #[test]
+    fn test_retain_statuses() {
+        let price = |signal_id: &str, status: PriceStatus| PriceData {
+            signal_id: signal_id.to_string(),
+            price: String::new(),
+            price_status: status.into(),
+            fixed_price: None,
+            timestamp: 0,
+        };
+        let prices = vec![
+            price("BTC", PriceStatus::Available),
+            price("ETH", PriceStatus::Unavailable),
+            price("XYZ", PriceStatus::Unsupported),
+        ];
+
+        let mut all = prices.clone();
+        retain_statuses(&mut all, &[]);
+        assert_eq!(all, prices);
+
+        let mut filtered = prices.clone();
+        retain_statuses(
+            &mut filtered,
+            &[
+                PriceStatus::Available.into(),
+                PriceStatus::Unsupported.into(),
+            ],
+        );
+        let ids = filtered
+            .iter()
+            .map(|p| p.signal_id.as_str())
+            .collect::<Vec<_>>();
+        assert_eq!(ids, vec!["BTC", "XYZ"]);
+    }
+    #[test]
+    fn test_summarize() {
+        let price = |status: PriceStatus, timestamp: u64| PriceData {
+            signal_id: String::new(),
+            price: String::new(),
+            price_status: status.into(),
+            fixed_price: None,
+            timestamp,
+        };
+        let prices = vec![
+            price(PriceStatus::Available, 1_700_000_300),
+            price(PriceStatus::Available, 1_700_000_100),
+            price(PriceStatus::Available, 0),
+            price(PriceStatus::Unavailable, 0),
+            price(PriceStatus::Unsupported, 0),
+        ];
+
+        let summary = summarize(&prices);
+        assert_eq!(summary.total_signals, 5);
+        assert_eq!(summary.available_signals, 3);
+        assert_eq!(summary.unavailable_signals, 1);
+        assert_eq!(summary.unsupported_signals, 1);
+        assert_eq!(summary.oldest_update, 1_700_000_100);
+        assert_eq!(summary.newest_update, 1_700_000_300);
+        assert_eq!(summarize(&[]), QueryStatsSummaryResponse::default());
     }
 }
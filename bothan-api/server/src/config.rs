@@ -1,3 +1,5 @@
+use std::collections::HashMap;
+
 use config::Config;
 use serde::Deserialize;
 
@@ -63,6 +65,9 @@ pub struct AppConfig {
     pub source: SourceConfig,
     pub registry: RegistryConfig,
     pub logging: LoggingConfig,
+    /// The signal groups that requests can refer to as `@name`, keyed by name.
+    #[serde(default)]
+    pub groups: HashMap<String, Vec<String>>,
 }
 
 impl AppConfig {
@@ -1,7 +1,9 @@
-use std::fs::File;
+use std::fs;
 use std::sync::Arc;
 
 use anyhow::{bail, Result};
+use sha2::{Digest, Sha256};
+use tonic::codec::CompressionEncoding;
 use tonic::transport::Server;
 use tracing::info;
 
@@ -36,14 +38,19 @@ async fn main() {
 
     info!("Server running on {}", addr);
     let _ = Server::builder()
-        .add_service(QueryServer::new(crypto_query_server))
+        .add_service(
+            QueryServer::new(crypto_query_server)
+                .accept_compressed(CompressionEncoding::Gzip)
+                .send_compressed(CompressionEncoding::Gzip),
+        )
         .serve(addr)
         .await;
 }
 
 async fn init_crypto_server(config: &AppConfig) -> Result<CryptoQueryServer> {
-    let file = File::open(config.registry.crypto_price.source.clone())?;
-    let registry = Arc::new(serde_json::from_reader::<_, Registry>(file)?);
+    let content = fs::read(&config.registry.crypto_price.source)?;
+    let registry = Arc::new(serde_json::from_slice::<Registry>(&content)?);
+    let registry_hash = format!("{:x}", Sha256::digest(&content));
     if !registry.validate() {
         bail!("registry validation failed".to_string());
     }
@@ -52,7 +59,9 @@ async fn init_crypto_server(config: &AppConfig) -> Result<CryptoQueryServer> {
 
     init_crypto_services(config, &mut manager).await;
 
-    Ok(CryptoQueryServer::new(manager))
+    Ok(CryptoQueryServer::new(manager)
+        .with_groups(config.groups.clone())
+        .with_registry_info(config.registry.crypto_price.version.clone(), registry_hash))
 }
 
 #[rustfmt::skip]
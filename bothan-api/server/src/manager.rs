@@ -1,3 +1,3 @@
 mod price_service;
 
-pub use price_service::manager::PriceServiceManager;
+pub use price_service::manager::{CachedPrices, PriceServiceManager};
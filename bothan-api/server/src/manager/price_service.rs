@@ -1,3 +1,4 @@
 pub mod manager;
+mod stats;
 mod types;
 mod utils;
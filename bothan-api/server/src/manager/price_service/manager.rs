@@ -1,6 +1,7 @@
-use std::collections::{HashMap, HashSet, VecDeque};
+use std::collections::{BTreeMap, HashMap, HashSet, VecDeque};
 use std::str::FromStr;
 use std::sync::Arc;
+use std::time::Instant;
 
 use tokio::sync::Mutex;
 use tokio::task::JoinSet;
@@ -9,11 +10,12 @@ use tracing::debug;
 use bothan_core::service::{Service as CoreService, ServiceResult};
 use bothan_core::types::PriceData as CorePriceData;
 
+use crate::manager::price_service::stats::SourceStatsStore;
 use crate::manager::price_service::types::{
-    ResultsStore, ServiceMap, SignalResultsStore, SourceResultsStore,
+    ResultsStore, ServiceMap, SignalResultsStore, SourceResultsStore, TimedPrice,
 };
-use crate::manager::price_service::utils::into_key;
-use crate::proto::query::{PriceData, PriceStatus};
+use crate::manager::price_service::utils::{fixed_point, into_key};
+use crate::proto::query::{PriceData, PriceStatus, SourceStats};
 use crate::registry::source::Route;
 use crate::registry::Registry;
 use crate::tasks::error::Error;
@@ -46,6 +48,36 @@ pub struct PriceServiceManager {
     service_map: Arc<Mutex<ServiceMap<Box<dyn CoreService>>>>,
     registry: Arc<Registry>,
     stale_threshold: u64,
+    source_stats: Arc<SourceStatsStore>,
+    latest: Arc<SignalResultsStore>,
+}
+
+/// CachedPrices reads the latest results of the signals computed by
+/// [`PriceServiceManager::get_prices`], without querying the sources. It does not borrow the
+/// manager, so that it can be read after releasing the lock of the manager.
+pub struct CachedPrices {
+    latest: Arc<SignalResultsStore>,
+}
+
+impl CachedPrices {
+    /// Gets the [`PriceData`](crate::proto::query::query::PriceData) of the given signal ids
+    /// as of the last time they were computed. Signals that were never computed are reported
+    /// as unavailable, as their sources have not been queried for them yet.
+    pub async fn get_prices(&self, ids: &[&str]) -> Vec<PriceData> {
+        get_result_from_store(ids, self.latest.clone(), PriceStatus::Unavailable).await
+    }
+
+    /// Gets the ids of the signals that were computed at least once and that a source
+    /// supports, sorted in ascending order. Their sources were subscribed when they were
+    /// computed, so the server keeps them up to date.
+    pub async fn active_ids(&self) -> Vec<String> {
+        let mut ids = self
+            .latest
+            .keys_where(|result| !matches!(result, Err(PriceStatus::Unsupported)))
+            .await;
+        ids.sort();
+        ids
+    }
 }
 
 impl PriceServiceManager {
@@ -56,6 +88,8 @@ impl PriceServiceManager {
                 service_map: arc_mutex!(HashMap::new()),
                 registry,
                 stale_threshold,
+                source_stats: Arc::new(SourceStatsStore::new()),
+                latest: Arc::new(ResultsStore::new()),
             }),
             Err(e) => Err(e),
         }
@@ -69,7 +103,42 @@ impl PriceServiceManager {
             .insert(name, arc_mutex!(service));
     }
 
+    /// Gets the ids of all signals in the registry, sorted in ascending order.
+    pub fn signal_ids(&self) -> Vec<String> {
+        let mut signal_ids = self.registry.keys().cloned().collect::<Vec<String>>();
+        signal_ids.sort();
+        signal_ids
+    }
+
+    /// Gets the given signal ids that are not in the registry, in the order they are given.
+    pub fn unknown_ids<'a>(&self, ids: &[&'a str]) -> Vec<&'a str> {
+        ids.iter()
+            .filter(|id| !self.registry.contains_key(**id))
+            .cloned()
+            .collect()
+    }
+
+    /// Gets the registry as JSON, with the signals ordered by signal id.
+    pub fn registry_json(&self) -> serde_json::Result<String> {
+        serde_json::to_string(&self.registry.iter().collect::<BTreeMap<_, _>>())
+    }
+
+    /// Gets the latency and success rate of every source queried so far, best first.
+    pub async fn source_stats(&self) -> Vec<SourceStats> {
+        self.source_stats.leaderboard().await
+    }
+
+    /// Gets the results of the last computation of every signal, for reads that must neither
+    /// query nor subscribe the sources, such as status pages.
+    pub fn cached_prices(&self) -> CachedPrices {
+        CachedPrices {
+            latest: self.latest.clone(),
+        }
+    }
+
     /// Gets the [`PriceData`](crate::proto::query::query::PriceData) of the given signal ids.
+    /// The sources are queried, which subscribes them to the ids they do not serve yet, and the
+    /// results are kept for [`cached_prices`](Self::cached_prices).
     pub async fn get_prices(&mut self, ids: &[&str]) -> Vec<PriceData> {
         let current_time = chrono::Utc::now().timestamp();
         let registry = self.registry.clone();
@@ -99,6 +168,7 @@ impl PriceServiceManager {
                         map,
                         src_store,
                         sig_store,
+                        self.source_stats.clone(),
                         current_time,
                         self.stale_threshold,
                     )
@@ -115,7 +185,20 @@ impl PriceServiceManager {
             }
         };
 
-        get_result_from_store(ids, signal_results_store.clone()).await
+        // Keep the results, with the signals the sources did not compute as unsupported like
+        // in the response.
+        let missing = signal_results_store
+            .get_batched(&available)
+            .await
+            .into_iter()
+            .zip(&available)
+            .filter(|(result, _)| result.is_none())
+            .map(|(_, id)| (*id, Err(PriceStatus::Unsupported)))
+            .collect::<Vec<_>>();
+        signal_results_store.set_batched(missing).await;
+        self.latest.merge(&signal_results_store).await;
+
+        get_result_from_store(ids, signal_results_store.clone(), PriceStatus::Unsupported).await
     }
 }
 
@@ -153,8 +236,8 @@ async fn store_source_data(
     store: Arc<SourceResultsStore>,
     current_time: i64,
     stale_threshold: u64,
-) {
-    let results: Vec<(String, f64)> = ids
+) -> usize {
+    let results: Vec<(String, TimedPrice)> = ids
         .iter()
         .zip(service_results)
         .filter_map(|(id, service)| {
@@ -163,7 +246,7 @@ async fn store_source_data(
                 if (current_time - pd.timestamp as i64) < stale_threshold as i64 {
                     f64::from_str(pd.price.as_str())
                         .ok()
-                        .map(|price| (key, price))
+                        .map(|price| (key, (price, pd.timestamp)))
                 } else {
                     None
                 }
@@ -171,14 +254,16 @@ async fn store_source_data(
         })
         .collect();
 
+    let fresh = results.len();
     store.set_batched(results).await;
+    fresh
 }
 
 async fn process_source_routes(
-    start: f64,
+    start: TimedPrice,
     routes: &Vec<Route>,
     signal_result_store: &SignalResultsStore,
-) -> Option<f64> {
+) -> Option<TimedPrice> {
     // Pre-store and compute the fold values
     let mut signal_values = HashMap::new();
     for route in routes {
@@ -189,18 +274,24 @@ async fn process_source_routes(
         signal_values.insert(signal_id, price);
     }
 
-    routes.iter().try_fold(start, |acc, route| {
-        let price = signal_values.get(&route.signal_id)?;
-        Some(route.operation.execute(acc, *price))
-    })
+    routes
+        .iter()
+        .try_fold(start, |(acc, acc_timestamp), route| {
+            let (price, timestamp) = signal_values.get(&route.signal_id)?;
+            Some((
+                route.operation.execute(acc, *price),
+                acc_timestamp.min(*timestamp),
+            ))
+        })
 }
 
 async fn process_signal_task(
     signal_task: &SignalTask,
     source_results_store: &SourceResultsStore,
     signal_results_store: &SignalResultsStore,
-) -> Result<f64, PriceStatus> {
+) -> Result<TimedPrice, PriceStatus> {
     let mut data = Vec::new();
+    let mut timestamps = Vec::new();
     for source in &signal_task.signal().sources {
         let key = into_key(&source.source_id, &source.id);
         let saved_price = source_results_store.get(&key).await;
@@ -213,11 +304,12 @@ async fn process_signal_task(
                 "{}::source::{}::price::{}",
                 signal_task.signal_id(),
                 source.source_id,
-                routed.map_or("None".to_string(), |v| v.to_string())
+                routed.map_or("None".to_string(), |(v, _)| v.to_string())
             );
 
-            if let Some(routed_price) = routed {
+            if let Some((routed_price, timestamp)) = routed {
                 data.push(routed_price);
+                timestamps.push(timestamp);
             }
         } else {
             // TODO: Refactor logging packages into helper
@@ -234,7 +326,13 @@ async fn process_signal_task(
         .await
         .into_iter()
         .map(|v| v?.ok())
-        .collect::<Option<Vec<f64>>>();
+        .collect::<Option<Vec<TimedPrice>>>()
+        .map(|data| {
+            timestamps.extend(data.iter().map(|(_, timestamp)| *timestamp));
+            data.into_iter()
+                .map(|(price, _)| price)
+                .collect::<Vec<f64>>()
+        });
 
     // TODO: Refactor logging packages into helper
     let vec_str = match &prerequisites_data {
@@ -271,6 +369,9 @@ async fn process_signal_task(
         }
     };
 
+    // The price is as old as the oldest data it was computed from.
+    let timestamp = timestamps.into_iter().min().unwrap_or_default();
+
     match processed_price {
         Ok(processed_price) => match signal_task.execute_post_processors(processed_price) {
             Some(post_processed_price) => {
@@ -279,7 +380,7 @@ async fn process_signal_task(
                     signal_task.signal_id(),
                     post_processed_price
                 );
-                Ok(post_processed_price)
+                Ok((post_processed_price, timestamp))
             }
             None => {
                 debug!("{}::post_processed_price::None", signal_task.signal_id());
@@ -298,6 +399,7 @@ async fn handle_tasks(
     service_map: &Mutex<ServiceMap<Box<dyn CoreService>>>,
     source_results_store: Arc<SourceResultsStore>,
     signal_results_store: Arc<SignalResultsStore>,
+    source_stats: Arc<SourceStatsStore>,
     current_time: i64,
     stale_threshold: u64,
 ) {
@@ -309,18 +411,26 @@ async fn handle_tasks(
         if let Some(service) = locked_service_map.get_mut(task.source_name()) {
             let cloned_service = service.clone();
             let cloned_source_store = source_results_store.clone();
+            let cloned_source_stats = source_stats.clone();
             task_set.spawn(async move {
                 let mut locked_service = cloned_service.lock().await;
+                let start = Instant::now();
                 let results = cloned_task.get_prices(&mut locked_service).await;
-                store_source_data(
+                let latency = start.elapsed();
+
+                let source_ids = cloned_task.source_ids();
+                let fresh = store_source_data(
                     cloned_task.source_name(),
-                    cloned_task.source_ids().as_slice(),
+                    source_ids.as_slice(),
                     results,
                     cloned_source_store,
                     current_time,
                     stale_threshold,
                 )
-                .await
+                .await;
+                cloned_source_stats
+                    .record(cloned_task.source_name(), latency, source_ids.len(), fresh)
+                    .await;
             });
         }
     });
@@ -360,28 +470,80 @@ async fn set_unavailable(ids: &[&str], store: Arc<SignalResultsStore>) {
     store.set_batched(results).await;
 }
 
-async fn get_result_from_store(ids: &[&str], store: Arc<SignalResultsStore>) -> Vec<PriceData> {
+/// Converts the results of the given ids to price data, with the given status for the ids that
+/// have no result.
+async fn get_result_from_store(
+    ids: &[&str],
+    store: Arc<SignalResultsStore>,
+    missing: PriceStatus,
+) -> Vec<PriceData> {
     store
         .get_batched(ids)
         .await
         .into_iter()
         .zip(ids)
         .map(|(v, k)| match v {
-            Some(Ok(price)) => PriceData {
-                signal_id: k.to_string(),
-                price: price.to_string(),
-                price_status: PriceStatus::Available.into(),
-            },
+            Some(Ok((price, timestamp))) => {
+                let price = price.to_string();
+                PriceData {
+                    signal_id: k.to_string(),
+                    fixed_price: Some(fixed_point(&price)),
+                    price,
+                    price_status: PriceStatus::Available.into(),
+                    timestamp,
+                }
+            }
             Some(Err(e)) => PriceData {
                 signal_id: k.to_string(),
                 price: "".to_string(),
                 price_status: e.into(),
+                fixed_price: None,
+                timestamp: 0,
             },
             None => PriceData {
                 signal_id: k.to_string(),
                 price: "".to_string(),
-                price_status: PriceStatus::Unsupported.into(),
+                price_status: missing.into(),
+                fixed_price: None,
+                timestamp: 0,
             },
         })
         .collect()
 }
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[tokio::test]
+    async fn test_cached_prices() {
+        let computed = SignalResultsStore::new();
+        computed.set("BTC", Ok((67012.125, 1_700_000_000))).await;
+        computed.set("XYZ", Err(PriceStatus::Unsupported)).await;
+        let latest = Arc::new(SignalResultsStore::new());
+        latest.merge(&computed).await;
+
+        let prices = CachedPrices { latest }
+            .get_prices(&["BTC", "XYZ", "ETH"])
+            .await;
+        let statuses = prices.iter().map(|p| p.price_status).collect::<Vec<i32>>();
+        let expected: Vec<i32> = vec![
+            PriceStatus::Available.into(),
+            PriceStatus::Unsupported.into(),
+            PriceStatus::Unavailable.into(),
+        ];
+        assert_eq!(statuses, expected);
+        assert_eq!(prices[0].timestamp, 1_700_000_000);
+    }
+
+    #[tokio::test]
+    async fn test_cached_active_ids() {
+        let latest = Arc::new(SignalResultsStore::new());
+        latest.set("ETH", Ok((3421.5, 1_700_000_000))).await;
+        latest.set("BTC", Err(PriceStatus::Unavailable)).await;
+        latest.set("XYZ", Err(PriceStatus::Unsupported)).await;
+
+        let ids = CachedPrices { latest }.active_ids().await;
+        assert_eq!(ids, vec!["BTC".to_string(), "ETH".to_string()]);
+    }
+}
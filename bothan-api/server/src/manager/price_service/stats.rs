@@ -0,0 +1,148 @@
+use std::cmp::Ordering;
+use std::collections::HashMap;
+use std::time::Duration;
+
+use tokio::sync::Mutex;
+
+use crate::proto::query::SourceStats;
+
+/// The latency and success counts of a source, accumulated over its queries.
+#[derive(Debug, Default, Clone, PartialEq)]
+pub(crate) struct SourceStatsAccumulator {
+    queries: u64,
+    total_latency: Duration,
+    max_latency: Duration,
+    requested_prices: u64,
+    fresh_prices: u64,
+}
+
+impl SourceStatsAccumulator {
+    /// Records a query of the source that took `latency` and returned `fresh` of the
+    /// `requested` prices.
+    pub(crate) fn record(&mut self, latency: Duration, requested: usize, fresh: usize) {
+        self.queries += 1;
+        self.total_latency += latency;
+        self.max_latency = self.max_latency.max(latency);
+        self.requested_prices += requested as u64;
+        self.fresh_prices += fresh as u64;
+    }
+
+    /// Converts the accumulated counts into the [`SourceStats`] of the given source.
+    pub(crate) fn to_source_stats(&self, source_id: &str) -> SourceStats {
+        let mean_latency_us = match self.queries {
+            0 => 0,
+            queries => (self.total_latency.as_micros() / queries as u128) as u64,
+        };
+        let success_rate = match self.requested_prices {
+            0 => 0.0,
+            requested => self.fresh_prices as f64 / requested as f64,
+        };
+
+        SourceStats {
+            source_id: source_id.to_string(),
+            queries: self.queries,
+            mean_latency_us,
+            max_latency_us: self.max_latency.as_micros() as u64,
+            requested_prices: self.requested_prices,
+            fresh_prices: self.fresh_prices,
+            success_rate,
+        }
+    }
+}
+
+/// A store of the stats of every source queried by the price service manager.
+pub(crate) struct SourceStatsStore {
+    stats: Mutex<HashMap<String, SourceStatsAccumulator>>,
+}
+
+impl SourceStatsStore {
+    /// Creates a new, empty `SourceStatsStore`.
+    pub(crate) fn new() -> Self {
+        Self {
+            stats: Mutex::new(HashMap::new()),
+        }
+    }
+
+    /// Records a query of the given source.
+    pub(crate) async fn record(
+        &self,
+        source_id: &str,
+        latency: Duration,
+        requested: usize,
+        fresh: usize,
+    ) {
+        let mut stats = self.stats.lock().await;
+        stats
+            .entry(source_id.to_string())
+            .or_default()
+            .record(latency, requested, fresh);
+    }
+
+    /// Returns the stats of all sources, best first, see [`rank`].
+    pub(crate) async fn leaderboard(&self) -> Vec<SourceStats> {
+        let mut leaderboard = self
+            .stats
+            .lock()
+            .await
+            .iter()
+            .map(|(source_id, stats)| stats.to_source_stats(source_id))
+            .collect::<Vec<SourceStats>>();
+        leaderboard.sort_by(rank);
+        leaderboard
+    }
+}
+
+/// Orders sources by descending success rate, then by ascending mean latency, and then by
+/// source id.
+fn rank(a: &SourceStats, b: &SourceStats) -> Ordering {
+    b.success_rate
+        .total_cmp(&a.success_rate)
+        .then(a.mean_latency_us.cmp(&b.mean_latency_us))
+        .then_with(|| a.source_id.cmp(&b.source_id))
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_to_source_stats() {
+        let mut stats = SourceStatsAccumulator::default();
+        stats.record(Duration::from_micros(100), 4, 4);
+        stats.record(Duration::from_micros(300), 4, 2);
+
+        let expected = SourceStats {
+            source_id: "binance".to_string(),
+            queries: 2,
+            mean_latency_us: 200,
+            max_latency_us: 300,
+            requested_prices: 8,
+            fresh_prices: 6,
+            success_rate: 0.75,
+        };
+        assert_eq!(stats.to_source_stats("binance"), expected);
+    }
+
+    #[test]
+    fn test_to_source_stats_without_queries() {
+        let stats = SourceStatsAccumulator::default().to_source_stats("binance");
+        assert_eq!(stats.mean_latency_us, 0);
+        assert_eq!(stats.success_rate, 0.0);
+    }
+
+    #[tokio::test]
+    async fn test_leaderboard() {
+        let store = SourceStatsStore::new();
+        store.record("slow", Duration::from_millis(20), 2, 2).await;
+        store.record("fast", Duration::from_millis(1), 2, 2).await;
+        store.record("flaky", Duration::from_millis(1), 2, 1).await;
+
+        let ids = store
+            .leaderboard()
+            .await
+            .into_iter()
+            .map(|stats| stats.source_id)
+            .collect::<Vec<String>>();
+        assert_eq!(ids, vec!["fast", "slow", "flaky"]);
+    }
+}
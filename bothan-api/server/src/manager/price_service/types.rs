@@ -6,11 +6,15 @@ use tokio::sync::{Mutex, RwLock};
 
 use crate::proto::query::PriceStatus;
 
+/// Type alias for a price and the unix time in seconds of the oldest source data it was
+/// computed from.
+pub(crate) type TimedPrice = (f64, u64);
+
 /// Type alias for a store of results from a source.
-pub(crate) type SourceResultsStore = ResultsStore<f64>;
+pub(crate) type SourceResultsStore = ResultsStore<TimedPrice>;
 
 /// Type alias for a store of results from a signal.
-pub(crate) type SignalResultsStore = ResultsStore<Result<f64, PriceStatus>>;
+pub(crate) type SignalResultsStore = ResultsStore<Result<TimedPrice, PriceStatus>>;
 
 /// Type alias for a map of services.
 pub(crate) type ServiceMap<T> = HashMap<String, Arc<Mutex<T>>>;
@@ -42,12 +46,32 @@ impl<T: Clone> ResultsStore<T> {
             .collect()
     }
 
+    /// Returns the keys whose value matches the predicate, in no particular order.
+    pub(crate) async fn keys_where<F: Fn(&T) -> bool>(&self, predicate: F) -> Vec<String> {
+        let reader = self.store.read().await;
+        reader
+            .iter()
+            .filter(|(_, v)| predicate(v))
+            .map(|(k, _)| k.clone())
+            .collect()
+    }
+
     /// Sets a value in the store by key.
     pub(crate) async fn set<K: Into<String>>(&self, key: K, value: T) {
         let mut writer = self.store.write().await;
         writer.insert(key.into(), value);
     }
 
+    /// Copies all values of the other store into this store, replacing the values of the same
+    /// keys.
+    pub(crate) async fn merge(&self, other: &ResultsStore<T>) {
+        let reader = other.store.read().await;
+        let mut writer = self.store.write().await;
+        for (k, v) in reader.iter() {
+            writer.insert(k.clone(), v.clone());
+        }
+    }
+
     /// Sets multiple values in the store by keys.
     pub(crate) async fn set_batched<K: Into<String>>(&self, values: Vec<(K, T)>) {
         let mut writer = self.store.write().await;
@@ -1,3 +1,49 @@
+use crate::proto::query::FixedPoint;
+
 pub(crate) fn into_key(source_id: &str, id: &str) -> String {
     format!("{}-{}", source_id, id)
 }
+
+/// Returns the fixed-point representation of a price formatted by `f64::to_string`, which
+/// never uses an exponent, e.g. mantissa "67012125" with scale 3 for "67012.125".
+pub(crate) fn fixed_point(price: &str) -> FixedPoint {
+    let (sign, digits) = match price.strip_prefix('-') {
+        Some(digits) => ("-", digits),
+        None => ("", price),
+    };
+    let (integer, fraction) = digits.split_once('.').unwrap_or((digits, ""));
+
+    let mantissa = format!("{}{}", integer, fraction);
+    let mantissa = match mantissa.trim_start_matches('0') {
+        "" => "0".to_string(),
+        trimmed => format!("{}{}", sign, trimmed),
+    };
+
+    FixedPoint {
+        mantissa,
+        scale: fraction.len() as i32,
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_fixed_point() {
+        let cases = [
+            (67012.125, "67012125", 3),
+            (0.00012, "12", 5),
+            (100.0, "100", 0),
+            (-1.5, "-15", 1),
+            (0.0, "0", 0),
+        ];
+        for (price, mantissa, scale) in cases {
+            let expected = FixedPoint {
+                mantissa: mantissa.to_string(),
+                scale,
+            };
+            assert_eq!(fixed_point(&price.to_string()), expected);
+        }
+    }
+}
@@ -4,8 +4,20 @@
 #[allow(clippy::derive_partial_eq_without_eq)]
 #[derive(Clone, PartialEq, ::prost::Message)]
 pub struct QueryPricesRequest {
+    /// The signal ids to query. An id of the form "@name" refers to the signal group
+    /// of that name configured on the server and is expanded to its signal ids.
     #[prost(string, repeated, tag="1")]
     pub signal_ids: ::prost::alloc::vec::Vec<::prost::alloc::string::String>,
+    /// The statuses of the prices to return. Prices with any other status are left
+    /// out of the response; all prices are returned if it is empty.
+    #[prost(enumeration="PriceStatus", repeated, tag="2")]
+    pub statuses: ::prost::alloc::vec::Vec<i32>,
+    /// Whether to reject the request with NOT_FOUND if any signal id is not in the
+    /// registry, e.g. because it is misspelled, instead of returning its price as
+    /// PRICE_STATUS_UNSUPPORTED. The unknown signal ids are sent in the
+    /// "bothan-unknown-signal-ids" response metadata, separated by commas.
+    #[prost(bool, tag="3")]
+    pub strict: bool,
 }
 /// QueryPricesResponse is the response type for the PriceService/GetPrices RPC
 /// method.
@@ -14,6 +26,198 @@ pub struct QueryPricesRequest {
 pub struct QueryPricesResponse {
     #[prost(message, repeated, tag="1")]
     pub prices: ::prost::alloc::vec::Vec<PriceData>,
+    /// The expansions of the signal groups referenced in the request.
+    #[prost(message, repeated, tag="2")]
+    pub expansions: ::prost::alloc::vec::Vec<GroupExpansion>,
+    /// The time the server spent on the request.
+    #[prost(message, optional, tag="3")]
+    pub server_timing: ::core::option::Option<ServerTiming>,
+}
+/// ServerTiming breaks down the time the server spent on a request, so that it can
+/// be told apart from network latency.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct ServerTiming {
+    /// The time the request waited for the price manager, in microseconds.
+    #[prost(uint64, tag="1")]
+    pub queue_time_us: u64,
+    /// The time spent handling the request besides waiting, in microseconds.
+    #[prost(uint64, tag="2")]
+    pub processing_time_us: u64,
+}
+/// GroupExpansion defines the signal ids a signal group was expanded to.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct GroupExpansion {
+    /// The referenced group, including the leading "@".
+    #[prost(string, tag="1")]
+    pub group: ::prost::alloc::string::String,
+    /// The signal ids of the group.
+    #[prost(string, repeated, tag="2")]
+    pub signal_ids: ::prost::alloc::vec::Vec<::prost::alloc::string::String>,
+}
+/// QuerySignalsRequest is the request type for the Query/Signals RPC method.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct QuerySignalsRequest {
+    /// The page_token of a previous response to continue listing from, or empty to
+    /// start from the first signal id.
+    #[prost(string, tag="1")]
+    pub page_token: ::prost::alloc::string::String,
+    /// The maximum number of signals to return. The server picks a default if it is
+    /// zero and caps it to a maximum.
+    #[prost(uint32, tag="2")]
+    pub page_size: u32,
+}
+/// QuerySignalsResponse is the response type for the Query/Signals RPC method.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct QuerySignalsResponse {
+    /// The signals of the page.
+    #[prost(message, repeated, tag="1")]
+    pub signals: ::prost::alloc::vec::Vec<SignalInfo>,
+    /// The token of the next page, or empty if this is the last page.
+    #[prost(string, tag="2")]
+    pub next_page_token: ::prost::alloc::string::String,
+}
+/// SignalInfo defines a signal known to the registry.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct SignalInfo {
+    /// The signal id.
+    #[prost(string, tag="1")]
+    pub signal_id: ::prost::alloc::string::String,
+    /// PriceStatus defines the price status of the signal as of its last price
+    /// query, so that listing the signals queries no source. Signals that were
+    /// never queried are unavailable.
+    #[prost(enumeration="PriceStatus", tag="2")]
+    pub price_status: i32,
+    /// The base asset of the signal in upper case, e.g. "BTC" for "CS:BTC-USD" and
+    /// "crypto_price.btcusd". Empty if the signal id does not follow a known form.
+    #[prost(string, tag="3")]
+    pub base: ::prost::alloc::string::String,
+    /// The quote asset of the signal in upper case, e.g. "USD" for "CS:BTC-USD" and
+    /// "crypto_price.btcusd". Empty if the signal id does not follow a known form.
+    #[prost(string, tag="4")]
+    pub quote: ::prost::alloc::string::String,
+    /// Whether the server keeps the signal up to date, as listed by ActiveSignals.
+    #[prost(bool, tag="5")]
+    pub active: bool,
+}
+/// QuerySourceStatsRequest is the request type for the Query/SourceStats RPC
+/// method.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct QuerySourceStatsRequest {
+}
+/// QuerySourceStatsResponse is the response type for the Query/SourceStats RPC
+/// method.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct QuerySourceStatsResponse {
+    /// The stats of every source that has been queried, ordered by descending
+    /// success rate and then by ascending mean latency.
+    #[prost(message, repeated, tag="1")]
+    pub sources: ::prost::alloc::vec::Vec<SourceStats>,
+}
+/// SourceStats defines the stats of a source since the server started.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct SourceStats {
+    /// The source id, as used in the registry.
+    #[prost(string, tag="1")]
+    pub source_id: ::prost::alloc::string::String,
+    /// The number of times the source was queried for prices.
+    #[prost(uint64, tag="2")]
+    pub queries: u64,
+    /// The mean time a query of the source took, in microseconds.
+    #[prost(uint64, tag="3")]
+    pub mean_latency_us: u64,
+    /// The longest time a query of the source took, in microseconds.
+    #[prost(uint64, tag="4")]
+    pub max_latency_us: u64,
+    /// The number of prices requested from the source.
+    #[prost(uint64, tag="5")]
+    pub requested_prices: u64,
+    /// The number of requested prices the source returned fresh.
+    #[prost(uint64, tag="6")]
+    pub fresh_prices: u64,
+    /// The share of requested prices the source returned fresh, from 0 to 1.
+    #[prost(double, tag="7")]
+    pub success_rate: f64,
+}
+/// QueryRegistryRequest is the request type for the Query/Registry RPC method.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct QueryRegistryRequest {
+}
+/// QueryRegistryResponse is the response type for the Query/Registry RPC method.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct QueryRegistryResponse {
+    /// The version of the registry, as configured on the server.
+    #[prost(string, tag="1")]
+    pub version: ::prost::alloc::string::String,
+    /// The hex encoded SHA-256 hash of the registry file the server loaded, the
+    /// same as the output of sha256sum for the file.
+    #[prost(string, tag="2")]
+    pub hash: ::prost::alloc::string::String,
+    /// The loaded registry as JSON, with the signals ordered by signal id.
+    #[prost(string, tag="3")]
+    pub registry: ::prost::alloc::string::String,
+}
+/// QueryStatsSummaryRequest is the request type for the Query/StatsSummary RPC
+/// method.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct QueryStatsSummaryRequest {
+}
+/// QueryStatsSummaryResponse is the response type for the Query/StatsSummary RPC
+/// method.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct QueryStatsSummaryResponse {
+    /// The number of signals of the registry.
+    #[prost(uint64, tag="1")]
+    pub total_signals: u64,
+    /// The number of signals whose price is available.
+    #[prost(uint64, tag="2")]
+    pub available_signals: u64,
+    /// The number of signals whose price is not available yet.
+    #[prost(uint64, tag="3")]
+    pub unavailable_signals: u64,
+    /// The number of signals of the registry that no source of the server supports.
+    #[prost(uint64, tag="8")]
+    pub unsupported_signals: u64,
+    /// The timestamp of the available price with the oldest source data, as unix
+    /// time in seconds, or zero if no price is available.
+    #[prost(uint64, tag="4")]
+    pub oldest_update: u64,
+    /// The timestamp of the available price with the newest source data, as unix
+    /// time in seconds, or zero if no price is available.
+    #[prost(uint64, tag="5")]
+    pub newest_update: u64,
+    /// The version of the registry, as configured on the server.
+    #[prost(string, tag="6")]
+    pub registry_version: ::prost::alloc::string::String,
+    /// The time since the server started, in seconds.
+    #[prost(uint64, tag="7")]
+    pub uptime_seconds: u64,
+}
+/// QueryActiveSignalsRequest is the request type for the Query/ActiveSignals RPC
+/// method.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct QueryActiveSignalsRequest {
+}
+/// QueryActiveSignalsResponse is the response type for the Query/ActiveSignals RPC
+/// method.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct QueryActiveSignalsResponse {
+    /// The active signal ids, ordered by signal id.
+    #[prost(string, repeated, tag="1")]
+    pub signal_ids: ::prost::alloc::vec::Vec<::prost::alloc::string::String>,
 }
 /// PriceData defines the data of a symbol price.
 #[allow(clippy::derive_partial_eq_without_eq)]
@@ -28,6 +232,27 @@ pub struct PriceData {
     /// PriceStatus defines the price status of a symbol.
     #[prost(enumeration="PriceStatus", tag="3")]
     pub price_status: i32,
+    /// The price as a fixed-point number, set if the price is available. Unlike a
+    /// price with an implicit multiplier, it states its own scale.
+    #[prost(message, optional, tag="4")]
+    pub fixed_price: ::core::option::Option<FixedPoint>,
+    /// The unix time in seconds of the oldest source data the price was computed
+    /// from, set if the price is available.
+    #[prost(uint64, tag="5")]
+    pub timestamp: u64,
+}
+/// FixedPoint is a decimal number as an integer mantissa and a scale, so that the
+/// number is mantissa × 10^-scale, e.g. 67012.125 is mantissa "67012125" with
+/// scale 3.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct FixedPoint {
+    /// The mantissa in base 10, with a leading '-' if the number is negative.
+    #[prost(string, tag="1")]
+    pub mantissa: ::prost::alloc::string::String,
+    /// The number of digits of the mantissa after the decimal point.
+    #[prost(int32, tag="2")]
+    pub scale: i32,
 }
 /// PriceOption defines the price option of a price.
 #[derive(Clone, Copy, Debug, PartialEq, Eq, Hash, PartialOrd, Ord, ::prost::Enumeration)]
@@ -106,6 +106,116 @@ pub mod query_client {
             req.extensions_mut().insert(GrpcMethod::new("query.Query", "Prices"));
             self.inner.unary(req, path, codec).await
         }
+        pub async fn signals(
+            &mut self,
+            request: impl tonic::IntoRequest<super::QuerySignalsRequest>,
+        ) -> std::result::Result<
+            tonic::Response<super::QuerySignalsResponse>,
+            tonic::Status,
+        > {
+            self.inner
+                .ready()
+                .await
+                .map_err(|e| {
+                    tonic::Status::new(
+                        tonic::Code::Unknown,
+                        format!("Service was not ready: {}", e.into()),
+                    )
+                })?;
+            let codec = tonic::codec::ProstCodec::default();
+            let path = http::uri::PathAndQuery::from_static("/query.Query/Signals");
+            let mut req = request.into_request();
+            req.extensions_mut().insert(GrpcMethod::new("query.Query", "Signals"));
+            self.inner.unary(req, path, codec).await
+        }
+        pub async fn source_stats(
+            &mut self,
+            request: impl tonic::IntoRequest<super::QuerySourceStatsRequest>,
+        ) -> std::result::Result<
+            tonic::Response<super::QuerySourceStatsResponse>,
+            tonic::Status,
+        > {
+            self.inner
+                .ready()
+                .await
+                .map_err(|e| {
+                    tonic::Status::new(
+                        tonic::Code::Unknown,
+                        format!("Service was not ready: {}", e.into()),
+                    )
+                })?;
+            let codec = tonic::codec::ProstCodec::default();
+            let path = http::uri::PathAndQuery::from_static("/query.Query/SourceStats");
+            let mut req = request.into_request();
+            req.extensions_mut().insert(GrpcMethod::new("query.Query", "SourceStats"));
+            self.inner.unary(req, path, codec).await
+        }
+        pub async fn registry(
+            &mut self,
+            request: impl tonic::IntoRequest<super::QueryRegistryRequest>,
+        ) -> std::result::Result<
+            tonic::Response<super::QueryRegistryResponse>,
+            tonic::Status,
+        > {
+            self.inner
+                .ready()
+                .await
+                .map_err(|e| {
+                    tonic::Status::new(
+                        tonic::Code::Unknown,
+                        format!("Service was not ready: {}", e.into()),
+                    )
+                })?;
+            let codec = tonic::codec::ProstCodec::default();
+            let path = http::uri::PathAndQuery::from_static("/query.Query/Registry");
+            let mut req = request.into_request();
+            req.extensions_mut().insert(GrpcMethod::new("query.Query", "Registry"));
+            self.inner.unary(req, path, codec).await
+        }
+        pub async fn stats_summary(
+            &mut self,
+            request: impl tonic::IntoRequest<super::QueryStatsSummaryRequest>,
+        ) -> std::result::Result<
+            tonic::Response<super::QueryStatsSummaryResponse>,
+            tonic::Status,
+        > {
+            self.inner
+                .ready()
+                .await
+                .map_err(|e| {
+                    tonic::Status::new(
+                        tonic::Code::Unknown,
+                        format!("Service was not ready: {}", e.into()),
+                    )
+                })?;
+            let codec = tonic::codec::ProstCodec::default();
+            let path = http::uri::PathAndQuery::from_static("/query.Query/StatsSummary");
+            let mut req = request.into_request();
+            req.extensions_mut().insert(GrpcMethod::new("query.Query", "StatsSummary"));
+            self.inner.unary(req, path, codec).await
+        }
+        pub async fn active_signals(
+            &mut self,
+            request: impl tonic::IntoRequest<super::QueryActiveSignalsRequest>,
+        ) -> std::result::Result<
+            tonic::Response<super::QueryActiveSignalsResponse>,
+            tonic::Status,
+        > {
+            self.inner
+                .ready()
+                .await
+                .map_err(|e| {
+                    tonic::Status::new(
+                        tonic::Code::Unknown,
+                        format!("Service was not ready: {}", e.into()),
+                    )
+                })?;
+            let codec = tonic::codec::ProstCodec::default();
+            let path = http::uri::PathAndQuery::from_static("/query.Query/ActiveSignals");
+            let mut req = request.into_request();
+            req.extensions_mut().insert(GrpcMethod::new("query.Query", "ActiveSignals"));
+            self.inner.unary(req, path, codec).await
+        }
     }
 }
 /// Generated server implementations.
@@ -122,6 +232,41 @@ pub mod query_server {
             tonic::Response<super::QueryPricesResponse>,
             tonic::Status,
         >;
+        async fn signals(
+            &self,
+            request: tonic::Request<super::QuerySignalsRequest>,
+        ) -> std::result::Result<
+            tonic::Response<super::QuerySignalsResponse>,
+            tonic::Status,
+        >;
+        async fn source_stats(
+            &self,
+            request: tonic::Request<super::QuerySourceStatsRequest>,
+        ) -> std::result::Result<
+            tonic::Response<super::QuerySourceStatsResponse>,
+            tonic::Status,
+        >;
+        async fn registry(
+            &self,
+            request: tonic::Request<super::QueryRegistryRequest>,
+        ) -> std::result::Result<
+            tonic::Response<super::QueryRegistryResponse>,
+            tonic::Status,
+        >;
+        async fn stats_summary(
+            &self,
+            request: tonic::Request<super::QueryStatsSummaryRequest>,
+        ) -> std::result::Result<
+            tonic::Response<super::QueryStatsSummaryResponse>,
+            tonic::Status,
+        >;
+        async fn active_signals(
+            &self,
+            request: tonic::Request<super::QueryActiveSignalsRequest>,
+        ) -> std::result::Result<
+            tonic::Response<super::QueryActiveSignalsResponse>,
+            tonic::Status,
+        >;
     }
     #[derive(Debug)]
     pub struct QueryServer<T: Query> {
@@ -246,6 +391,226 @@ pub mod query_server {
                     };
                     Box::pin(fut)
                 }
+                "/query.Query/Signals" => {
+                    #[allow(non_camel_case_types)]
+                    struct SignalsSvc<T: Query>(pub Arc<T>);
+                    impl<T: Query> tonic::server::UnaryService<super::QuerySignalsRequest>
+                    for SignalsSvc<T> {
+                        type Response = super::QuerySignalsResponse;
+                        type Future = BoxFuture<
+                            tonic::Response<Self::Response>,
+                            tonic::Status,
+                        >;
+                        fn call(
+                            &mut self,
+                            request: tonic::Request<super::QuerySignalsRequest>,
+                        ) -> Self::Future {
+                            let inner = Arc::clone(&self.0);
+                            let fut = async move {
+                                <T as Query>::signals(&inner, request).await
+                            };
+                            Box::pin(fut)
+                        }
+                    }
+                    let accept_compression_encodings = self.accept_compression_encodings;
+                    let send_compression_encodings = self.send_compression_encodings;
+                    let max_decoding_message_size = self.max_decoding_message_size;
+                    let max_encoding_message_size = self.max_encoding_message_size;
+                    let inner = self.inner.clone();
+                    let fut = async move {
+                        let inner = inner.0;
+                        let method = SignalsSvc(inner);
+                        let codec = tonic::codec::ProstCodec::default();
+                        let mut grpc = tonic::server::Grpc::new(codec)
+                            .apply_compression_config(
+                                accept_compression_encodings,
+                                send_compression_encodings,
+                            )
+                            .apply_max_message_size_config(
+                                max_decoding_message_size,
+                                max_encoding_message_size,
+                            );
+                        let res = grpc.unary(method, req).await;
+                        Ok(res)
+                    };
+                    Box::pin(fut)
+                }
+                "/query.Query/SourceStats" => {
+                    #[allow(non_camel_case_types)]
+                    struct SourceStatsSvc<T: Query>(pub Arc<T>);
+                    impl<T: Query> tonic::server::UnaryService<super::QuerySourceStatsRequest>
+                    for SourceStatsSvc<T> {
+                        type Response = super::QuerySourceStatsResponse;
+                        type Future = BoxFuture<
+                            tonic::Response<Self::Response>,
+                            tonic::Status,
+                        >;
+                        fn call(
+                            &mut self,
+                            request: tonic::Request<super::QuerySourceStatsRequest>,
+                        ) -> Self::Future {
+                            let inner = Arc::clone(&self.0);
+                            let fut = async move {
+                                <T as Query>::source_stats(&inner, request).await
+                            };
+                            Box::pin(fut)
+                        }
+                    }
+                    let accept_compression_encodings = self.accept_compression_encodings;
+                    let send_compression_encodings = self.send_compression_encodings;
+                    let max_decoding_message_size = self.max_decoding_message_size;
+                    let max_encoding_message_size = self.max_encoding_message_size;
+                    let inner = self.inner.clone();
+                    let fut = async move {
+                        let inner = inner.0;
+                        let method = SourceStatsSvc(inner);
+                        let codec = tonic::codec::ProstCodec::default();
+                        let mut grpc = tonic::server::Grpc::new(codec)
+                            .apply_compression_config(
+                                accept_compression_encodings,
+                                send_compression_encodings,
+                            )
+                            .apply_max_message_size_config(
+                                max_decoding_message_size,
+                                max_encoding_message_size,
+                            );
+                        let res = grpc.unary(method, req).await;
+                        Ok(res)
+                    };
+                    Box::pin(fut)
+                }
+                "/query.Query/Registry" => {
+                    #[allow(non_camel_case_types)]
+                    struct RegistrySvc<T: Query>(pub Arc<T>);
+                    impl<T: Query> tonic::server::UnaryService<super::QueryRegistryRequest>
+                    for RegistrySvc<T> {
+                        type Response = super::QueryRegistryResponse;
+                        type Future = BoxFuture<
+                            tonic::Response<Self::Response>,
+                            tonic::Status,
+                        >;
+                        fn call(
+                            &mut self,
+                            request: tonic::Request<super::QueryRegistryRequest>,
+                        ) -> Self::Future {
+                            let inner = Arc::clone(&self.0);
+                            let fut = async move {
+                                <T as Query>::registry(&inner, request).await
+                            };
+                            Box::pin(fut)
+                        }
+                    }
+                    let accept_compression_encodings = self.accept_compression_encodings;
+                    let send_compression_encodings = self.send_compression_encodings;
+                    let max_decoding_message_size = self.max_decoding_message_size;
+                    let max_encoding_message_size = self.max_encoding_message_size;
+                    let inner = self.inner.clone();
+                    let fut = async move {
+                        let inner = inner.0;
+                        let method = RegistrySvc(inner);
+                        let codec = tonic::codec::ProstCodec::default();
+                        let mut grpc = tonic::server::Grpc::new(codec)
+                            .apply_compression_config(
+                                accept_compression_encodings,
+                                send_compression_encodings,
+                            )
+                            .apply_max_message_size_config(
+                                max_decoding_message_size,
+                                max_encoding_message_size,
+                            );
+                        let res = grpc.unary(method, req).await;
+                        Ok(res)
+                    };
+                    Box::pin(fut)
+                }
+                "/query.Query/StatsSummary" => {
+                    #[allow(non_camel_case_types)]
+                    struct StatsSummarySvc<T: Query>(pub Arc<T>);
+                    impl<T: Query> tonic::server::UnaryService<super::QueryStatsSummaryRequest>
+                    for StatsSummarySvc<T> {
+                        type Response = super::QueryStatsSummaryResponse;
+                        type Future = BoxFuture<
+                            tonic::Response<Self::Response>,
+                            tonic::Status,
+                        >;
+                        fn call(
+                            &mut self,
+                            request: tonic::Request<super::QueryStatsSummaryRequest>,
+                        ) -> Self::Future {
+                            let inner = Arc::clone(&self.0);
+                            let fut = async move {
+                                <T as Query>::stats_summary(&inner, request).await
+                            };
+                            Box::pin(fut)
+                        }
+                    }
+                    let accept_compression_encodings = self.accept_compression_encodings;
+                    let send_compression_encodings = self.send_compression_encodings;
+                    let max_decoding_message_size = self.max_decoding_message_size;
+                    let max_encoding_message_size = self.max_encoding_message_size;
+                    let inner = self.inner.clone();
+                    let fut = async move {
+                        let inner = inner.0;
+                        let method = StatsSummarySvc(inner);
+                        let codec = tonic::codec::ProstCodec::default();
+                        let mut grpc = tonic::server::Grpc::new(codec)
+                            .apply_compression_config(
+                                accept_compression_encodings,
+                                send_compression_encodings,
+                            )
+                            .apply_max_message_size_config(
+                                max_decoding_message_size,
+                                max_encoding_message_size,
+                            );
+                        let res = grpc.unary(method, req).await;
+                        Ok(res)
+                    };
+                    Box::pin(fut)
+                }
+                "/query.Query/ActiveSignals" => {
+                    #[allow(non_camel_case_types)]
+                    struct ActiveSignalsSvc<T: Query>(pub Arc<T>);
+                    impl<T: Query> tonic::server::UnaryService<super::QueryActiveSignalsRequest>
+                    for ActiveSignalsSvc<T> {
+                        type Response = super::QueryActiveSignalsResponse;
+                        type Future = BoxFuture<
+                            tonic::Response<Self::Response>,
+                            tonic::Status,
+                        >;
+                        fn call(
+                            &mut self,
+                            request: tonic::Request<super::QueryActiveSignalsRequest>,
+                        ) -> Self::Future {
+                            let inner = Arc::clone(&self.0);
+                            let fut = async move {
+                                <T as Query>::active_signals(&inner, request).await
+                            };
+                            Box::pin(fut)
+                        }
+                    }
+                    let accept_compression_encodings = self.accept_compression_encodings;
+                    let send_compression_encodings = self.send_compression_encodings;
+                    let max_decoding_message_size = self.max_decoding_message_size;
+                    let max_encoding_message_size = self.max_encoding_message_size;
+                    let inner = self.inner.clone();
+                    let fut = async move {
+                        let inner = inner.0;
+                        let method = ActiveSignalsSvc(inner);
+                        let codec = tonic::codec::ProstCodec::default();
+                        let mut grpc = tonic::server::Grpc::new(codec)
+                            .apply_compression_config(
+                                accept_compression_encodings,
+                                send_compression_encodings,
+                            )
+                            .apply_max_message_size_config(
+                                max_decoding_message_size,
+                                max_encoding_message_size,
+                            );
+                        let res = grpc.unary(method, req).await;
+                        Ok(res)
+                    };
+                    Box::pin(fut)
+                }
                 _ => {
                     Box::pin(async move {
                         Ok(
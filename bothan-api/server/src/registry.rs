@@ -10,6 +10,7 @@ use crate::registry::source::Source;
 use crate::tasks::utils::get_tasks;
 
 pub mod source;
+pub mod unit;
 
 /// The `Registry` type is a HashMap that maps a signal name to its corresponding `Signal`.
 pub type Registry = HashMap<String, Signal>;
@@ -0,0 +1,69 @@
+/// The quote assets recognized at the end of ids of the form `category.basequote`, longest
+/// first so that e.g. `usdt` is not taken for `usd`.
+const QUOTES: [&str; 6] = ["usdt", "usdc", "usd", "eur", "btc", "eth"];
+
+/// The base and quote assets of a signal, in upper case.
+#[derive(Debug, Clone, PartialEq, Eq)]
+pub struct SignalUnit {
+    pub base: String,
+    pub quote: String,
+}
+
+/// Parses the base and quote assets from a signal id of the form `CS:BTC-USD` or
+/// `crypto_price.btcusd`. Returns `None` if the id follows neither form.
+pub fn parse_signal_unit(signal_id: &str) -> Option<SignalUnit> {
+    if let Some((_, pair)) = signal_id.split_once(':') {
+        let (base, quote) = pair.split_once('-')?;
+        return new_unit(base, quote);
+    }
+
+    let (_, pair) = signal_id.split_once('.')?;
+    let quote = QUOTES.iter().find(|quote| pair.ends_with(*quote))?;
+    new_unit(&pair[..pair.len() - quote.len()], quote)
+}
+
+fn new_unit(base: &str, quote: &str) -> Option<SignalUnit> {
+    if base.is_empty() || quote.is_empty() {
+        return None;
+    }
+
+    Some(SignalUnit {
+        base: base.to_uppercase(),
+        quote: quote.to_uppercase(),
+    })
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    fn unit(base: &str, quote: &str) -> Option<SignalUnit> {
+        Some(SignalUnit {
+            base: base.to_string(),
+            quote: quote.to_string(),
+        })
+    }
+
+    #[test]
+    fn test_parse_signal_unit() {
+        assert_eq!(parse_signal_unit("CS:BTC-USD"), unit("BTC", "USD"));
+        assert_eq!(parse_signal_unit("crypto_price.btcusd"), unit("BTC", "USD"));
+        assert_eq!(
+            parse_signal_unit("crypto_price.usdtusd"),
+            unit("USDT", "USD")
+        );
+        assert_eq!(
+            parse_signal_unit("crypto_price.ethusdt"),
+            unit("ETH", "USDT")
+        );
+    }
+
+    #[test]
+    fn test_parse_signal_unit_with_unknown_format() {
+        assert_eq!(parse_signal_unit("btc"), None);
+        assert_eq!(parse_signal_unit("CS:BTC"), None);
+        assert_eq!(parse_signal_unit("CS:-USD"), None);
+        assert_eq!(parse_signal_unit("crypto_price.usd"), None);
+        assert_eq!(parse_signal_unit("crypto_price.btcjpy"), None);
+    }
+}